@@ -51,4 +51,13 @@ type Auth struct {
 	// Timeout is the timeout for establish an SSH connection.
 	// +optional
 	Timeout *time.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+
+	// HostKeyChecking defines how to verify the SSH host key, one of strict, tofu and insecure.
+	// The tofu mode trusts the key on first use and records it into the KnownHostsFile.
+	// +optional
+	HostKeyChecking string `yaml:"hostKeyChecking,omitempty" json:"hostKeyChecking,omitempty"`
+
+	// KnownHostsFile is the path to the known_hosts file used to verify the SSH host key.
+	// +optional
+	KnownHostsFile string `yaml:"knownHostsFile,omitempty" json:"knownHostsFile,omitempty"`
 }
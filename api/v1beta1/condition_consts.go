@@ -39,6 +39,14 @@ const (
 	WaitForDNSNameResolveReason = "WaitForDNSNameResolve"
 )
 
+const (
+	// ControlPlaneLoadBalancerReadyCondition reports on whether the load balancer provisioned by CAPKK serves the API server.
+	ControlPlaneLoadBalancerReadyCondition clusterv1.ConditionType = "ControlPlaneLoadBalancerReady"
+
+	// WaitingForLoadBalancerReason used while the control plane endpoint is not reachable.
+	WaitingForLoadBalancerReason = "WaitingForLoadBalancer"
+)
+
 const (
 	// CallKKInstanceInPlaceUpgradeCondition reports whether set up the InPlaceUpgradeVersionAnnotation annotation on all the KKInstance conditions.
 	CallKKInstanceInPlaceUpgradeCondition clusterv1.ConditionType = "CallKKInstanceInPlaceUpgrade"
@@ -105,6 +113,13 @@ const (
 	KKInstanceInstallCRIFailedReason = "InstallCRIFailed"
 )
 
+const (
+	// KKInstanceLoadBalancerReadyCondition reports on whether the load balancer provisioned by CAPKK is deployed on the instance.
+	KKInstanceLoadBalancerReadyCondition clusterv1.ConditionType = "InstanceLoadBalancerReady"
+	// KKInstanceDeployLoadBalancerFailedReason used when the instance couldn't deploy the load balancer.
+	KKInstanceDeployLoadBalancerFailedReason = "DeployLoadBalancerFailed"
+)
+
 const (
 	// KKInstanceProvisionedCondition reports on whether the instance is provisioned by cloud-init.
 	KKInstanceProvisionedCondition clusterv1.ConditionType = "InstanceProvisioned"
@@ -96,10 +96,92 @@ type InstanceInfo struct {
 	Auth Auth `json:"auth,omitempty"`
 }
 
+const (
+	// KubeVipLoadBalancer is the load balancer type which runs kube-vip on the control plane instances.
+	KubeVipLoadBalancer = "kube-vip"
+	// HaproxyLoadBalancer is the load balancer type which runs haproxy on the worker instances.
+	HaproxyLoadBalancer = "haproxy"
+
+	// KubeVipARPMode advertises the VIP with ARP.
+	KubeVipARPMode = "arp"
+	// KubeVipBGPMode advertises the VIP with BGP.
+	KubeVipBGPMode = "bgp"
+)
+
 // KKLoadBalancerSpec defines the desired state of an KK load balancer.
 type KKLoadBalancerSpec struct {
 	// The hostname on which the API server is serving.
+	// It is the VIP when the type is kube-vip.
 	Host string `json:"host,omitempty"`
+
+	// Type is the type of the load balancer provisioned by CAPKK, one of "kube-vip" and "haproxy".
+	// The load balancer is provided by the user when it is empty.
+	// +kubebuilder:validation:Enum=kube-vip;haproxy
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// KubeVip is the configuration of kube-vip, it is used when the type is kube-vip.
+	// +optional
+	KubeVip *KubeVipSpec `json:"kubeVip,omitempty"`
+
+	// Haproxy is the configuration of haproxy, it is used when the type is haproxy.
+	// +optional
+	Haproxy *HaproxySpec `json:"haproxy,omitempty"`
+}
+
+// KubeVipSpec defines the kube-vip which serves the VIP on the control plane instances.
+type KubeVipSpec struct {
+	// Mode is how the VIP is advertised, one of "arp" and "bgp".
+	// +kubebuilder:validation:Enum=arp;bgp
+	// +kubebuilder:default=arp
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// Interface is the network interface which the VIP is bound to in arp mode.
+	// It is the interface of the internal address of the instance when it is empty.
+	// +optional
+	Interface string `json:"interface,omitempty"`
+
+	// Image is the kube-vip image.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// BGP is the BGP configuration used in bgp mode.
+	// +optional
+	BGP *KubeVipBGPSpec `json:"bgp,omitempty"`
+}
+
+// KubeVipBGPSpec defines the BGP configuration of kube-vip.
+type KubeVipBGPSpec struct {
+	// AS is the AS number of the instances.
+	// +kubebuilder:default=65000
+	// +optional
+	AS uint32 `json:"as,omitempty"`
+
+	// Peers are the BGP peers. The other control plane instances are the peers when it is empty.
+	// +optional
+	Peers []BGPPeer `json:"peers,omitempty"`
+}
+
+// BGPPeer defines a BGP peer of kube-vip.
+type BGPPeer struct {
+	// Address is the address of the peer.
+	Address string `json:"address"`
+
+	// AS is the AS number of the peer.
+	AS uint32 `json:"as"`
+}
+
+// HaproxySpec defines the haproxy which forwards the API server requests of a worker instance to the control plane.
+type HaproxySpec struct {
+	// Image is the haproxy image.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// IsManaged returns whether the load balancer is provisioned by CAPKK.
+func (l *KKLoadBalancerSpec) IsManaged() bool {
+	return l != nil && l.Type != ""
 }
 
 // KKClusterStatus defines the observed state of KKCluster
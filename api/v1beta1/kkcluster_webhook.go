@@ -33,6 +33,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kubesphere/kubekey/v3/util/hostkey"
 )
 
 const (
@@ -186,9 +188,45 @@ func validateDistribution(spec KKClusterSpec) []*field.Error {
 func validateLoadBalancer(loadBalancer *KKLoadBalancerSpec) []*field.Error {
 	var errs field.ErrorList
 	path := field.NewPath("spec", "controlPlaneLoadBalancer")
+	if loadBalancer == nil {
+		errs = append(errs, field.Required(path, "can't be empty"))
+		return errs
+	}
 	if loadBalancer.Host == "" {
 		errs = append(errs, field.Required(path.Child("host"), "can't be empty"))
 	}
+
+	switch loadBalancer.Type {
+	case "", HaproxyLoadBalancer:
+	case KubeVipLoadBalancer:
+		if loadBalancer.Host != "" && net.ParseIP(loadBalancer.Host) == nil {
+			errs = append(errs, field.Invalid(path.Child("host"), loadBalancer.Host, "must be the VIP address when the type is kube-vip"))
+		}
+		errs = append(errs, validateKubeVip(loadBalancer.KubeVip, path.Child("kubeVip"))...)
+	default:
+		errs = append(errs, field.NotSupported(path.Child("type"), loadBalancer.Type, []string{KubeVipLoadBalancer, HaproxyLoadBalancer}))
+	}
+	return errs
+}
+
+func validateKubeVip(kubeVip *KubeVipSpec, path *field.Path) []*field.Error {
+	var errs field.ErrorList
+	if kubeVip == nil {
+		return errs
+	}
+	switch kubeVip.Mode {
+	case "", KubeVipARPMode, KubeVipBGPMode:
+	default:
+		errs = append(errs, field.NotSupported(path.Child("mode"), kubeVip.Mode, []string{KubeVipARPMode, KubeVipBGPMode}))
+	}
+	if kubeVip.BGP == nil {
+		return errs
+	}
+	for i, peer := range kubeVip.BGP.Peers {
+		if net.ParseIP(peer.Address) == nil {
+			errs = append(errs, field.Invalid(path.Child("bgp", "peers").Index(i).Child("address"), peer.Address, "peer address is invalid"))
+		}
+	}
 	return errs
 }
 
@@ -198,6 +236,10 @@ func validateClusterNodes(nodes Nodes) []*field.Error {
 	if nodes.Auth.Password == "" && nodes.Auth.PrivateKey == "" && nodes.Auth.PrivateKeyPath == "" && nodes.Auth.Secret == "" {
 		errs = append(errs, field.Required(field.NewPath("spec", "nodes", "auth"), "password and privateKey can't both be empty"))
 	}
+	if err := hostkey.Validate(nodes.Auth.HostKeyChecking); err != nil {
+		errs = append(errs, field.NotSupported(field.NewPath("spec", "nodes", "auth", "hostKeyChecking"),
+			nodes.Auth.HostKeyChecking, hostkey.Modes))
+	}
 
 	nameSet := mapset.NewThreadUnsafeSet()
 	addrSet := mapset.NewThreadUnsafeSet()
@@ -28,6 +28,10 @@ const (
 	// InstanceFinalizer allows ReconcileKKInstance to clean up KubeKey resources associated with KKInstance before
 	// removing it from the apiserver.
 	InstanceFinalizer = "kkinstance.infrastructure.cluster.x-k8s.io"
+
+	// HaproxyBackendsAnnotation is the annotation that stores the control plane addresses the haproxy of the instance
+	// was rendered with, the haproxy is deployed again when they change.
+	HaproxyBackendsAnnotation = "kkinstance.infrastructure.cluster.x-k8s.io/haproxy-backends"
 )
 
 // InstanceState describes the state of an KK instance.
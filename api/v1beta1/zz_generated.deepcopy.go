@@ -54,6 +54,21 @@ func (in *Auth) DeepCopy() *Auth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPPeer) DeepCopyInto(out *BGPPeer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPPeer.
+func (in *BGPPeer) DeepCopy() *BGPPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Checksum) DeepCopyInto(out *Checksum) {
 	*out = *in
@@ -104,6 +119,21 @@ func (in *ContainerManager) DeepCopy() *ContainerManager {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HaproxySpec) DeepCopyInto(out *HaproxySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HaproxySpec.
+func (in *HaproxySpec) DeepCopy() *HaproxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HaproxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceInfo) DeepCopyInto(out *InstanceInfo) {
 	*out = *in
@@ -192,7 +222,7 @@ func (in *KKClusterSpec) DeepCopyInto(out *KKClusterSpec) {
 	if in.ControlPlaneLoadBalancer != nil {
 		in, out := &in.ControlPlaneLoadBalancer, &out.ControlPlaneLoadBalancer
 		*out = new(KKLoadBalancerSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Component != nil {
 		in, out := &in.Component, &out.Component
@@ -466,6 +496,16 @@ func (in *KKInstanceStatus) DeepCopy() *KKInstanceStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KKLoadBalancerSpec) DeepCopyInto(out *KKLoadBalancerSpec) {
 	*out = *in
+	if in.KubeVip != nil {
+		in, out := &in.KubeVip, &out.KubeVip
+		*out = new(KubeVipSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Haproxy != nil {
+		in, out := &in.Haproxy, &out.Haproxy
+		*out = new(HaproxySpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KKLoadBalancerSpec.
@@ -729,6 +769,46 @@ func (in *KKMachineTemplateStatus) DeepCopy() *KKMachineTemplateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVipBGPSpec) DeepCopyInto(out *KubeVipBGPSpec) {
+	*out = *in
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]BGPPeer, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVipBGPSpec.
+func (in *KubeVipBGPSpec) DeepCopy() *KubeVipBGPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVipBGPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVipSpec) DeepCopyInto(out *KubeVipSpec) {
+	*out = *in
+	if in.BGP != nil {
+		in, out := &in.BGP, &out.BGP
+		*out = new(KubeVipBGPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVipSpec.
+func (in *KubeVipSpec) DeepCopy() *KubeVipSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVipSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Nodes) DeepCopyInto(out *Nodes) {
 	*out = *in
@@ -34,6 +34,7 @@ import (
 // ClusterSpec defines the desired state of Cluster
 type ClusterSpec struct {
 	Hosts                []HostCfg            `yaml:"hosts" json:"hosts,omitempty"`
+	Bastions             []BastionCfg         `yaml:"bastions,omitempty" json:"bastions,omitempty"`
 	RoleGroups           map[string][]string  `yaml:"roleGroups" json:"roleGroups,omitempty"`
 	ControlPlaneEndpoint ControlPlaneEndpoint `yaml:"controlPlaneEndpoint" json:"controlPlaneEndpoint,omitempty"`
 	System               System               `yaml:"system" json:"system,omitempty"`
@@ -67,10 +68,42 @@ type HostCfg struct {
 	Arch            string `yaml:"arch,omitempty" json:"arch,omitempty"`
 	Timeout         *int64 `yaml:"timeout,omitempty" json:"timeout,omitempty"`
 
+	// HostKeyChecking defines how to verify the SSH host key: strict, tofu or insecure.
+	HostKeyChecking string `yaml:"hostKeyChecking,omitempty" json:"hostKeyChecking,omitempty"`
+	// KnownHostsFile is the known_hosts file used to verify the SSH host key.
+	KnownHostsFile string `yaml:"knownHostsFile,omitempty" json:"knownHostsFile,omitempty"`
+	// Bastions defines the chain of jump hosts to reach the host. It overrides the cluster-wide bastions.
+	Bastions []BastionCfg `yaml:"bastions,omitempty" json:"bastions,omitempty"`
+
 	// Labels defines the kubernetes labels for the node.
 	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
 }
 
+// InternalAddresses returns the comma separated internal addresses of a dual-stack host, the first one is the primary.
+func (h HostCfg) InternalAddresses() []string {
+	var addresses []string
+	for _, address := range strings.Split(h.InternalAddress, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	if len(addresses) == 0 {
+		return []string{h.InternalAddress}
+	}
+	return addresses
+}
+
+// BastionCfg defines a jump host on the SSH path to the hosts.
+// The user and credentials of the host are used when none is set.
+type BastionCfg struct {
+	Address        string `yaml:"address,omitempty" json:"address,omitempty"`
+	Port           int    `yaml:"port,omitempty" json:"port,omitempty"`
+	User           string `yaml:"user,omitempty" json:"user,omitempty"`
+	Password       string `yaml:"password,omitempty" json:"password,omitempty"`
+	PrivateKey     string `yaml:"privateKey,omitempty" json:"privateKey,omitempty"`
+	PrivateKeyPath string `yaml:"privateKeyPath,omitempty" json:"privateKeyPath,omitempty"`
+}
+
 // ControlPlaneEndpoint defines the control plane endpoint information for cluster.
 type ControlPlaneEndpoint struct {
 	InternalLoadbalancer string  `yaml:"internalLoadbalancer" json:"internalLoadbalancer,omitempty"`
@@ -140,12 +173,14 @@ func (cfg *ClusterSpec) GenerateCertSANs() []string {
 		if host.Address != cfg.ControlPlaneEndpoint.Address {
 			extraCertSANs = append(extraCertSANs, host.Address)
 		}
-		if host.InternalAddress != host.Address && host.InternalAddress != cfg.ControlPlaneEndpoint.Address {
-			extraCertSANs = append(extraCertSANs, host.InternalAddress)
+		for _, address := range host.InternalAddresses() {
+			if address != host.Address && address != cfg.ControlPlaneEndpoint.Address {
+				extraCertSANs = append(extraCertSANs, address)
+			}
 		}
 	}
 
-	extraCertSANs = append(extraCertSANs, util.ParseIp(cfg.Network.KubeServiceCIDR)[0])
+	extraCertSANs = append(extraCertSANs, cfg.ClusterIPs()...)
 
 	defaultCertSANs = append(defaultCertSANs, extraCertSANs...)
 
@@ -182,6 +217,16 @@ func (cfg *ClusterSpec) GroupHosts() map[string][]*KubeHost {
 	return roleGroups
 }
 
+// Host returns the host of the given name from the hosts list, whether it belongs to a role group or not.
+func (cfg *ClusterSpec) Host(name string) (*KubeHost, bool) {
+	for _, hostCfg := range cfg.Hosts {
+		if hostCfg.Name == name {
+			return toHosts(hostCfg), true
+		}
+	}
+	return nil, false
+}
+
 // +kubebuilder:object:generate=false
 type KubeHost struct {
 	*connector.BaseHost
@@ -192,7 +237,8 @@ func toHosts(cfg HostCfg) *KubeHost {
 	host := connector.NewHost()
 	host.Name = cfg.Name
 	host.Address = cfg.Address
-	host.InternalAddress = cfg.InternalAddress
+	host.InternalAddresses = cfg.InternalAddresses()
+	host.InternalAddress = host.InternalAddresses[0]
 	host.Port = cfg.Port
 	host.User = cfg.User
 	host.Password = cfg.Password
@@ -200,6 +246,18 @@ func toHosts(cfg HostCfg) *KubeHost {
 	host.PrivateKeyPath = cfg.PrivateKeyPath
 	host.Arch = cfg.Arch
 	host.Timeout = *cfg.Timeout
+	host.HostKeyChecking = cfg.HostKeyChecking
+	host.KnownHostsFile = cfg.KnownHostsFile
+	for _, b := range cfg.Bastions {
+		host.Bastions = append(host.Bastions, connector.Bastion{
+			Address:        b.Address,
+			Port:           b.Port,
+			User:           b.User,
+			Password:       b.Password,
+			PrivateKey:     b.PrivateKey,
+			PrivateKeyPath: b.PrivateKeyPath,
+		})
+	}
 
 	kubeHost := &KubeHost{
 		BaseHost: host,
@@ -210,23 +268,59 @@ func toHosts(cfg HostCfg) *KubeHost {
 
 // ClusterIP is used to get the kube-apiserver service address inside the cluster.
 func (cfg *ClusterSpec) ClusterIP() string {
-	return util.ParseIp(cfg.Network.KubeServiceCIDR)[0]
+	return serviceIP(cfg.Network.KubeServiceCIDRs()[0], 1)
+}
+
+// ClusterIPs is used to get the kube-apiserver service address of each IP family inside the cluster.
+func (cfg *ClusterSpec) ClusterIPs() []string {
+	var ips []string
+	for _, cidr := range cfg.Network.KubeServiceCIDRs() {
+		ips = append(ips, serviceIP(cidr, 1))
+	}
+	return ips
 }
 
 // CorednsClusterIP is used to get the coredns service address inside the cluster.
 func (cfg *ClusterSpec) CorednsClusterIP() string {
-	return util.ParseIp(cfg.Network.KubeServiceCIDR)[2]
+	return serviceIP(cfg.Network.KubeServiceCIDRs()[0], 3)
+}
+
+// CorednsClusterIPs is used to get the coredns service address of each IP family inside the cluster.
+func (cfg *ClusterSpec) CorednsClusterIPs() []string {
+	var ips []string
+	for _, cidr := range cfg.Network.KubeServiceCIDRs() {
+		ips = append(ips, serviceIP(cidr, 3))
+	}
+	return ips
+}
+
+// NodelocaldnsIP is used to get the link-local address which nodelocaldns listens on.
+func (cfg *ClusterSpec) NodelocaldnsIP() string {
+	for _, cidr := range cfg.Network.KubeServiceCIDRs() {
+		if !util.IsIPv6(cidr) {
+			return DefaultNodelocaldnsIP
+		}
+	}
+	return DefaultNodelocaldnsIPv6
 }
 
 // ClusterDNS is used to get the dns server address inside the cluster.
 func (cfg *ClusterSpec) ClusterDNS() string {
 	if cfg.Kubernetes.EnableNodelocaldns() {
-		return "169.254.25.10"
+		return cfg.NodelocaldnsIP()
 	} else {
 		return cfg.CorednsClusterIP()
 	}
 }
 
+func serviceIP(cidr string, n int64) string {
+	ip, err := util.NthIP(cidr, n)
+	if err != nil {
+		logger.Log.Fatalf("Invalid kubeServiceCIDR: %s", err)
+	}
+	return ip
+}
+
 // ParseRolesList is used to parse the host grouping list.
 func (cfg *ClusterSpec) ParseRolesList(hostMap map[string]*KubeHost) map[string][]*KubeHost {
 	roleGroupLists := make(map[string][]*KubeHost)
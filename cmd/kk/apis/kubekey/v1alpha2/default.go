@@ -44,6 +44,8 @@ const (
 	DefaultDockerVersion           = "24.0.6"
 	DefaultContainerdVersion       = "1.6.4"
 	DefaultRuncVersion             = "v1.1.1"
+	DefaultCrioVersion             = "v1.24.6"
+	DefaultCriDockerdVersion       = "0.3.1"
 	DefaultCrictlVersion           = "v1.24.0"
 	DefaultKubeVersion             = "v1.23.10"
 	DefaultCalicoVersion           = "v3.26.1"
@@ -61,12 +63,16 @@ const (
 	DefaultMaxPods                 = 110
 	DefaultPodPidsLimit            = 10000
 	DefaultNodeCidrMaskSize        = 24
+	DefaultNodeCidrMaskSizeIPv6    = 64
+	DefaultNodelocaldnsIP          = "169.254.25.10"
+	DefaultNodelocaldnsIPv6        = "fd00::10"
 	DefaultIPIPMode                = "Always"
 	DefaultVXLANMode               = "Never"
 	DefaultVethMTU                 = 0
 	DefaultBackendMode             = "vxlan"
 	DefaultProxyMode               = "ipvs"
 	DefaultCrioEndpoint            = "unix:///var/run/crio/crio.sock"
+	DefaultCriDockerdEndpoint      = "unix:///var/run/cri-dockerd.sock"
 	DefaultContainerdEndpoint      = "unix:///run/containerd/containerd.sock"
 	DefaultIsulaEndpoint           = "unix:///var/run/isulad.sock"
 	Etcd                           = "etcd"
@@ -136,6 +142,9 @@ func (cfg *ClusterSpec) SetDefaultClusterSpec() (*ClusterSpec, map[string][]*Kub
 	if cfg.Kubernetes.NodeCidrMaskSize == 0 {
 		clusterCfg.Kubernetes.NodeCidrMaskSize = DefaultNodeCidrMaskSize
 	}
+	if cfg.Kubernetes.NodeCidrMaskSizeIPv6 == 0 {
+		clusterCfg.Kubernetes.NodeCidrMaskSizeIPv6 = DefaultNodeCidrMaskSizeIPv6
+	}
 	if cfg.Kubernetes.ProxyMode == "" {
 		clusterCfg.Kubernetes.ProxyMode = DefaultProxyMode
 	}
@@ -149,7 +158,7 @@ func SetDefaultHostsCfg(cfg *ClusterSpec) []HostCfg {
 	}
 	for _, host := range cfg.Hosts {
 		if len(host.Address) == 0 && len(host.InternalAddress) > 0 {
-			host.Address = host.InternalAddress
+			host.Address = host.InternalAddresses()[0]
 		}
 		if len(host.InternalAddress) == 0 && len(host.Address) > 0 {
 			host.InternalAddress = host.Address
@@ -180,11 +189,31 @@ func SetDefaultHostsCfg(cfg *ClusterSpec) []HostCfg {
 			host.Timeout = &timeout
 		}
 
+		if len(host.Bastions) == 0 {
+			host.Bastions = cfg.Bastions
+		}
+		host.Bastions = SetDefaultBastionsCfg(host.Bastions)
+
 		hostCfg = append(hostCfg, host)
 	}
 	return hostCfg
 }
 
+func SetDefaultBastionsCfg(bastions []BastionCfg) []BastionCfg {
+	var bastionCfg []BastionCfg
+	for _, bastion := range bastions {
+		if bastion.Port == 0 {
+			bastion.Port = DefaultSSHPort
+		}
+		if bastion.PrivateKeyPath != "" && strings.HasPrefix(strings.TrimSpace(bastion.PrivateKeyPath), "~/") {
+			homeDir, _ := util.Home()
+			bastion.PrivateKeyPath = strings.Replace(bastion.PrivateKeyPath, "~/", fmt.Sprintf("%s/", homeDir), 1)
+		}
+		bastionCfg = append(bastionCfg, bastion)
+	}
+	return bastionCfg
+}
+
 func SetDefaultLBCfg(cfg *ClusterSpec, masterGroup []*KubeHost) ControlPlaneEndpoint {
 	//Check whether LB should be configured
 	if len(masterGroup) >= 2 && !cfg.ControlPlaneEndpoint.IsInternalLBEnabled() && cfg.ControlPlaneEndpoint.Address == "" && !cfg.ControlPlaneEndpoint.EnableExternalDNS() {
@@ -315,7 +344,11 @@ func SetDefaultClusterCfg(cfg *ClusterSpec) Kubernetes {
 	if cfg.Kubernetes.ContainerRuntimeEndpoint == "" {
 		switch cfg.Kubernetes.ContainerManager {
 		case Docker:
-			cfg.Kubernetes.ContainerRuntimeEndpoint = ""
+			if cfg.Kubernetes.EnableCriDockerd() {
+				cfg.Kubernetes.ContainerRuntimeEndpoint = DefaultCriDockerdEndpoint
+			} else {
+				cfg.Kubernetes.ContainerRuntimeEndpoint = ""
+			}
 		case Crio:
 			cfg.Kubernetes.ContainerRuntimeEndpoint = DefaultCrioEndpoint
 		case Containerd:
@@ -16,7 +16,10 @@
 
 package v1alpha2
 
-import "k8s.io/apimachinery/pkg/runtime"
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	versionutil "k8s.io/apimachinery/pkg/util/version"
+)
 
 // Kubernetes contains the configuration for the cluster
 type Kubernetes struct {
@@ -29,6 +32,7 @@ type Kubernetes struct {
 	MaxPods                int      `yaml:"maxPods" json:"maxPods,omitempty"`
 	PodPidsLimit           int      `yaml:"podPidsLimit" json:"podPidsLimit,omitempty"`
 	NodeCidrMaskSize       int      `yaml:"nodeCidrMaskSize" json:"nodeCidrMaskSize,omitempty"`
+	NodeCidrMaskSizeIPv6   int      `yaml:"nodeCidrMaskSizeIPv6" json:"nodeCidrMaskSizeIPv6,omitempty"`
 	ApiserverCertExtraSans []string `yaml:"apiserverCertExtraSans" json:"apiserverCertExtraSans,omitempty"`
 	ProxyMode              string   `yaml:"proxyMode" json:"proxyMode,omitempty"`
 	AutoRenewCerts         *bool    `yaml:"autoRenewCerts" json:"autoRenewCerts,omitempty"`
@@ -103,3 +107,20 @@ func (k *Kubernetes) EnableAudit() bool {
 	}
 	return *k.AutoRenewCerts
 }
+
+// DockershimRemoved is used to determine whether the kubernetes version no longer contains dockershim.
+func (k *Kubernetes) DockershimRemoved() bool {
+	if k.Type != "" && k.Type != "kubernetes" {
+		return false
+	}
+	v, err := versionutil.ParseSemantic(k.Version)
+	if err != nil {
+		return false
+	}
+	return v.AtLeast(versionutil.MustParseSemantic("v1.24.0"))
+}
+
+// EnableCriDockerd is used to determine whether docker is connected to kubelet through cri-dockerd.
+func (k *Kubernetes) EnableCriDockerd() bool {
+	return k.ContainerManager == Docker && k.DockershimRemoved()
+}
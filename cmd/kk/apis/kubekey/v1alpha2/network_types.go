@@ -16,6 +16,10 @@
 
 package v1alpha2
 
+import (
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
+)
+
 type NetworkConfig struct {
 	Plugin          string       `yaml:"plugin" json:"plugin,omitempty"`
 	KubePodsCIDR    string       `yaml:"kubePodsCIDR" json:"kubePodsCIDR,omitempty"`
@@ -25,6 +29,77 @@ type NetworkConfig struct {
 	Kubeovn         KubeovnCfg   `yaml:"kubeovn" json:"kubeovn,omitempty"`
 	MultusCNI       MultusCNI    `yaml:"multusCNI" json:"multusCNI,omitempty"`
 	Hybridnet       HybridnetCfg `yaml:"hybridnet" json:"hybridnet,omitempty"`
+	// CustomPlugins registers network plugins that are not built into kk. Select one by setting Plugin to its name.
+	CustomPlugins []CustomNetworkPlugin `yaml:"customPlugins" json:"customPlugins,omitempty"`
+}
+
+// CustomNetworkPlugin deploys a CNI from a local directory of manifests, a Helm chart, or both.
+type CustomNetworkPlugin struct {
+	Name string `yaml:"name" json:"name,omitempty"`
+	// ManifestsDir is a local directory whose *.yaml and *.yml files are rendered as templates and applied in name order.
+	ManifestsDir string              `yaml:"manifestsDir" json:"manifestsDir,omitempty"`
+	Chart        *NetworkPluginChart `yaml:"chart" json:"chart,omitempty"`
+}
+
+// NetworkPluginChart installs a network plugin with Helm.
+type NetworkPluginChart struct {
+	// Path is a local chart archive. It is used instead of Repo and Name when set.
+	Path      string `yaml:"path" json:"path,omitempty"`
+	Repo      string `yaml:"repo" json:"repo,omitempty"`
+	Name      string `yaml:"name" json:"name,omitempty"`
+	Version   string `yaml:"version" json:"version,omitempty"`
+	Namespace string `yaml:"namespace" json:"namespace,omitempty"`
+	// Values maps chart values to templates rendered with the cluster network settings, such as "{{ .KubePodsCIDR }}".
+	Values map[string]string `yaml:"values" json:"values,omitempty"`
+}
+
+// CustomPlugin returns the custom network plugin registered under name.
+func (n *NetworkConfig) CustomPlugin(name string) (*CustomNetworkPlugin, bool) {
+	for i := range n.CustomPlugins {
+		if n.CustomPlugins[i].Name == name {
+			return &n.CustomPlugins[i], true
+		}
+	}
+	return nil, false
+}
+
+// KubePodsCIDRs returns the pod CIDRs, a dual-stack cluster has one of each IP family.
+func (n *NetworkConfig) KubePodsCIDRs() []string {
+	return util.SplitCIDRs(n.KubePodsCIDR)
+}
+
+// KubeServiceCIDRs returns the service CIDRs, the first one is the primary IP family of the cluster.
+func (n *NetworkConfig) KubeServiceCIDRs() []string {
+	cidrs := util.SplitCIDRs(n.KubeServiceCIDR)
+	if len(cidrs) == 0 {
+		return []string{DefaultServiceCIDR}
+	}
+	return cidrs
+}
+
+// IsDualStack tells whether the cluster has both IPv4 and IPv6 pod and service CIDRs.
+func (n *NetworkConfig) IsDualStack() bool {
+	return len(n.KubePodsCIDRs()) > 1 || len(n.KubeServiceCIDRs()) > 1
+}
+
+// KubePodsIPv4CIDR returns the IPv4 pod CIDR, or an empty string on an IPv6 single-stack cluster.
+func (n *NetworkConfig) KubePodsIPv4CIDR() string {
+	for _, cidr := range n.KubePodsCIDRs() {
+		if !util.IsIPv6(cidr) {
+			return cidr
+		}
+	}
+	return ""
+}
+
+// KubePodsIPv6CIDR returns the IPv6 pod CIDR, or an empty string on an IPv4 single-stack cluster.
+func (n *NetworkConfig) KubePodsIPv6CIDR() string {
+	for _, cidr := range n.KubePodsCIDRs() {
+		if util.IsIPv6(cidr) {
+			return cidr
+		}
+	}
+	return ""
 }
 
 type CalicoCfg struct {
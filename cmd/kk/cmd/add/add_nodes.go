@@ -26,18 +26,27 @@ import (
 )
 
 type AddNodesOptions struct {
-	CommonOptions    *options.CommonOptions
-	ClusterCfgFile   string
-	SkipPullImages   bool
-	ContainerManager string
-	DownloadCmd      string
-	Artifact         string
-	InstallPackages  bool
+	CommonOptions     *options.CommonOptions
+	RolloutOptions    *options.RolloutOptions
+	DryRunOptions     *options.DryRunOptions
+	ClusterCfgFile    string
+	SkipPullImages    bool
+	ContainerManager  string
+	DownloadCmd       string
+	Artifact          string
+	VerifyKey         string
+	InstallPackages   bool
+	Resume            bool
+	FromCluster       bool
+	KubeConfig        string
+	ClusterConfigName string
 }
 
 func NewAddNodesOptions() *AddNodesOptions {
 	return &AddNodesOptions{
-		CommonOptions: options.NewCommonOptions(),
+		CommonOptions:  options.NewCommonOptions(),
+		RolloutOptions: options.NewRolloutOptions(),
+		DryRunOptions:  options.NewDryRunOptions(),
 	}
 }
 
@@ -49,11 +58,15 @@ func NewCmdAddNodes() *cobra.Command {
 		Short: "Add nodes to the cluster according to the new nodes information from the specified configuration file",
 		Run: func(cmd *cobra.Command, args []string) {
 			util.CheckErr(o.Complete(cmd, args))
+			util.CheckErr(o.DryRunOptions.Validate())
+			util.CheckErr(o.RolloutOptions.Validate())
 			util.CheckErr(o.Run())
 		},
 	}
 
 	o.CommonOptions.AddCommonFlag(cmd)
+	o.RolloutOptions.AddRolloutFlag(cmd)
+	o.DryRunOptions.AddDryRunFlag(cmd)
 	o.AddFlags(cmd)
 	return cmd
 }
@@ -67,16 +80,30 @@ func (o *AddNodesOptions) Complete(_ *cobra.Command, _ []string) error {
 
 func (o *AddNodesOptions) Run() error {
 	arg := common.Argument{
-		FilePath:         o.ClusterCfgFile,
-		KsEnable:         false,
-		Debug:            o.CommonOptions.Verbose,
-		IgnoreErr:        o.CommonOptions.IgnoreErr,
-		SkipConfirmCheck: o.CommonOptions.SkipConfirmCheck,
-		SkipPullImages:   o.SkipPullImages,
-		ContainerManager: o.ContainerManager,
-		Artifact:         o.Artifact,
-		InstallPackages:  o.InstallPackages,
-		Namespace:        o.CommonOptions.Namespace,
+		FilePath:          o.ClusterCfgFile,
+		KsEnable:          false,
+		Debug:             o.CommonOptions.Verbose,
+		IgnoreErr:         o.CommonOptions.IgnoreErr,
+		SkipConfirmCheck:  o.CommonOptions.SkipConfirmCheck,
+		SkipPullImages:    o.SkipPullImages,
+		ContainerManager:  o.ContainerManager,
+		Artifact:          o.Artifact,
+		VerifyKey:         o.VerifyKey,
+		InstallPackages:   o.InstallPackages,
+		Namespace:         o.CommonOptions.Namespace,
+		Resume:            o.Resume,
+		FromCluster:       o.FromCluster,
+		KubeConfig:        o.KubeConfig,
+		ClusterConfigName: o.ClusterConfigName,
+		HostKeyChecking:   o.CommonOptions.HostKeyChecking,
+		Events:            o.CommonOptions.Events,
+		TemplateDir:       o.CommonOptions.TemplateDir,
+		Concurrency:       o.RolloutOptions.Concurrency,
+		BatchSize:         o.RolloutOptions.BatchSize,
+		MaxFailures:       o.RolloutOptions.MaxFailures,
+		KnownHostsFile:    o.CommonOptions.KnownHostsFile,
+		DryRun:            o.DryRunOptions.DryRun,
+		DryRunFormat:      o.DryRunOptions.Format,
 	}
 	return pipelines.AddNodes(arg, o.DownloadCmd)
 }
@@ -85,8 +112,13 @@ func (o *AddNodesOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.ClusterCfgFile, "filename", "f", "", "Path to a configuration file")
 	cmd.Flags().BoolVarP(&o.SkipPullImages, "skip-pull-images", "", false, "Skip pre pull images")
 	cmd.Flags().StringVarP(&o.ContainerManager, "container-manager", "", "docker", "Container manager: docker, crio, containerd and isula.")
-	cmd.Flags().StringVarP(&o.DownloadCmd, "download-cmd", "", "curl -L -o %s %s",
-		`The user defined command to download the necessary binary files. The first param '%s' is output path, the second param '%s', is the URL`)
+	cmd.Flags().StringVarP(&o.DownloadCmd, "download-cmd", "", "",
+		`The user defined command to download the necessary binary files. The first param '%s' is output path, the second param '%s', is the URL. The built-in downloader is used if it is empty`)
 	cmd.Flags().StringVarP(&o.Artifact, "artifact", "a", "", "Path to a KubeKey artifact")
+	cmd.Flags().StringVarP(&o.VerifyKey, "verify-key", "", "", "Path to a PEM public key to verify the signature of the KubeKey artifact")
 	cmd.Flags().BoolVarP(&o.InstallPackages, "with-packages", "", false, "install operation system packages by artifact")
+	cmd.Flags().BoolVarP(&o.Resume, "resume", "", false, "Resume from the last failed module, the modules finished by the previous run with the same configuration will be skipped")
+	cmd.Flags().BoolVarP(&o.FromCluster, "from-cluster", "", false, "Load the cluster configuration from a ConfigMap or Secret in the existing cluster")
+	cmd.Flags().StringVarP(&o.KubeConfig, "kubeconfig", "", "", "Specify a kubeconfig file to access the existing cluster")
+	cmd.Flags().StringVarP(&o.ClusterConfigName, "config-name", "", common.DefaultClusterConfigName, "The name of the ConfigMap or Secret which stores the cluster configuration")
 }
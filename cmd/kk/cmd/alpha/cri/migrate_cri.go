@@ -69,17 +69,21 @@ func (o *MigrateCriOptions) Run() error {
 		KubernetesVersion: o.Kubernetes,
 		Type:              o.Type,
 		Role:              o.Role,
+		HostKeyChecking:   o.CommonOptions.HostKeyChecking,
+		Events:            o.CommonOptions.Events,
+		TemplateDir:       o.CommonOptions.TemplateDir,
+		KnownHostsFile:    o.CommonOptions.KnownHostsFile,
 	}
 	return pipelines.MigrateCri(arg, o.DownloadCmd)
 }
 
 func (o *MigrateCriOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.Role, "role", "", "", "Role groups for migrating. Support: master, worker, all.")
-	cmd.Flags().StringVarP(&o.Type, "type", "", "", "Type of target CRI. Support: docker, containerd.")
+	cmd.Flags().StringVarP(&o.Type, "type", "", "", "Type of target CRI. Support: docker, containerd, crio.")
 	cmd.Flags().StringVarP(&o.ClusterCfgFile, "filename", "f", "", "Path to a configuration file")
 	cmd.Flags().StringVarP(&o.Kubernetes, "with-kubernetes", "", "", "Specify a supported version of kubernetes")
-	cmd.Flags().StringVarP(&o.DownloadCmd, "download-cmd", "", "curl -L -o %s %s",
-		`The user defined command to download the necessary binary files. The first param '%s' is output path, the second param '%s', is the URL`)
+	cmd.Flags().StringVarP(&o.DownloadCmd, "download-cmd", "", "",
+		`The user defined command to download the necessary binary files. The first param '%s' is output path, the second param '%s', is the URL. The built-in downloader is used if it is empty`)
 	cmd.Flags().StringVarP(&o.Artifact, "artifact", "a", "", "Path to a KubeKey artifact")
 }
 
@@ -94,7 +98,7 @@ func (o *MigrateCriOptions) Validate() error {
 	if o.Type == "" {
 		return errors.New("cri Type can not be empty")
 	}
-	if o.Type != common.Docker && o.Type != common.Containerd {
+	if o.Type != common.Docker && o.Type != common.Containerd && o.Type != common.Crio {
 		return errors.Errorf("cri Type is invalid: %s", o.Type)
 	}
 	if o.ClusterCfgFile == "" {
@@ -0,0 +1,115 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/options"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/util"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/pipelines"
+)
+
+type ApplyOptions struct {
+	CommonOptions     *options.CommonOptions
+	RolloutOptions    *options.RolloutOptions
+	ClusterCfgFile    string
+	SkipPullImages    bool
+	ContainerManager  string
+	DownloadCmd       string
+	Artifact          string
+	VerifyKey         string
+	InstallPackages   bool
+	FromCluster       bool
+	KubeConfig        string
+	ClusterConfigName string
+}
+
+func NewApplyOptions() *ApplyOptions {
+	return &ApplyOptions{
+		CommonOptions:  options.NewCommonOptions(),
+		RolloutOptions: options.NewRolloutOptions(),
+	}
+}
+
+// NewCmdApply creates a new apply command
+func NewCmdApply() *cobra.Command {
+	o := NewApplyOptions()
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Compare the configuration file with the live cluster, display the plan and apply it",
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.Complete(cmd, args))
+			util.CheckErr(o.RolloutOptions.Validate())
+			util.CheckErr(o.Run())
+		},
+	}
+
+	o.CommonOptions.AddCommonFlag(cmd)
+	o.RolloutOptions.AddRolloutFlag(cmd)
+	o.AddFlags(cmd)
+	return cmd
+}
+
+func (o *ApplyOptions) Complete(_ *cobra.Command, _ []string) error {
+	if o.Artifact == "" {
+		o.InstallPackages = false
+	}
+	return nil
+}
+
+func (o *ApplyOptions) Run() error {
+	arg := common.Argument{
+		FilePath:          o.ClusterCfgFile,
+		KsEnable:          false,
+		Debug:             o.CommonOptions.Verbose,
+		IgnoreErr:         o.CommonOptions.IgnoreErr,
+		SkipConfirmCheck:  o.CommonOptions.SkipConfirmCheck,
+		SkipPullImages:    o.SkipPullImages,
+		ContainerManager:  o.ContainerManager,
+		Artifact:          o.Artifact,
+		VerifyKey:         o.VerifyKey,
+		InstallPackages:   o.InstallPackages,
+		Namespace:         o.CommonOptions.Namespace,
+		FromCluster:       o.FromCluster,
+		KubeConfig:        o.KubeConfig,
+		ClusterConfigName: o.ClusterConfigName,
+		HostKeyChecking:   o.CommonOptions.HostKeyChecking,
+		Events:            o.CommonOptions.Events,
+		TemplateDir:       o.CommonOptions.TemplateDir,
+		Concurrency:       o.RolloutOptions.Concurrency,
+		BatchSize:         o.RolloutOptions.BatchSize,
+		MaxFailures:       o.RolloutOptions.MaxFailures,
+		KnownHostsFile:    o.CommonOptions.KnownHostsFile,
+	}
+	return pipelines.Apply(arg, o.DownloadCmd)
+}
+
+func (o *ApplyOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.ClusterCfgFile, "filename", "f", "", "Path to a configuration file")
+	cmd.Flags().BoolVarP(&o.SkipPullImages, "skip-pull-images", "", false, "Skip pre pull images")
+	cmd.Flags().StringVarP(&o.ContainerManager, "container-manager", "", "docker", "Container manager: docker, crio, containerd and isula.")
+	cmd.Flags().StringVarP(&o.DownloadCmd, "download-cmd", "", "",
+		`The user defined command to download the necessary binary files. The first param '%s' is output path, the second param '%s', is the URL. The built-in downloader is used if it is empty`)
+	cmd.Flags().StringVarP(&o.Artifact, "artifact", "a", "", "Path to a KubeKey artifact")
+	cmd.Flags().StringVarP(&o.VerifyKey, "verify-key", "", "", "Path to a PEM public key to verify the signature of the KubeKey artifact")
+	cmd.Flags().BoolVarP(&o.InstallPackages, "with-packages", "", false, "install operation system packages by artifact")
+	cmd.Flags().BoolVarP(&o.FromCluster, "from-cluster", "", false, "Load the cluster configuration from a ConfigMap or Secret in the existing cluster")
+	cmd.Flags().StringVarP(&o.KubeConfig, "kubeconfig", "", "", "Specify a kubeconfig file to access the existing cluster")
+	cmd.Flags().StringVarP(&o.ClusterConfigName, "config-name", "", common.DefaultClusterConfigName, "The name of the ConfigMap or Secret which stores the cluster configuration")
+}
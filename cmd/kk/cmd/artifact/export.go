@@ -34,6 +34,8 @@ type ArtifactExportOptions struct {
 	Output       string
 	CriSocket    string
 	DownloadCmd  string
+	SignKey      string
+	Base         string
 }
 
 func NewArtifactExportOptions() *ArtifactExportOptions {
@@ -71,6 +73,9 @@ func (o *ArtifactExportOptions) Validate(_ []string) error {
 	if o.ManifestFile == "" {
 		return fmt.Errorf("--manifest can not be an empty string")
 	}
+	if o.Base != "" && o.Base == o.Output {
+		return fmt.Errorf("--base can not be the same as --output")
+	}
 	return nil
 }
 
@@ -81,6 +86,9 @@ func (o *ArtifactExportOptions) Run() error {
 		CriSocket:    o.CriSocket,
 		Debug:        o.CommonOptions.Verbose,
 		IgnoreErr:    o.CommonOptions.IgnoreErr,
+		Events:       o.CommonOptions.Events,
+		SignKey:      o.SignKey,
+		Base:         o.Base,
 	}
 
 	return pipelines.ArtifactExport(arg, o.DownloadCmd)
@@ -89,6 +97,8 @@ func (o *ArtifactExportOptions) Run() error {
 func (o *ArtifactExportOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.ManifestFile, "manifest", "m", "", "Path to a manifest file")
 	cmd.Flags().StringVarP(&o.Output, "output", "o", "", "Path to a output path")
-	cmd.Flags().StringVarP(&o.DownloadCmd, "download-cmd", "", "curl -L -o %s %s",
-		`The user defined command to download the necessary binary files. The first param '%s' is output path, the second param '%s', is the URL`)
+	cmd.Flags().StringVarP(&o.Base, "base", "", "", "Path to a previously exported artifact, only the files which are not in it are packaged")
+	cmd.Flags().StringVarP(&o.SignKey, "sign-key", "", "", "Path to an unencrypted PEM ed25519 or ECDSA private key to sign the digests of the artifact")
+	cmd.Flags().StringVarP(&o.DownloadCmd, "download-cmd", "", "",
+		`The user defined command to download the necessary binary files. The first param '%s' is output path, the second param '%s', is the URL. The built-in downloader is used if it is empty`)
 }
@@ -89,11 +89,15 @@ func (o *ArtifactImagesPushOptions) Validate(_ []string) error {
 
 func (o *ArtifactImagesPushOptions) Run() error {
 	arg := common.Argument{
-		ImagesDir: o.ImageDirPath,
-		Artifact:  o.Artifact,
-		FilePath:  o.ClusterCfgFile,
-		Debug:     o.CommonOptions.Verbose,
-		IgnoreErr: o.CommonOptions.IgnoreErr,
+		ImagesDir:       o.ImageDirPath,
+		Artifact:        o.Artifact,
+		FilePath:        o.ClusterCfgFile,
+		Debug:           o.CommonOptions.Verbose,
+		IgnoreErr:       o.CommonOptions.IgnoreErr,
+		HostKeyChecking: o.CommonOptions.HostKeyChecking,
+		Events:          o.CommonOptions.Events,
+		TemplateDir:     o.CommonOptions.TemplateDir,
+		KnownHostsFile:  o.CommonOptions.KnownHostsFile,
 	}
 	return runPush(arg)
 }
@@ -30,6 +30,7 @@ import (
 type ArtifactImportOptions struct {
 	CommonOptions *options.CommonOptions
 	Artifact      string
+	VerifyKey     string
 }
 
 func NewArtifactImportOptions() *ArtifactImportOptions {
@@ -57,14 +58,20 @@ func NewCmdArtifactImport() *cobra.Command {
 
 func (o *ArtifactImportOptions) Run() error {
 	arg := common.Argument{
-		Debug:    o.CommonOptions.Verbose,
-		Artifact: o.Artifact,
+		Debug:           o.CommonOptions.Verbose,
+		Artifact:        o.Artifact,
+		VerifyKey:       o.VerifyKey,
+		HostKeyChecking: o.CommonOptions.HostKeyChecking,
+		Events:          o.CommonOptions.Events,
+		TemplateDir:     o.CommonOptions.TemplateDir,
+		KnownHostsFile:  o.CommonOptions.KnownHostsFile,
 	}
 	return artifact.ArtifactImport(arg)
 }
 
 func (o *ArtifactImportOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.Artifact, "artifact", "a", "", "Path to a artifact gzip")
+	cmd.Flags().StringVarP(&o.VerifyKey, "verify-key", "", "", "Path to a PEM public key to verify the signature of the KubeKey artifact")
 }
 
 func (o *ArtifactImportOptions) Validate(_ []string) error {
@@ -0,0 +1,106 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package artifact
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/util"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/artifact"
+)
+
+type ArtifactInspectOptions struct {
+	Artifact string
+	Output   string
+}
+
+func NewArtifactInspectOptions() *ArtifactInspectOptions {
+	return &ArtifactInspectOptions{}
+}
+
+// NewCmdArtifactInspect creates a new artifact inspect command
+func NewCmdArtifactInspect() *cobra.Command {
+	o := NewArtifactInspectOptions()
+	cmd := &cobra.Command{
+		Use:   "inspect <artifact>",
+		Short: "List and validate the contents of a KubeKey offline installation package",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.Complete(cmd, args))
+			util.CheckErr(o.Validate(args))
+			util.CheckErr(o.Run(cmd.OutOrStdout()))
+		},
+	}
+
+	o.AddFlags(cmd)
+	return cmd
+}
+
+func (o *ArtifactInspectOptions) Complete(_ *cobra.Command, args []string) error {
+	o.Artifact = args[0]
+	return nil
+}
+
+func (o *ArtifactInspectOptions) Validate(_ []string) error {
+	switch o.Output {
+	case "", "yaml", "json":
+		return nil
+	default:
+		return errors.Errorf("invalid output format: %s", o.Output)
+	}
+}
+
+func (o *ArtifactInspectOptions) Run(out io.Writer) error {
+	inspection, err := artifact.Inspect(o.Artifact)
+	if err != nil {
+		return err
+	}
+
+	switch o.Output {
+	case "yaml":
+		y, err := yaml.Marshal(inspection)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprint(out, string(y))
+	case "json":
+		j, err := json.MarshalIndent(inspection, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(j))
+	default:
+		if err := inspection.Print(out); err != nil {
+			return err
+		}
+	}
+
+	if mismatches := inspection.Mismatches(); len(mismatches) != 0 {
+		return errors.Errorf("the sha256 of %d binaries of artifact %s do not match the checksums of components.json", len(mismatches), o.Artifact)
+	}
+	return nil
+}
+
+func (o *ArtifactInspectOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.Output, "output", "o", "", "Output format; available options are 'yaml' and 'json'")
+}
@@ -54,8 +54,12 @@ func NewCmdCertList() *cobra.Command {
 
 func (o *CertListOptions) Run() error {
 	arg := common.Argument{
-		FilePath: o.ClusterCfgFile,
-		Debug:    o.CommonOptions.Verbose,
+		FilePath:        o.ClusterCfgFile,
+		Debug:           o.CommonOptions.Verbose,
+		HostKeyChecking: o.CommonOptions.HostKeyChecking,
+		Events:          o.CommonOptions.Events,
+		TemplateDir:     o.CommonOptions.TemplateDir,
+		KnownHostsFile:  o.CommonOptions.KnownHostsFile,
 	}
 	return pipelines.CheckCerts(arg)
 }
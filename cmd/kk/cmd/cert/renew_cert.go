@@ -26,8 +26,11 @@ import (
 )
 
 type CertRenewOptions struct {
-	CommonOptions  *options.CommonOptions
-	ClusterCfgFile string
+	CommonOptions     *options.CommonOptions
+	ClusterCfgFile    string
+	FromCluster       bool
+	KubeConfig        string
+	ClusterConfigName string
 }
 
 func NewCertRenewOptions() *CertRenewOptions {
@@ -54,12 +57,23 @@ func NewCmdCertRenew() *cobra.Command {
 
 func (o *CertRenewOptions) Run() error {
 	arg := common.Argument{
-		FilePath: o.ClusterCfgFile,
-		Debug:    o.CommonOptions.Verbose,
+		FilePath:          o.ClusterCfgFile,
+		Debug:             o.CommonOptions.Verbose,
+		Namespace:         o.CommonOptions.Namespace,
+		FromCluster:       o.FromCluster,
+		KubeConfig:        o.KubeConfig,
+		ClusterConfigName: o.ClusterConfigName,
+		HostKeyChecking:   o.CommonOptions.HostKeyChecking,
+		Events:            o.CommonOptions.Events,
+		TemplateDir:       o.CommonOptions.TemplateDir,
+		KnownHostsFile:    o.CommonOptions.KnownHostsFile,
 	}
 	return pipelines.RenewCerts(arg)
 }
 
 func (o *CertRenewOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.ClusterCfgFile, "filename", "f", "", "Path to a configuration file")
+	cmd.Flags().BoolVarP(&o.FromCluster, "from-cluster", "", false, "Load the cluster configuration from a ConfigMap or Secret in the existing cluster")
+	cmd.Flags().StringVarP(&o.KubeConfig, "kubeconfig", "", "", "Specify a kubeconfig file to access the existing cluster")
+	cmd.Flags().StringVarP(&o.ClusterConfigName, "config-name", "", common.DefaultClusterConfigName, "The name of the ConfigMap or Secret which stores the cluster configuration")
 }
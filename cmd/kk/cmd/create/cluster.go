@@ -31,7 +31,9 @@ import (
 )
 
 type CreateClusterOptions struct {
-	CommonOptions *options.CommonOptions
+	CommonOptions  *options.CommonOptions
+	RolloutOptions *options.RolloutOptions
+	DryRunOptions  *options.DryRunOptions
 
 	ClusterCfgFile      string
 	Kubernetes          string
@@ -44,14 +46,18 @@ type CreateClusterOptions struct {
 	ContainerManager    string
 	DownloadCmd         string
 	Artifact            string
+	VerifyKey           string
 	InstallPackages     bool
+	Resume              bool
 
 	localStorageChanged bool
 }
 
 func NewCreateClusterOptions() *CreateClusterOptions {
 	return &CreateClusterOptions{
-		CommonOptions: options.NewCommonOptions(),
+		CommonOptions:  options.NewCommonOptions(),
+		RolloutOptions: options.NewRolloutOptions(),
+		DryRunOptions:  options.NewDryRunOptions(),
 	}
 }
 
@@ -64,11 +70,15 @@ func NewCmdCreateCluster() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			util.CheckErr(o.Complete(cmd, args))
 			util.CheckErr(o.Validate(cmd, args))
+			util.CheckErr(o.DryRunOptions.Validate())
+			util.CheckErr(o.RolloutOptions.Validate())
 			util.CheckErr(o.Run())
 		},
 	}
 
 	o.CommonOptions.AddCommonFlag(cmd)
+	o.RolloutOptions.AddRolloutFlag(cmd)
+	o.DryRunOptions.AddDryRunFlag(cmd)
 	o.AddFlags(cmd)
 
 	if err := completionSetting(cmd); err != nil {
@@ -120,8 +130,19 @@ func (o *CreateClusterOptions) Run() error {
 		SkipConfirmCheck:    o.CommonOptions.SkipConfirmCheck,
 		ContainerManager:    o.ContainerManager,
 		Artifact:            o.Artifact,
+		VerifyKey:           o.VerifyKey,
 		InstallPackages:     o.InstallPackages,
 		Namespace:           o.CommonOptions.Namespace,
+		Resume:              o.Resume,
+		HostKeyChecking:     o.CommonOptions.HostKeyChecking,
+		Events:              o.CommonOptions.Events,
+		TemplateDir:         o.CommonOptions.TemplateDir,
+		Concurrency:         o.RolloutOptions.Concurrency,
+		BatchSize:           o.RolloutOptions.BatchSize,
+		MaxFailures:         o.RolloutOptions.MaxFailures,
+		KnownHostsFile:      o.CommonOptions.KnownHostsFile,
+		DryRun:              o.DryRunOptions.DryRun,
+		DryRunFormat:        o.DryRunOptions.Format,
 	}
 
 	if o.localStorageChanged {
@@ -141,10 +162,12 @@ func (o *CreateClusterOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&o.SkipPushImages, "skip-push-images", "", false, "Skip pre push images")
 	cmd.Flags().BoolVarP(&o.SecurityEnhancement, "with-security-enhancement", "", false, "Security enhancement")
 	cmd.Flags().StringVarP(&o.ContainerManager, "container-manager", "", "docker", "Container runtime: docker, crio, containerd and isula.")
-	cmd.Flags().StringVarP(&o.DownloadCmd, "download-cmd", "", "curl -L -o %s %s",
-		`The user defined command to download the necessary binary files. The first param '%s' is output path, the second param '%s', is the URL`)
+	cmd.Flags().StringVarP(&o.DownloadCmd, "download-cmd", "", "",
+		`The user defined command to download the necessary binary files. The first param '%s' is output path, the second param '%s', is the URL. The built-in downloader is used if it is empty`)
 	cmd.Flags().StringVarP(&o.Artifact, "artifact", "a", "", "Path to a KubeKey artifact")
+	cmd.Flags().StringVarP(&o.VerifyKey, "verify-key", "", "", "Path to a PEM public key to verify the signature of the KubeKey artifact")
 	cmd.Flags().BoolVarP(&o.InstallPackages, "with-packages", "", false, "install operation system packages by artifact")
+	cmd.Flags().BoolVarP(&o.Resume, "resume", "", false, "Resume from the last failed module, the modules finished by the previous run with the same configuration will be skipped")
 }
 
 func completionSetting(cmd *cobra.Command) (err error) {
@@ -45,5 +45,6 @@ func NewCmdCreate() *cobra.Command {
 	cmd.AddCommand(NewCmdCreateCluster())
 	cmd.AddCommand(NewCmdCreateConfig())
 	cmd.AddCommand(NewCmdCreateManifest())
+	cmd.AddCommand(NewCmdCreateTemplates())
 	return cmd
 }
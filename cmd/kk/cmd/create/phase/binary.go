@@ -65,6 +65,10 @@ func (o *CreateBinaryOptions) Run() error {
 		FilePath:          o.ClusterCfgFile,
 		KubernetesVersion: o.Kubernetes,
 		Debug:             o.CommonOptions.Verbose,
+		HostKeyChecking:   o.CommonOptions.HostKeyChecking,
+		Events:            o.CommonOptions.Events,
+		TemplateDir:       o.CommonOptions.TemplateDir,
+		KnownHostsFile:    o.CommonOptions.KnownHostsFile,
 	}
 	return binary.CreateBinary(arg, o.DownloadCmd)
 }
@@ -72,8 +76,8 @@ func (o *CreateBinaryOptions) Run() error {
 func (o *CreateBinaryOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.ClusterCfgFile, "filename", "f", "", "Path to a configuration file")
 	cmd.Flags().StringVarP(&o.Kubernetes, "with-kubernetes", "", "", "Specify a supported version of kubernetes")
-	cmd.Flags().StringVarP(&o.DownloadCmd, "download-cmd", "", "curl -L -o %s %s",
-		`The user defined command to download the necessary binary files. The first param '%s' is output path, the second param '%s', is the URL`)
+	cmd.Flags().StringVarP(&o.DownloadCmd, "download-cmd", "", "",
+		`The user defined command to download the necessary binary files. The first param '%s' is output path, the second param '%s', is the URL. The built-in downloader is used if it is empty`)
 
 }
 
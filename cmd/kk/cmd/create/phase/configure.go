@@ -75,6 +75,10 @@ func (o *CreateConfigureKubernetesOptions) Run() error {
 		KubernetesVersion: o.Kubernetes,
 		Debug:             o.CommonOptions.Verbose,
 		Namespace:         o.CommonOptions.Namespace,
+		HostKeyChecking:   o.CommonOptions.HostKeyChecking,
+		Events:            o.CommonOptions.Events,
+		TemplateDir:       o.CommonOptions.TemplateDir,
+		KnownHostsFile:    o.CommonOptions.KnownHostsFile,
 	}
 
 	if o.localStorageChanged {
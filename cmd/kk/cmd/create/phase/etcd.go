@@ -54,8 +54,12 @@ func NewCmdCreateEtcd() *cobra.Command {
 
 func (o *CreateEtcdOptions) Run() error {
 	arg := common.Argument{
-		FilePath: o.ClusterCfgFile,
-		Debug:    o.CommonOptions.Verbose,
+		FilePath:        o.ClusterCfgFile,
+		Debug:           o.CommonOptions.Verbose,
+		HostKeyChecking: o.CommonOptions.HostKeyChecking,
+		Events:          o.CommonOptions.Events,
+		TemplateDir:     o.CommonOptions.TemplateDir,
+		KnownHostsFile:  o.CommonOptions.KnownHostsFile,
 	}
 	return etcd.CreateEtcd(arg)
 }
@@ -75,6 +75,10 @@ func (o *CreateImagesOptions) Run() error {
 		KubernetesVersion: o.Kubernetes,
 		ContainerManager:  o.ContainerManager,
 		Debug:             o.CommonOptions.Verbose,
+		HostKeyChecking:   o.CommonOptions.HostKeyChecking,
+		Events:            o.CommonOptions.Events,
+		TemplateDir:       o.CommonOptions.TemplateDir,
+		KnownHostsFile:    o.CommonOptions.KnownHostsFile,
 	}
 	return images.CreateImages(arg)
 }
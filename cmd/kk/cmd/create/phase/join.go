@@ -64,6 +64,10 @@ func (o *CreateJoinNodesOptions) Run() error {
 		KubernetesVersion: o.Kubernetes,
 		Debug:             o.CommonOptions.Verbose,
 		Namespace:         o.CommonOptions.Namespace,
+		HostKeyChecking:   o.CommonOptions.HostKeyChecking,
+		Events:            o.CommonOptions.Events,
+		TemplateDir:       o.CommonOptions.TemplateDir,
+		KnownHostsFile:    o.CommonOptions.KnownHostsFile,
 	}
 
 	return kubernetes.CreateJoinNodes(arg)
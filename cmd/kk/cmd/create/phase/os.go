@@ -58,6 +58,10 @@ func (o *ConfigOSOptions) Run() error {
 		FilePath:        o.ClusterCfgFile,
 		Debug:           o.CommonOptions.Verbose,
 		InstallPackages: o.InstallPackages,
+		HostKeyChecking: o.CommonOptions.HostKeyChecking,
+		Events:          o.CommonOptions.Events,
+		TemplateDir:     o.CommonOptions.TemplateDir,
+		KnownHostsFile:  o.CommonOptions.KnownHostsFile,
 	}
 	return os.ConfigOS(arg)
 }
@@ -0,0 +1,63 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package create
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/util"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/templates"
+)
+
+type CreateTemplatesOptions struct {
+	Dir       string
+	Overwrite bool
+}
+
+func NewCreateTemplatesOptions() *CreateTemplatesOptions {
+	return &CreateTemplatesOptions{}
+}
+
+// NewCmdCreateTemplates creates a create templates command
+func NewCmdCreateTemplates() *cobra.Command {
+	o := NewCreateTemplatesOptions()
+	cmd := &cobra.Command{
+		Use:   "templates",
+		Short: "Dump the built-in templates to start a --template-dir from",
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.Run())
+		},
+	}
+
+	o.AddFlags(cmd)
+	return cmd
+}
+
+func (o *CreateTemplatesOptions) Run() error {
+	if err := templates.Dump(o.Dir, o.Overwrite); err != nil {
+		return err
+	}
+	fmt.Printf("The built-in templates are written into %s\n", o.Dir)
+	return nil
+}
+
+func (o *CreateTemplatesOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.Dir, "dir", "d", "templates", "Path to the directory which the templates are written into")
+	cmd.Flags().BoolVarP(&o.Overwrite, "overwrite", "", false, "Overwrite the templates which already exist in the directory")
+}
@@ -27,6 +27,7 @@ import (
 
 type DeleteClusterOptions struct {
 	CommonOptions  *options.CommonOptions
+	DryRunOptions  *options.DryRunOptions
 	ClusterCfgFile string
 	Kubernetes     string
 	DeleteCRI      bool
@@ -35,6 +36,7 @@ type DeleteClusterOptions struct {
 func NewDeleteClusterOptions() *DeleteClusterOptions {
 	return &DeleteClusterOptions{
 		CommonOptions: options.NewCommonOptions(),
+		DryRunOptions: options.NewDryRunOptions(),
 	}
 }
 
@@ -45,11 +47,13 @@ func NewCmdDeleteCluster() *cobra.Command {
 		Use:   "cluster",
 		Short: "Delete a cluster",
 		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.DryRunOptions.Validate())
 			util.CheckErr(o.Run())
 		},
 	}
 
 	o.CommonOptions.AddCommonFlag(cmd)
+	o.DryRunOptions.AddDryRunFlag(cmd)
 	o.AddFlags(cmd)
 	return cmd
 }
@@ -61,6 +65,12 @@ func (o *DeleteClusterOptions) Run() error {
 		KubernetesVersion: o.Kubernetes,
 		DeleteCRI:         o.DeleteCRI,
 		SkipConfirmCheck:  o.CommonOptions.SkipConfirmCheck,
+		HostKeyChecking:   o.CommonOptions.HostKeyChecking,
+		Events:            o.CommonOptions.Events,
+		TemplateDir:       o.CommonOptions.TemplateDir,
+		KnownHostsFile:    o.CommonOptions.KnownHostsFile,
+		DryRun:            o.DryRunOptions.DryRun,
+		DryRunFormat:      o.DryRunOptions.Format,
 	}
 	return pipelines.DeleteCluster(arg)
 }
@@ -30,6 +30,7 @@ import (
 
 type DeleteNodeOptions struct {
 	CommonOptions  *options.CommonOptions
+	DryRunOptions  *options.DryRunOptions
 	ClusterCfgFile string
 	nodeName       string
 }
@@ -37,6 +38,7 @@ type DeleteNodeOptions struct {
 func NewDeleteNodeOptions() *DeleteNodeOptions {
 	return &DeleteNodeOptions{
 		CommonOptions: options.NewCommonOptions(),
+		DryRunOptions: options.NewDryRunOptions(),
 	}
 }
 
@@ -49,11 +51,13 @@ func NewCmdDeleteNode() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			util.CheckErr(o.Complete(cmd, args))
 			util.CheckErr(o.Validate())
+			util.CheckErr(o.DryRunOptions.Validate())
 			util.CheckErr(o.Run())
 		},
 	}
 
 	o.CommonOptions.AddCommonFlag(cmd)
+	o.DryRunOptions.AddDryRunFlag(cmd)
 	o.AddFlags(cmd)
 	return cmd
 }
@@ -76,6 +80,12 @@ func (o *DeleteNodeOptions) Run() error {
 		Debug:            o.CommonOptions.Verbose,
 		NodeName:         o.nodeName,
 		SkipConfirmCheck: o.CommonOptions.SkipConfirmCheck,
+		HostKeyChecking:  o.CommonOptions.HostKeyChecking,
+		Events:           o.CommonOptions.Events,
+		TemplateDir:      o.CommonOptions.TemplateDir,
+		KnownHostsFile:   o.CommonOptions.KnownHostsFile,
+		DryRun:           o.DryRunOptions.DryRun,
+		DryRunFormat:     o.DryRunOptions.Format,
 	}
 	return pipelines.DeleteNode(arg)
 }
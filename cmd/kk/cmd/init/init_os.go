@@ -27,13 +27,16 @@ import (
 
 type InitOsOptions struct {
 	CommonOptions  *options.CommonOptions
+	RolloutOptions *options.RolloutOptions
 	ClusterCfgFile string
 	Artifact       string
+	VerifyKey      string
 }
 
 func NewInitOsOptions() *InitOsOptions {
 	return &InitOsOptions{
-		CommonOptions: options.NewCommonOptions(),
+		CommonOptions:  options.NewCommonOptions(),
+		RolloutOptions: options.NewRolloutOptions(),
 	}
 }
 
@@ -44,20 +47,30 @@ func NewCmdInitOs() *cobra.Command {
 		Use:   "os",
 		Short: "Init operating system",
 		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.RolloutOptions.Validate())
 			util.CheckErr(o.Run())
 		},
 	}
 
 	o.CommonOptions.AddCommonFlag(cmd)
+	o.RolloutOptions.AddRolloutFlag(cmd)
 	o.AddFlags(cmd)
 	return cmd
 }
 
 func (o *InitOsOptions) Run() error {
 	arg := common.Argument{
-		FilePath: o.ClusterCfgFile,
-		Debug:    o.CommonOptions.Verbose,
-		Artifact: o.Artifact,
+		FilePath:        o.ClusterCfgFile,
+		Debug:           o.CommonOptions.Verbose,
+		Artifact:        o.Artifact,
+		VerifyKey:       o.VerifyKey,
+		HostKeyChecking: o.CommonOptions.HostKeyChecking,
+		Events:          o.CommonOptions.Events,
+		TemplateDir:     o.CommonOptions.TemplateDir,
+		Concurrency:     o.RolloutOptions.Concurrency,
+		BatchSize:       o.RolloutOptions.BatchSize,
+		MaxFailures:     o.RolloutOptions.MaxFailures,
+		KnownHostsFile:  o.CommonOptions.KnownHostsFile,
 	}
 	return pipelines.InitDependencies(arg)
 }
@@ -65,4 +78,5 @@ func (o *InitOsOptions) Run() error {
 func (o *InitOsOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.ClusterCfgFile, "filename", "f", "", "Path to a configuration file")
 	cmd.Flags().StringVarP(&o.Artifact, "artifact", "a", "", "Path to a KubeKey artifact")
+	cmd.Flags().StringVarP(&o.VerifyKey, "verify-key", "", "", "Path to a PEM public key to verify the signature of the KubeKey artifact")
 }
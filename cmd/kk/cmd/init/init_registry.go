@@ -30,6 +30,7 @@ type InitRegistryOptions struct {
 	ClusterCfgFile string
 	DownloadCmd    string
 	Artifact       string
+	VerifyKey      string
 }
 
 func NewInitRegistryOptions() *InitRegistryOptions {
@@ -61,16 +62,22 @@ func (o *InitRegistryOptions) Complete(_ *cobra.Command, _ []string) error {
 
 func (o *InitRegistryOptions) Run() error {
 	arg := common.Argument{
-		FilePath: o.ClusterCfgFile,
-		Debug:    o.CommonOptions.Verbose,
-		Artifact: o.Artifact,
+		FilePath:        o.ClusterCfgFile,
+		Debug:           o.CommonOptions.Verbose,
+		Artifact:        o.Artifact,
+		VerifyKey:       o.VerifyKey,
+		HostKeyChecking: o.CommonOptions.HostKeyChecking,
+		Events:          o.CommonOptions.Events,
+		TemplateDir:     o.CommonOptions.TemplateDir,
+		KnownHostsFile:  o.CommonOptions.KnownHostsFile,
 	}
 	return pipelines.InitRegistry(arg, o.DownloadCmd)
 }
 
 func (o *InitRegistryOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.ClusterCfgFile, "filename", "f", "", "Path to a configuration file")
-	cmd.Flags().StringVarP(&o.DownloadCmd, "download-cmd", "", "curl -L -o %s %s",
-		`The user defined command to download the necessary files. The first param '%s' is output path, the second param '%s', is the URL`)
+	cmd.Flags().StringVarP(&o.DownloadCmd, "download-cmd", "", "",
+		`The user defined command to download the necessary files. The first param '%s' is output path, the second param '%s', is the URL. The built-in downloader is used if it is empty`)
 	cmd.Flags().StringVarP(&o.Artifact, "artifact", "a", "", "Path to a KubeKey artifact")
+	cmd.Flags().StringVarP(&o.VerifyKey, "verify-key", "", "", "Path to a PEM public key to verify the signature of the KubeKey artifact")
 }
@@ -25,6 +25,10 @@ type CommonOptions struct {
 	SkipConfirmCheck bool
 	IgnoreErr        bool
 	Namespace        string
+	HostKeyChecking  string
+	KnownHostsFile   string
+	Events           string
+	TemplateDir      string
 }
 
 func NewCommonOptions() *CommonOptions {
@@ -36,4 +40,8 @@ func (o *CommonOptions) AddCommonFlag(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&o.SkipConfirmCheck, "yes", "y", false, "Skip confirm check")
 	cmd.Flags().BoolVar(&o.IgnoreErr, "ignore-err", false, "Ignore the error message, remove the host which reported error and force to continue")
 	cmd.Flags().StringVar(&o.Namespace, "namespace", "kubekey-system", "KubeKey namespace to use")
+	cmd.Flags().StringVar(&o.HostKeyChecking, "host-key-checking", "", "How to verify the SSH host keys: strict, tofu or insecure. The hosts without hostKeyChecking in the configuration file use it (default insecure)")
+	cmd.Flags().StringVar(&o.KnownHostsFile, "known-hosts", "", "Path to the known_hosts file used to verify the SSH host keys (default ~/.kube/kk_known_hosts)")
+	cmd.Flags().StringVar(&o.Events, "events", "", "Write the pipeline events as JSON lines to the target: stdout, unix://<socket> or a file path")
+	cmd.Flags().StringVar(&o.TemplateDir, "template-dir", "", "Path to a directory of templates, a template in it replaces the built-in one with the same name")
 }
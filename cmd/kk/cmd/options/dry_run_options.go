@@ -0,0 +1,48 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+)
+
+type DryRunOptions struct {
+	DryRun bool
+	Format string
+}
+
+func NewDryRunOptions() *DryRunOptions {
+	return &DryRunOptions{}
+}
+
+func (o *DryRunOptions) AddDryRunFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false, "Record the remote commands, copied files and rendered templates of each host instead of executing them")
+	cmd.Flags().StringVar(&o.Format, "dry-run-format", common.DryRunScript, "The format of the dry-run report: script or json")
+}
+
+func (o *DryRunOptions) Validate() error {
+	switch o.Format {
+	case common.DryRunScript, common.DryRunJSON:
+		return nil
+	default:
+		return fmt.Errorf("unsupported dry-run format [%s], it must be %s or %s", o.Format, common.DryRunScript, common.DryRunJSON)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type RolloutOptions struct {
+	Concurrency int
+	BatchSize   string
+	MaxFailures string
+}
+
+func NewRolloutOptions() *RolloutOptions {
+	return &RolloutOptions{}
+}
+
+func (o *RolloutOptions) AddRolloutFlag(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&o.Concurrency, "concurrency", 0, "The max number of hosts which run a task at the same time (default 10)")
+	cmd.Flags().StringVar(&o.BatchSize, "batch-size", "", "Run each task on the hosts in waves of the size, a number or a percentage of the hosts such as 10%")
+	cmd.Flags().StringVar(&o.MaxFailures, "max-failures", "", "The number or the percentage of the hosts which may fail before a task fails, the failed hosts are removed from the pipeline. A failed master or etcd host always fails the task")
+}
+
+func (o *RolloutOptions) Validate() error {
+	if o.Concurrency < 0 {
+		return fmt.Errorf("invalid concurrency [%d], it must not be negative", o.Concurrency)
+	}
+	for flag, value := range map[string]string{"batch-size": o.BatchSize, "max-failures": o.MaxFailures} {
+		if value == "" {
+			continue
+		}
+		v := intstr.Parse(value)
+		n, err := intstr.GetScaledValueFromIntOrPercent(&v, 100, true)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid %s [%s], it must be a number or a percentage such as 10%%", flag, value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package restore
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/options"
+)
+
+type RestoreOptions struct {
+	CommonOptions *options.CommonOptions
+}
+
+func NewRestoreOptions() *RestoreOptions {
+	return &RestoreOptions{
+		CommonOptions: options.NewCommonOptions(),
+	}
+}
+
+// NewCmdRestore creates a new restore command
+func NewCmdRestore() *cobra.Command {
+	o := NewRestoreOptions()
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the cluster data from a backup",
+	}
+
+	o.CommonOptions.AddCommonFlag(cmd)
+
+	cmd.AddCommand(NewCmdRestoreETCD())
+	return cmd
+}
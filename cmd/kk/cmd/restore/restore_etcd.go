@@ -0,0 +1,105 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package restore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/options"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/util"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/pipelines"
+)
+
+type RestoreETCDOptions struct {
+	CommonOptions     *options.CommonOptions
+	ClusterCfgFile    string
+	Snapshot          string
+	FromCluster       bool
+	KubeConfig        string
+	ClusterConfigName string
+}
+
+func NewRestoreETCDOptions() *RestoreETCDOptions {
+	return &RestoreETCDOptions{
+		CommonOptions: options.NewCommonOptions(),
+	}
+}
+
+// NewCmdRestoreETCD creates a new restore etcd command
+func NewCmdRestoreETCD() *cobra.Command {
+	o := NewRestoreETCDOptions()
+	cmd := &cobra.Command{
+		Use:   "etcd",
+		Short: "Restore the etcd cluster installed by kubekey from a snapshot",
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.Validate(cmd, args))
+			util.CheckErr(o.Run())
+		},
+	}
+
+	o.CommonOptions.AddCommonFlag(cmd)
+	o.AddFlags(cmd)
+	return cmd
+}
+
+func (o *RestoreETCDOptions) Validate(_ *cobra.Command, _ []string) error {
+	if o.Snapshot == "" {
+		return fmt.Errorf("--snapshot is required")
+	}
+	snapshot, err := filepath.Abs(o.Snapshot)
+	if err != nil {
+		return err
+	}
+	if fi, err := os.Stat(snapshot); err != nil {
+		return fmt.Errorf("invalid snapshot file %s: %v", o.Snapshot, err)
+	} else if fi.IsDir() {
+		return fmt.Errorf("invalid snapshot file %s: it is a directory", o.Snapshot)
+	}
+	o.Snapshot = snapshot
+	return nil
+}
+
+func (o *RestoreETCDOptions) Run() error {
+	arg := common.Argument{
+		FilePath:          o.ClusterCfgFile,
+		Debug:             o.CommonOptions.Verbose,
+		Namespace:         o.CommonOptions.Namespace,
+		SkipConfirmCheck:  o.CommonOptions.SkipConfirmCheck,
+		FromCluster:       o.FromCluster,
+		KubeConfig:        o.KubeConfig,
+		ClusterConfigName: o.ClusterConfigName,
+		HostKeyChecking:   o.CommonOptions.HostKeyChecking,
+		Events:            o.CommonOptions.Events,
+		TemplateDir:       o.CommonOptions.TemplateDir,
+		KnownHostsFile:    o.CommonOptions.KnownHostsFile,
+		EtcdSnapshot:      o.Snapshot,
+	}
+	return pipelines.RestoreETCD(arg)
+}
+
+func (o *RestoreETCDOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.ClusterCfgFile, "filename", "f", "", "Path to a configuration file")
+	cmd.Flags().StringVarP(&o.Snapshot, "snapshot", "", "", "Path to the etcd snapshot file to restore from")
+	cmd.Flags().BoolVarP(&o.FromCluster, "from-cluster", "", false, "Load the cluster configuration from a ConfigMap or Secret in the existing cluster")
+	cmd.Flags().StringVarP(&o.KubeConfig, "kubeconfig", "", "", "Specify a kubeconfig file to access the existing cluster")
+	cmd.Flags().StringVarP(&o.ClusterConfigName, "config-name", "", common.DefaultClusterConfigName, "The name of the ConfigMap or Secret which stores the cluster configuration")
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/add"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/alpha"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/apply"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/artifact"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/cert"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/completion"
@@ -36,6 +37,7 @@ import (
 	initOs "github.com/kubesphere/kubekey/v3/cmd/kk/cmd/init"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/options"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/plugin"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/restore"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/upgrade"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/cmd/version"
 )
@@ -112,8 +114,10 @@ func NewKubeKeyCommand(o KubeKeyOptions) *cobra.Command {
 	cmds.AddCommand(create.NewCmdCreate())
 	cmds.AddCommand(delete.NewCmdDelete())
 	cmds.AddCommand(add.NewCmdAdd())
+	cmds.AddCommand(apply.NewCmdApply())
 	cmds.AddCommand(upgrade.NewCmdUpgrade())
 	cmds.AddCommand(cert.NewCmdCerts())
+	cmds.AddCommand(restore.NewCmdRestore())
 	cmds.AddCommand(artifact.NewCmdArtifact())
 
 	cmds.AddCommand(plugin.NewCmdPlugin(o.IOStreams))
@@ -80,6 +80,10 @@ func (o *UpgradeKubeSphereOptions) Run() error {
 		KsVersion:        o.KubeSphere,
 		SkipConfirmCheck: o.CommonOptions.SkipConfirmCheck,
 		Debug:            o.CommonOptions.Verbose,
+		HostKeyChecking:  o.CommonOptions.HostKeyChecking,
+		Events:           o.CommonOptions.Events,
+		TemplateDir:      o.CommonOptions.TemplateDir,
+		KnownHostsFile:   o.CommonOptions.KnownHostsFile,
 	}
 	return alpha.UpgradeKubeSphere(arg)
 }
@@ -31,19 +31,27 @@ import (
 )
 
 type UpgradeOptions struct {
-	CommonOptions    *options.CommonOptions
-	ClusterCfgFile   string
-	Kubernetes       string
-	EnableKubeSphere bool
-	KubeSphere       string
-	SkipPullImages   bool
-	DownloadCmd      string
-	Artifact         string
+	CommonOptions     *options.CommonOptions
+	RolloutOptions    *options.RolloutOptions
+	DryRunOptions     *options.DryRunOptions
+	ClusterCfgFile    string
+	Kubernetes        string
+	EnableKubeSphere  bool
+	KubeSphere        string
+	SkipPullImages    bool
+	DownloadCmd       string
+	Artifact          string
+	VerifyKey         string
+	FromCluster       bool
+	KubeConfig        string
+	ClusterConfigName string
 }
 
 func NewUpgradeOptions() *UpgradeOptions {
 	return &UpgradeOptions{
-		CommonOptions: options.NewCommonOptions(),
+		CommonOptions:  options.NewCommonOptions(),
+		RolloutOptions: options.NewRolloutOptions(),
+		DryRunOptions:  options.NewDryRunOptions(),
 	}
 }
 
@@ -55,10 +63,14 @@ func NewCmdUpgrade() *cobra.Command {
 		Short: "Upgrade your cluster smoothly to a newer version with this command",
 		Run: func(cmd *cobra.Command, args []string) {
 			util.CheckErr(o.Complete(cmd, args))
+			util.CheckErr(o.DryRunOptions.Validate())
+			util.CheckErr(o.RolloutOptions.Validate())
 			util.CheckErr(o.Run())
 		},
 	}
 	o.CommonOptions.AddCommonFlag(cmd)
+	o.RolloutOptions.AddRolloutFlag(cmd)
+	o.DryRunOptions.AddDryRunFlag(cmd)
 	o.AddFlags(cmd)
 
 	if err := completionSetting(cmd); err != nil {
@@ -88,6 +100,20 @@ func (o *UpgradeOptions) Run() error {
 		Debug:             o.CommonOptions.Verbose,
 		SkipConfirmCheck:  o.CommonOptions.SkipConfirmCheck,
 		Artifact:          o.Artifact,
+		VerifyKey:         o.VerifyKey,
+		Namespace:         o.CommonOptions.Namespace,
+		FromCluster:       o.FromCluster,
+		KubeConfig:        o.KubeConfig,
+		ClusterConfigName: o.ClusterConfigName,
+		HostKeyChecking:   o.CommonOptions.HostKeyChecking,
+		Events:            o.CommonOptions.Events,
+		TemplateDir:       o.CommonOptions.TemplateDir,
+		Concurrency:       o.RolloutOptions.Concurrency,
+		BatchSize:         o.RolloutOptions.BatchSize,
+		MaxFailures:       o.RolloutOptions.MaxFailures,
+		KnownHostsFile:    o.CommonOptions.KnownHostsFile,
+		DryRun:            o.DryRunOptions.DryRun,
+		DryRunFormat:      o.DryRunOptions.Format,
 	}
 	return pipelines.UpgradeCluster(arg, o.DownloadCmd)
 }
@@ -97,9 +123,13 @@ func (o *UpgradeOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.Kubernetes, "with-kubernetes", "", "", "Specify a supported version of kubernetes")
 	cmd.Flags().BoolVarP(&o.EnableKubeSphere, "with-kubesphere", "", false, fmt.Sprintf("Deploy a specific version of kubesphere (default %s)", kubesphere.Latest().Version))
 	cmd.Flags().BoolVarP(&o.SkipPullImages, "skip-pull-images", "", false, "Skip pre pull images")
-	cmd.Flags().StringVarP(&o.DownloadCmd, "download-cmd", "", "curl -L -o %s %s",
-		`The user defined command to download the necessary binary files. The first param '%s' is output path, the second param '%s', is the URL`)
+	cmd.Flags().StringVarP(&o.DownloadCmd, "download-cmd", "", "",
+		`The user defined command to download the necessary binary files. The first param '%s' is output path, the second param '%s', is the URL. The built-in downloader is used if it is empty`)
 	cmd.Flags().StringVarP(&o.Artifact, "artifact", "a", "", "Path to a KubeKey artifact")
+	cmd.Flags().StringVarP(&o.VerifyKey, "verify-key", "", "", "Path to a PEM public key to verify the signature of the KubeKey artifact")
+	cmd.Flags().BoolVarP(&o.FromCluster, "from-cluster", "", false, "Load the cluster configuration from a ConfigMap or Secret in the existing cluster")
+	cmd.Flags().StringVarP(&o.KubeConfig, "kubeconfig", "", "", "Specify a kubeconfig file to access the existing cluster")
+	cmd.Flags().StringVarP(&o.ClusterConfigName, "config-name", "", common.DefaultClusterConfigName, "The name of the ConfigMap or Secret which stores the cluster configuration")
 }
 
 func completionSetting(cmd *cobra.Command) (err error) {
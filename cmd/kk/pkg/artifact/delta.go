@@ -0,0 +1,170 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package artifact
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ManifestFile is the manifest spec which an artifact is exported from.
+	ManifestFile = "manifest.json"
+	// DeltaFile marks a delta artifact, which only contains the files missing from its base artifact.
+	// Its DigestsFile still lists the files of the base artifact, so a delta can be the base of another one.
+	DeltaFile = "delta.json"
+	// ImageIndexFile is the index of the OCI layout of the images.
+	ImageIndexFile = "images/index.json"
+)
+
+// the pipeline cache keys of the base artifact which are only used in this package.
+const (
+	baseDigestsSum = "artifactBaseDigestsSum"
+	baseImageIndex = "artifactBaseImageIndex"
+	prunedDigests  = "artifactPrunedDigests"
+)
+
+// digestsHistoryFile lists the DigestsSum of the artifacts which were unpacked into the work dir before the
+// current one, a delta artifact exported against any of them can still be imported.
+const digestsHistoryFile = "SHA256SUMS.history"
+
+// Delta is the content of the DeltaFile.
+type Delta struct {
+	// Base is the sha256 of the DigestsFile of the base artifact.
+	Base string `json:"base"`
+}
+
+// ReadArtifactFiles reads the named files from the root of an artifact tarball without unpacking it.
+func ReadArtifactFiles(path string, names ...string) (map[string][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open artifact %s failed", path)
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read artifact %s failed", path)
+	}
+	defer gr.Close()
+
+	wanted := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		wanted[name] = struct{}{}
+	}
+	result := make(map[string][]byte, len(names))
+	tr := tar.NewReader(gr)
+	for len(result) < len(wanted) {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "read artifact %s failed", path)
+		}
+		if _, ok := wanted[hdr.Name]; !ok || hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "read %s of artifact %s failed", hdr.Name, path)
+		}
+		result[hdr.Name] = content
+	}
+	return result, nil
+}
+
+// ParseDigests parses the content of a DigestsFile into a map of the file path to its sha256.
+func ParseDigests(content []byte) map[string]string {
+	digests := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), "  ", 2)
+		if len(fields) == 2 {
+			digests[fields[1]] = fields[0]
+		}
+	}
+	return digests
+}
+
+// DigestsSum returns the sha256 of the content of a DigestsFile, which identifies an artifact.
+func DigestsSum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// MergeDigests adds the digests which are missing from the content of a DigestsFile, so that the DigestsFile of
+// a delta artifact still lists the files which are pruned from it and identifies the artifact it results in.
+func MergeDigests(content []byte, digests map[string]string) []byte {
+	merged := ParseDigests(content)
+	for path, sum := range digests {
+		if _, ok := merged[path]; !ok {
+			merged[path] = sum
+		}
+	}
+	paths := make([]string, 0, len(merged))
+	for path := range merged {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var buf bytes.Buffer
+	for _, path := range paths {
+		fmt.Fprintf(&buf, "%s  %s\n", merged[path], path)
+	}
+	return buf.Bytes()
+}
+
+// MergeImageIndex adds the images of the base index which are missing from the index.
+func MergeImageIndex(base, index []byte) ([]byte, error) {
+	var baseIndex, newIndex imgspecv1.Index
+	if err := json.Unmarshal(base, &baseIndex); err != nil {
+		return nil, errors.Wrap(err, "parse the image index of the base artifact failed")
+	}
+	if err := json.Unmarshal(index, &newIndex); err != nil {
+		return nil, errors.Wrap(err, "parse the image index failed")
+	}
+
+	key := func(d imgspecv1.Descriptor) string {
+		if ref, ok := d.Annotations[imgspecv1.AnnotationRefName]; ok {
+			return ref
+		}
+		return d.Digest.String()
+	}
+	exist := make(map[string]struct{}, len(newIndex.Manifests))
+	for _, d := range newIndex.Manifests {
+		exist[key(d)] = struct{}{}
+	}
+	for _, d := range baseIndex.Manifests {
+		if _, ok := exist[key(d)]; !ok {
+			newIndex.Manifests = append(newIndex.Manifests, d)
+		}
+	}
+	return json.Marshal(newIndex)
+}
@@ -0,0 +1,59 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package artifact
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestParseDigests(t *testing.T) {
+	digests := ParseDigests([]byte("aaa  kube/v1.24.0/amd64/kubeadm\nbbb  images/index.json\n\n"))
+	if len(digests) != 2 || digests["kube/v1.24.0/amd64/kubeadm"] != "aaa" || digests["images/index.json"] != "bbb" {
+		t.Fatalf("unexpected digests %v", digests)
+	}
+}
+
+func TestMergeImageIndex(t *testing.T) {
+	ref := func(name, d string) imgspecv1.Descriptor {
+		return imgspecv1.Descriptor{
+			MediaType:   imgspecv1.MediaTypeImageManifest,
+			Digest:      digest.Digest("sha256:" + d),
+			Annotations: map[string]string{imgspecv1.AnnotationRefName: name},
+		}
+	}
+	base, _ := json.Marshal(imgspecv1.Index{Manifests: []imgspecv1.Descriptor{ref("pause:3.8-amd64", "a"), ref("coredns:1.9.3-amd64", "b")}})
+	index, _ := json.Marshal(imgspecv1.Index{Manifests: []imgspecv1.Descriptor{ref("pause:3.8-amd64", "c")}})
+
+	merged, err := MergeImageIndex(base, index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result imgspecv1.Index
+	if err := json.Unmarshal(merged, &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Manifests) != 2 {
+		t.Fatalf("expected 2 manifests, got %d", len(result.Manifests))
+	}
+	if result.Manifests[0].Digest != "sha256:c" || result.Manifests[1].Digest != "sha256:b" {
+		t.Fatalf("unexpected manifests %v", result.Manifests)
+	}
+}
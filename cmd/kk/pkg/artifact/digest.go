@@ -0,0 +1,234 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package artifact
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DigestsFile lists the sha256 of every file of an artifact in the format of sha256sum.
+	DigestsFile = "SHA256SUMS"
+	// SignatureFile is the base64 encoded signature of the DigestsFile, it can be verified by "cosign verify-blob".
+	SignatureFile = "SHA256SUMS.sig"
+)
+
+// WriteDigests writes the DigestsFile at the root of dir for all the regular files under it.
+func WriteDigests(dir string) error {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == DigestsFile || rel == SignatureFile {
+			return nil
+		}
+		paths = append(paths, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "walk %s failed", dir)
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	for _, path := range paths {
+		sum, err := sha256File(filepath.Join(dir, path))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&buf, "%s  %s\n", sum, path)
+	}
+	return os.WriteFile(filepath.Join(dir, DigestsFile), buf.Bytes(), 0644)
+}
+
+// VerifyDigests checks that every file listed in the DigestsFile under dir exists and has the same sha256.
+func VerifyDigests(dir string) error {
+	f, err := os.Open(filepath.Join(dir, DigestsFile))
+	if err != nil {
+		return errors.Wrapf(err, "open %s failed", DigestsFile)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.SplitN(scanner.Text(), "  ", 2)
+		if len(fields) != 2 {
+			return errors.Errorf("invalid line %d of %s", line, DigestsFile)
+		}
+		// the listed files must be inside dir.
+		rel := filepath.Clean(filepath.FromSlash(fields[1]))
+		if rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+			return errors.Errorf("invalid path %q at line %d of %s", fields[1], line, DigestsFile)
+		}
+		sum, err := sha256File(filepath.Join(dir, rel))
+		if err != nil {
+			return err
+		}
+		if sum != fields[0] {
+			return errors.Errorf("sha256 of %s is %s, the artifact expects %s", fields[1], sum, fields[0])
+		}
+	}
+	return scanner.Err()
+}
+
+// VerifyArtifactFiles checks that every regular file in the artifact tarball is listed in the content of its
+// DigestsFile, so that no unsigned file is unpacked with the artifact.
+func VerifyArtifactFiles(artifact string, digests []byte) error {
+	listed := ParseDigests(digests)
+	f, err := os.Open(artifact)
+	if err != nil {
+		return errors.Wrapf(err, "open artifact %s failed", artifact)
+	}
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return errors.Wrapf(err, "read artifact %s failed", artifact)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "read artifact %s failed", artifact)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := filepath.ToSlash(filepath.Clean(hdr.Name))
+		if name == DigestsFile || name == SignatureFile {
+			continue
+		}
+		if _, ok := listed[name]; !ok {
+			return errors.Errorf("%s of artifact %s is not listed in %s", hdr.Name, artifact, DigestsFile)
+		}
+	}
+}
+
+// Sign signs data with a PEM encoded ed25519 or ECDSA private key and returns the base64 encoded signature.
+func Sign(data, keyPEM []byte) ([]byte, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("no PEM data is found in the sign key")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, errors.Errorf("unsupported sign key type %q, an unencrypted PKCS#8 ed25519 or ECDSA key is required", block.Type)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "parse the sign key failed")
+	}
+
+	var sig []byte
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		sig, err = k.Sign(rand.Reader, data, crypto.Hash(0))
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256(data)
+		sig, err = ecdsa.SignASN1(rand.Reader, k, digest[:])
+	default:
+		return nil, errors.Errorf("unsupported sign key %T, an ed25519 or ECDSA key is required", key)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "sign failed")
+	}
+	return []byte(base64.StdEncoding.EncodeToString(sig)), nil
+}
+
+// Verify verifies the base64 encoded signature of data with a PEM encoded ed25519 or ECDSA public key.
+func Verify(data, signature, pubPEM []byte) error {
+	block, _ := pem.Decode(pubPEM)
+	if block == nil {
+		return errors.New("no PEM data is found in the verify key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "parse the verify key failed")
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return errors.Wrap(err, "decode the signature failed")
+	}
+
+	var ok bool
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(k, data, sig)
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		ok = ecdsa.VerifyASN1(k, digest[:], sig)
+	default:
+		return errors.Errorf("unsupported verify key %T, an ed25519 or ECDSA key is required", key)
+	}
+	if !ok {
+		return errors.New("the signature of the artifact is invalid")
+	}
+	return nil
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "open %s failed", path)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", errors.Wrapf(err, "read %s failed", path)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,125 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package artifact
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDigests(t *testing.T) {
+	dir := t.TempDir()
+	for path, content := range map[string]string{
+		"kube/v1.24.0/amd64/kubeadm":                           "kubeadm",
+		"images/blobs/sha256/0123456789abcdef":                 "layer",
+		"repository/amd64/ubuntu/20.04/ubuntu-20.04-amd64.iso": "iso",
+	} {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := WriteDigests(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyDigests(dir); err != nil {
+		t.Fatalf("verify the untouched files: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "kube/v1.24.0/amd64/kubeadm"), []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyDigests(dir); err == nil {
+		t.Error("a tampered file should fail the verification")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, DigestsFile), []byte("0000  ../../etc/passwd\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyDigests(dir); err == nil {
+		t.Error("a path outside the artifact should be rejected")
+	}
+}
+
+func TestVerifyArtifactFiles(t *testing.T) {
+	artifact := export(t, map[string]string{"kube/kubeadm": "v1"}, "")
+	files, err := ReadArtifactFiles(artifact, DigestsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyArtifactFiles(artifact, files[DigestsFile]); err != nil {
+		t.Fatalf("verify the listed files: %v", err)
+	}
+
+	digests := MergeDigests(nil, map[string]string{"kube/kubeadm": "0000", ManifestFile: "0000"})
+	if err := VerifyArtifactFiles(artifact, digests); err != nil {
+		t.Fatalf("the digests file itself does not need to be listed: %v", err)
+	}
+	if err := VerifyArtifactFiles(artifact, MergeDigests(nil, map[string]string{"kube/kubeadm": "0000"})); err == nil {
+		t.Error("a file which is not listed in the digests should be rejected")
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  kube/v1.24.0/amd64/kubeadm\n")
+	for name, key := range map[string]crypto.Signer{"ed25519": edKey, "ecdsa": ecKey} {
+		t.Run(name, func(t *testing.T) {
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pubDer, err := x509.MarshalPKIXPublicKey(key.Public())
+			if err != nil {
+				t.Fatal(err)
+			}
+			priv := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+			pub := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDer})
+
+			sig, err := Sign(data, priv)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Verify(data, sig, pub); err != nil {
+				t.Fatalf("verify a valid signature: %v", err)
+			}
+			if err := Verify(append(data, 'x'), sig, pub); err == nil {
+				t.Error("a signature of other data should be invalid")
+			}
+		})
+	}
+}
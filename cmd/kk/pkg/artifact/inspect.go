@@ -0,0 +1,241 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package artifact
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	kubekeyv1alpha2 "github.com/kubesphere/kubekey/v3/cmd/kk/apis/kubekey/v1alpha2"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/files"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/images"
+)
+
+// the checksum status of a binary of an artifact.
+const (
+	ChecksumOK       = "ok"
+	ChecksumMismatch = "mismatch"
+	ChecksumUnknown  = "unknown"
+)
+
+// Inspection is the contents of an artifact.
+type Inspection struct {
+	Manifest *kubekeyv1alpha2.ManifestSpec `json:"manifest,omitempty"`
+	Delta    *Delta                        `json:"delta,omitempty"`
+	Signed   bool                          `json:"signed"`
+	Binaries []InspectedBinary             `json:"binaries"`
+	Images   []InspectedImage              `json:"images"`
+	ISOs     []string                      `json:"isos"`
+}
+
+// InspectedBinary is a binary of an artifact, its checksum is compared with the one in components.json.
+type InspectedBinary struct {
+	Type     string `json:"type"`
+	ID       string `json:"id"`
+	Arch     string `json:"arch"`
+	Version  string `json:"version"`
+	Path     string `json:"path"`
+	SHA256   string `json:"sha256"`
+	Checksum string `json:"checksum"`
+}
+
+// InspectedImage is an image of an artifact.
+type InspectedImage struct {
+	Image    string `json:"image"`
+	Platform string `json:"platform"`
+}
+
+// Mismatches returns the binaries whose checksum is not the one in components.json.
+func (i *Inspection) Mismatches() []InspectedBinary {
+	var result []InspectedBinary
+	for _, b := range i.Binaries {
+		if b.Checksum == ChecksumMismatch {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// Print writes the inspection in a human-readable format.
+func (i *Inspection) Print(out io.Writer) error {
+	if i.Manifest != nil {
+		content, err := yaml.Marshal(i.Manifest)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, _ = fmt.Fprintf(out, "Manifest:\n%s\n", content)
+	} else {
+		_, _ = fmt.Fprintf(out, "Manifest: <none>\n\n")
+	}
+	if i.Delta != nil {
+		_, _ = fmt.Fprintf(out, "Delta of: %s\n", i.Delta.Base)
+	}
+	_, _ = fmt.Fprintf(out, "Signed: %t\n\n", i.Signed)
+
+	w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TYPE\tID\tARCH\tVERSION\tCHECKSUM\tPATH")
+	for _, b := range i.Binaries {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", b.Type, b.ID, b.Arch, b.Version, b.Checksum, b.Path)
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "IMAGE\tPLATFORM")
+	for _, image := range i.Images {
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", image.Image, image.Platform)
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "ISO")
+	for _, iso := range i.ISOs {
+		_, _ = fmt.Fprintln(w, iso)
+	}
+	return w.Flush()
+}
+
+// Inspect reads the contents of an artifact tarball without unpacking it.
+func Inspect(path string) (*Inspection, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open artifact %s failed", path)
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read artifact %s failed", path)
+	}
+	defer gr.Close()
+
+	result := &Inspection{}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "read artifact %s failed", path)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch name := hdr.Name; {
+		case name == ManifestFile:
+			result.Manifest = new(kubekeyv1alpha2.ManifestSpec)
+			if err := json.NewDecoder(tr).Decode(result.Manifest); err != nil {
+				return nil, errors.Wrapf(err, "parse %s of artifact %s failed", name, path)
+			}
+		case name == DeltaFile:
+			result.Delta = new(Delta)
+			if err := json.NewDecoder(tr).Decode(result.Delta); err != nil {
+				return nil, errors.Wrapf(err, "parse %s of artifact %s failed", name, path)
+			}
+		case name == SignatureFile:
+			result.Signed = true
+		case name == ImageIndexFile:
+			content, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "read %s of artifact %s failed", name, path)
+			}
+			if result.Images, err = parseImageIndex(content); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(name, "repository/"):
+			result.ISOs = append(result.ISOs, name)
+		default:
+			b := files.LookupKubeBinary(name)
+			if b == nil {
+				continue
+			}
+			h := sha256.New()
+			if _, err := io.Copy(h, tr); err != nil {
+				return nil, errors.Wrapf(err, "read %s of artifact %s failed", name, path)
+			}
+			result.Binaries = append(result.Binaries, inspectBinary(b, name, hex.EncodeToString(h.Sum(nil))))
+		}
+	}
+
+	sort.Slice(result.Binaries, func(i, j int) bool {
+		return result.Binaries[i].Path < result.Binaries[j].Path
+	})
+	sort.Strings(result.ISOs)
+	return result, nil
+}
+
+func inspectBinary(b *files.KubeBinary, path, sum string) InspectedBinary {
+	binary := InspectedBinary{
+		Type:     b.Type,
+		ID:       b.ID,
+		Arch:     b.Arch,
+		Version:  b.Version,
+		Path:     path,
+		SHA256:   sum,
+		Checksum: ChecksumUnknown,
+	}
+	if expected := b.GetSha256(); expected != "" {
+		binary.Checksum = ChecksumOK
+		if expected != sum {
+			binary.Checksum = ChecksumMismatch
+		}
+	}
+	return binary
+}
+
+// parseImageIndex lists the images of the OCI index written by SaveImages, whose ref names look like
+// kubesphere:kube-apiserver:v1.21.5-amd64.
+func parseImageIndex(content []byte) ([]InspectedImage, error) {
+	index := images.NewIndex()
+	if err := json.Unmarshal(content, index); err != nil {
+		return nil, errors.Wrapf(err, "parse %s failed", ImageIndexFile)
+	}
+
+	result := make([]InspectedImage, 0, len(index.Manifests))
+	for _, m := range index.Manifests {
+		ref := m.Annotations.RefName
+		nameArr := strings.SplitN(ref, ":", 3)
+		if len(nameArr) != 3 {
+			return nil, errors.Errorf("invalid ref name: %s", ref)
+		}
+		image, p, err := images.SplitImageArchTag(fmt.Sprintf("%s/%s:%s", nameArr[0], nameArr[1], nameArr[2]))
+		if err != nil {
+			return nil, err
+		}
+		platform := p.OS + "/" + p.Architecture
+		if p.Variant != "" {
+			platform += "/" + p.Variant
+		}
+		result = append(result, InspectedImage{Image: image, Platform: platform})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Image != result[j].Image {
+			return result[i].Image < result[j].Image
+		}
+		return result[i].Platform < result[j].Platform
+	})
+	return result, nil
+}
@@ -0,0 +1,108 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package artifact
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/files"
+)
+
+func TestInspect(t *testing.T) {
+	kubeadm, kubectl := "kubeadm", "kubectl"
+	sum := sha256.Sum256([]byte(kubeadm))
+	origin := files.FileSha256
+	defer func() { files.FileSha256 = origin }()
+	files.FileSha256 = map[string]map[string]map[string]string{
+		"kubeadm": {"amd64": {"v1.24.0": hex.EncodeToString(sum[:])}},
+		"kubectl": {"amd64": {"v1.24.0": "0000"}},
+	}
+
+	path := filepath.Join(t.TempDir(), "artifact.tar.gz")
+	writeArtifact(t, path, map[string]string{
+		ManifestFile:                 `{"arches":["amd64"],"images":["docker.io/kubesphere/pause:3.7"]}`,
+		ImageIndexFile:               `{"manifests":[{"annotations":{"org.opencontainers.image.ref.name":"kubesphere:pause:3.7-amd64"}},{"annotations":{"org.opencontainers.image.ref.name":"kubesphere:pause:3.7-arm-v7"}}]}`,
+		"kube/v1.24.0/amd64/kubeadm": kubeadm,
+		"kube/v1.24.0/amd64/kubectl": kubectl,
+		"helm/v3.9.0/amd64/helm":     "helm",
+		"repository/amd64/ubuntu/20.04/ubuntu-20.04-amd64.iso": "iso",
+	})
+
+	inspection, err := Inspect(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inspection.Manifest == nil || len(inspection.Manifest.Images) != 1 || inspection.Signed || inspection.Delta != nil {
+		t.Fatalf("unexpected inspection %+v", inspection)
+	}
+
+	checksums := map[string]string{}
+	for _, b := range inspection.Binaries {
+		checksums[b.ID] = b.Checksum
+	}
+	expected := map[string]string{"kubeadm": ChecksumOK, "kubectl": ChecksumMismatch, "helm": ChecksumUnknown}
+	if len(checksums) != len(expected) {
+		t.Fatalf("expected binaries %v, got %v", expected, checksums)
+	}
+	for id, checksum := range expected {
+		if checksums[id] != checksum {
+			t.Errorf("expected checksum of %s to be %s, got %s", id, checksum, checksums[id])
+		}
+	}
+	if len(inspection.Mismatches()) != 1 {
+		t.Errorf("expected 1 mismatch, got %d", len(inspection.Mismatches()))
+	}
+
+	if len(inspection.Images) != 2 ||
+		inspection.Images[0] != (InspectedImage{Image: "kubesphere/pause:3.7", Platform: "linux/amd64"}) ||
+		inspection.Images[1] != (InspectedImage{Image: "kubesphere/pause:3.7", Platform: "linux/arm/v7"}) {
+		t.Errorf("unexpected images %v", inspection.Images)
+	}
+	if len(inspection.ISOs) != 1 {
+		t.Errorf("unexpected isos %v", inspection.ISOs)
+	}
+}
+
+func writeArtifact(t *testing.T, path string, contents map[string]string) {
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	for name, content := range contents {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -48,6 +48,26 @@ func (r *RepositoryModule) Init() {
 	}
 }
 
+type DeltaModule struct {
+	common.ArtifactModule
+}
+
+func (d *DeltaModule) Init() {
+	d.Name = "ArtifactDeltaModule"
+	d.Desc = "Load the base artifact of a delta artifact"
+
+	load := &task.LocalTask{
+		Name:    "LoadBaseArtifact",
+		Desc:    "Load the manifest and the digests of the base artifact",
+		Prepare: new(EnableDelta),
+		Action:  new(LoadBase),
+	}
+
+	d.Tasks = []task.Interface{
+		load,
+	}
+}
+
 type ArchiveModule struct {
 	common.ArtifactModule
 }
@@ -56,6 +76,46 @@ func (a *ArchiveModule) Init() {
 	a.Name = "ArtifactArchiveModule"
 	a.Desc = "Archive the dependencies"
 
+	embedManifest := &task.LocalTask{
+		Name:   "EmbedManifest",
+		Desc:   "Embed the manifest into the artifact",
+		Action: new(EmbedManifest),
+	}
+
+	mergeBaseImages := &task.LocalTask{
+		Name:    "MergeBaseImages",
+		Desc:    "Merge the images index of the base artifact",
+		Prepare: new(EnableDelta),
+		Action:  new(MergeBaseImages),
+	}
+
+	pruneBaseFiles := &task.LocalTask{
+		Name:    "PruneBaseFiles",
+		Desc:    "Remove the files which are in the base artifact",
+		Prepare: new(EnableDelta),
+		Action:  new(PruneBaseFiles),
+	}
+
+	writeDelta := &task.LocalTask{
+		Name:    "WriteDelta",
+		Desc:    "Mark the artifact as a delta of the base artifact",
+		Prepare: new(EnableDelta),
+		Action:  new(WriteDelta),
+	}
+
+	digests := &task.LocalTask{
+		Name:   "GenerateDigests",
+		Desc:   "Generate the sha256 digests of the dependencies",
+		Action: new(GenerateDigests),
+	}
+
+	sign := &task.LocalTask{
+		Name:    "SignDigests",
+		Desc:    "Sign the digests of the dependencies",
+		Prepare: new(EnableSign),
+		Action:  new(SignDigests),
+	}
+
 	archive := &task.LocalTask{
 		Name:   "ArchiveDependencies",
 		Desc:   "Archive the dependencies",
@@ -63,6 +123,12 @@ func (a *ArchiveModule) Init() {
 	}
 
 	a.Tasks = []task.Interface{
+		embedManifest,
+		mergeBaseImages,
+		pruneBaseFiles,
+		writeDelta,
+		digests,
+		sign,
 		archive,
 	}
 }
@@ -93,6 +159,12 @@ func (u *UnArchiveModule) Init() {
 		Action:  new(UnArchive),
 	}
 
+	verify := &task.LocalTask{
+		Name:   "VerifyArtifact",
+		Desc:   "Verify the signature and the digests of the KubeKey artifact",
+		Action: new(VerifyArtifact),
+	}
+
 	createMd5File := &task.LocalTask{
 		Name:    "CreateArtifactMd5File",
 		Desc:    "Create the KubeKey artifact Md5 file",
@@ -103,6 +175,7 @@ func (u *UnArchiveModule) Init() {
 	u.Tasks = []task.Interface{
 		md5Check,
 		unArchive,
+		verify,
 		createMd5File,
 	}
 }
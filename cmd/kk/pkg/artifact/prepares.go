@@ -36,6 +36,22 @@ func (e *EnableDownload) PreCheck(_ connector.Runtime) (bool, error) {
 	return false, nil
 }
 
+type EnableSign struct {
+	common.ArtifactPrepare
+}
+
+func (e *EnableSign) PreCheck(_ connector.Runtime) (bool, error) {
+	return e.Manifest.Arg.SignKey != "", nil
+}
+
+type EnableDelta struct {
+	common.ArtifactPrepare
+}
+
+func (e *EnableDelta) PreCheck(_ connector.Runtime) (bool, error) {
+	return e.Manifest.Arg.Base != "", nil
+}
+
 type Md5AreEqual struct {
 	common.KubePrepare
 	Not bool
@@ -17,8 +17,11 @@
 package artifact
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -26,10 +29,12 @@ import (
 
 	"github.com/pkg/errors"
 
+	kubekeyv1alpha2 "github.com/kubesphere/kubekey/v3/cmd/kk/apis/kubekey/v1alpha2"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
 	coreutil "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/files"
 )
 
 type DownloadISOFile struct {
@@ -37,13 +42,26 @@ type DownloadISOFile struct {
 }
 
 func (d *DownloadISOFile) Execute(runtime connector.Runtime) error {
+	base, _ := d.PipelineCache.Get(common.ArtifactBaseSpec)
 	for i, sys := range d.Manifest.Spec.OperatingSystems {
 		if sys.Repository.Iso.Url == "" {
 			continue
 		}
+		if base != nil && baseHasISO(base.(*kubekeyv1alpha2.ManifestSpec), sys) {
+			logger.Log.Infof("%s-%s-%s iso file is in the base artifact, skip it", sys.Id, sys.Version, sys.Arch)
+			continue
+		}
 
 		fileName := fmt.Sprintf("%s-%s-%s.iso", sys.Id, sys.Version, sys.Arch)
 		filePath := filepath.Join(runtime.GetWorkDir(), fileName)
+		if d.Manifest.Arg.DownloadCommand == nil {
+			if err := files.DefaultDownloader.Download(context.Background(), sys.Repository.Iso.Url, filePath, ""); err != nil {
+				return fmt.Errorf("Failed to download %s iso file: %s error: %w ", fileName, sys.Repository.Iso.Url, err)
+			}
+			d.Manifest.Spec.OperatingSystems[i].Repository.Iso.LocalPath = filePath
+			continue
+		}
+
 		getCmd := d.Manifest.Arg.DownloadCommand(filePath, sys.Repository.Iso.Url)
 
 		cmd := exec.Command("/bin/sh", "-c", getCmd)
@@ -116,17 +134,241 @@ func (a *ArchiveDependencies) Execute(runtime connector.Runtime) error {
 	return nil
 }
 
+type GenerateDigests struct {
+	common.ArtifactAction
+}
+
+func (g *GenerateDigests) Execute(runtime connector.Runtime) error {
+	dir := filepath.Join(runtime.GetWorkDir(), common.Artifact)
+	if err := WriteDigests(dir); err != nil {
+		return err
+	}
+	pruned, ok := g.PipelineCache.Get(prunedDigests)
+	if !ok {
+		return nil
+	}
+
+	path := filepath.Join(dir, DigestsFile)
+	digests, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(errors.WithStack(err), "read %s failed", DigestsFile)
+	}
+	return os.WriteFile(path, MergeDigests(digests, pruned.(map[string]string)), 0644)
+}
+
+type SignDigests struct {
+	common.ArtifactAction
+}
+
+func (s *SignDigests) Execute(runtime connector.Runtime) error {
+	dir := filepath.Join(runtime.GetWorkDir(), common.Artifact)
+	digests, err := os.ReadFile(filepath.Join(dir, DigestsFile))
+	if err != nil {
+		return errors.Wrapf(errors.WithStack(err), "read %s failed", DigestsFile)
+	}
+	key, err := os.ReadFile(s.Manifest.Arg.SignKey)
+	if err != nil {
+		return errors.Wrapf(errors.WithStack(err), "read sign key %s failed", s.Manifest.Arg.SignKey)
+	}
+	sig, err := Sign(digests, key)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, SignatureFile), sig, 0644)
+}
+
+type LoadBase struct {
+	common.ArtifactAction
+}
+
+func (l *LoadBase) Execute(_ connector.Runtime) error {
+	base := l.Manifest.Arg.Base
+	files, err := ReadArtifactFiles(base, ManifestFile, DigestsFile, ImageIndexFile)
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{ManifestFile, DigestsFile} {
+		if _, ok := files[name]; !ok {
+			return errors.Errorf("the base artifact %s has no %s, export it again to use it as a base", base, name)
+		}
+	}
+
+	spec := new(kubekeyv1alpha2.ManifestSpec)
+	if err := json.Unmarshal(files[ManifestFile], spec); err != nil {
+		return errors.Wrapf(err, "parse the %s of the base artifact %s failed", ManifestFile, base)
+	}
+	l.PipelineCache.Set(common.ArtifactBaseSpec, spec)
+	l.PipelineCache.Set(common.ArtifactBaseDigests, ParseDigests(files[DigestsFile]))
+	l.PipelineCache.Set(baseDigestsSum, DigestsSum(files[DigestsFile]))
+	if index, ok := files[ImageIndexFile]; ok {
+		l.PipelineCache.Set(baseImageIndex, index)
+	}
+	return nil
+}
+
+type EmbedManifest struct {
+	common.ArtifactAction
+}
+
+func (e *EmbedManifest) Execute(runtime connector.Runtime) error {
+	content, err := json.Marshal(e.Manifest.Spec)
+	if err != nil {
+		return errors.Wrap(errors.WithStack(err), "marshal the manifest failed")
+	}
+	return os.WriteFile(filepath.Join(runtime.GetWorkDir(), common.Artifact, ManifestFile), content, 0644)
+}
+
+type MergeBaseImages struct {
+	common.ArtifactAction
+}
+
+func (m *MergeBaseImages) Execute(runtime connector.Runtime) error {
+	base, ok := m.PipelineCache.Get(baseImageIndex)
+	path := filepath.Join(runtime.GetWorkDir(), common.Artifact, filepath.FromSlash(ImageIndexFile))
+	if !ok || !coreutil.IsExist(path) {
+		return nil
+	}
+
+	index, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(errors.WithStack(err), "read %s failed", path)
+	}
+	merged, err := MergeImageIndex(base.([]byte), index)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, merged, 0644)
+}
+
+type PruneBaseFiles struct {
+	common.ArtifactAction
+}
+
+func (p *PruneBaseFiles) Execute(runtime connector.Runtime) error {
+	digestsObj, ok := p.PipelineCache.Get(common.ArtifactBaseDigests)
+	if !ok {
+		return errors.New("get the digests of the base artifact by pipeline cache failed")
+	}
+	digests := digestsObj.(map[string]string)
+
+	pruned := make(map[string]string)
+	dir := filepath.Join(runtime.GetWorkDir(), common.Artifact)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == ManifestFile {
+			return nil
+		}
+		baseSum, ok := digests[rel]
+		if !ok {
+			return nil
+		}
+		if sum, err := sha256File(path); err != nil || sum != baseSum {
+			return err
+		}
+		pruned[rel] = baseSum
+		return os.Remove(path)
+	})
+	if err != nil {
+		return err
+	}
+	p.PipelineCache.Set(prunedDigests, pruned)
+	return nil
+}
+
+type WriteDelta struct {
+	common.ArtifactAction
+}
+
+func (w *WriteDelta) Execute(runtime connector.Runtime) error {
+	sum, ok := w.PipelineCache.GetMustString(baseDigestsSum)
+	if !ok {
+		return errors.New("get the digests sum of the base artifact by pipeline cache failed")
+	}
+	content, err := json.Marshal(Delta{Base: sum})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return os.WriteFile(filepath.Join(runtime.GetWorkDir(), common.Artifact, DeltaFile), content, 0644)
+}
+
 type UnArchive struct {
 	common.KubeAction
 }
 
 func (u *UnArchive) Execute(runtime connector.Runtime) error {
-	if err := coreutil.Untar(u.KubeConf.Arg.Artifact, runtime.GetWorkDir()); err != nil {
+	workDir := runtime.GetWorkDir()
+	delta, err := checkDeltaBase(u.KubeConf.Arg.Artifact, workDir)
+	if err != nil {
+		return err
+	}
+	if err := recordDigestsHistory(workDir, delta); err != nil {
+		return err
+	}
+
+	// the digests of a previous artifact must not be used to verify this one.
+	for _, name := range []string{DigestsFile, SignatureFile, DeltaFile} {
+		if err := os.RemoveAll(filepath.Join(workDir, name)); err != nil {
+			return errors.Wrapf(errors.WithStack(err), "remove %s failed", name)
+		}
+	}
+	if err := coreutil.Untar(u.KubeConf.Arg.Artifact, workDir); err != nil {
 		return errors.Wrapf(errors.WithStack(err), "unArchive %s failed", u.KubeConf.Arg.Artifact)
 	}
 	return nil
 }
 
+type VerifyArtifact struct {
+	common.KubeAction
+}
+
+func (v *VerifyArtifact) Execute(runtime connector.Runtime) error {
+	dir := runtime.GetWorkDir()
+	verifyKey := v.KubeConf.Arg.VerifyKey
+	if !coreutil.IsExist(filepath.Join(dir, DigestsFile)) {
+		if verifyKey != "" {
+			return errors.Errorf("the artifact %s has no %s, it can not be verified", v.KubeConf.Arg.Artifact, DigestsFile)
+		}
+		logger.Log.Warnf("the artifact %s has no %s, its files are not verified", v.KubeConf.Arg.Artifact, DigestsFile)
+		return nil
+	}
+
+	digests, err := os.ReadFile(filepath.Join(dir, DigestsFile))
+	if err != nil {
+		return errors.Wrapf(errors.WithStack(err), "read %s failed", DigestsFile)
+	}
+	sigFile := filepath.Join(dir, SignatureFile)
+	if verifyKey != "" {
+		sig, err := os.ReadFile(sigFile)
+		if err != nil {
+			return errors.Wrapf(errors.WithStack(err), "the artifact %s is not signed", v.KubeConf.Arg.Artifact)
+		}
+		pub, err := os.ReadFile(verifyKey)
+		if err != nil {
+			return errors.Wrapf(errors.WithStack(err), "read verify key %s failed", verifyKey)
+		}
+		if err := Verify(digests, sig, pub); err != nil {
+			return errors.Wrapf(err, "verify the artifact %s failed", v.KubeConf.Arg.Artifact)
+		}
+	} else if coreutil.IsExist(sigFile) {
+		logger.Log.Warnf("the artifact %s is signed, but its signature is not verified without --verify-key", v.KubeConf.Arg.Artifact)
+	}
+
+	if err := VerifyArtifactFiles(v.KubeConf.Arg.Artifact, digests); err != nil {
+		return errors.Wrapf(err, "verify the artifact %s failed", v.KubeConf.Arg.Artifact)
+	}
+	if err := VerifyDigests(dir); err != nil {
+		return errors.Wrapf(err, "verify the artifact %s failed", v.KubeConf.Arg.Artifact)
+	}
+	return nil
+}
+
 type Md5Check struct {
 	common.KubeAction
 }
@@ -170,3 +412,70 @@ func (c *CreateMd5File) Execute(runtime connector.Runtime) error {
 	}
 	return nil
 }
+
+func baseHasISO(base *kubekeyv1alpha2.ManifestSpec, sys kubekeyv1alpha2.OperatingSystem) bool {
+	for _, b := range base.OperatingSystems {
+		if b.Id == sys.Id && b.Version == sys.Version && b.Arch == sys.Arch && b.Repository.Iso.Url == sys.Repository.Iso.Url {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDeltaBase makes sure that a delta artifact is unpacked onto the artifact which it is exported against,
+// or onto a delta of it. It returns whether the artifact is a delta.
+func checkDeltaBase(artifact, workDir string) (bool, error) {
+	files, err := ReadArtifactFiles(artifact, DeltaFile)
+	if err != nil {
+		return false, err
+	}
+	content, ok := files[DeltaFile]
+	if !ok {
+		return false, nil
+	}
+	delta := new(Delta)
+	if err := json.Unmarshal(content, delta); err != nil {
+		return true, errors.Wrapf(err, "parse the %s of artifact %s failed", DeltaFile, artifact)
+	}
+
+	digests, err := os.ReadFile(filepath.Join(workDir, DigestsFile))
+	if err != nil {
+		return true, errors.Errorf("%s is a delta artifact, import its base artifact into %s first", artifact, workDir)
+	}
+	sum := DigestsSum(digests)
+	if sum == delta.Base {
+		return true, nil
+	}
+	history, err := os.ReadFile(filepath.Join(workDir, digestsHistoryFile))
+	if err != nil && !os.IsNotExist(err) {
+		return true, errors.Wrapf(errors.WithStack(err), "read %s failed", digestsHistoryFile)
+	}
+	for _, imported := range strings.Fields(string(history)) {
+		if imported == delta.Base {
+			return true, nil
+		}
+	}
+	return true, errors.Errorf("%s is a delta artifact of the artifact %s, but the artifact in %s is %s", artifact, delta.Base, workDir, sum)
+}
+
+// recordDigestsHistory keeps the DigestsSum of the artifact in workDir before a delta artifact replaces its
+// DigestsFile, the history starts over with a full artifact.
+func recordDigestsHistory(workDir string, delta bool) error {
+	path := filepath.Join(workDir, digestsHistoryFile)
+	if !delta {
+		return errors.Wrapf(errors.WithStack(os.RemoveAll(path)), "remove %s failed", digestsHistoryFile)
+	}
+	digests, err := os.ReadFile(filepath.Join(workDir, DigestsFile))
+	if err != nil {
+		return errors.Wrapf(errors.WithStack(err), "read %s failed", DigestsFile)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrapf(errors.WithStack(err), "open %s failed", digestsHistoryFile)
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintln(f, DigestsSum(digests)); err != nil {
+		return errors.Wrapf(errors.WithStack(err), "write %s failed", digestsHistoryFile)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package artifact
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/action"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/cache"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
+	coreutil "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
+)
+
+type fakeRuntime struct {
+	connector.Runtime
+	workDir string
+}
+
+func (f *fakeRuntime) GetWorkDir() string {
+	return f.workDir
+}
+
+type executor interface {
+	Execute(runtime connector.Runtime) error
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// export runs the tasks of the artifact export which build the artifact from the files, as a delta if base is set.
+func export(t *testing.T, files map[string]string, base string) string {
+	t.Helper()
+	runtime := &fakeRuntime{workDir: t.TempDir()}
+	writeFiles(t, filepath.Join(runtime.workDir, common.Artifact), files)
+	writeFiles(t, filepath.Join(runtime.workDir, common.Artifact), map[string]string{ManifestFile: "{}"})
+
+	output := filepath.Join(t.TempDir(), "kubekey-artifact.tar.gz")
+	artifactAction := common.ArtifactAction{
+		BaseAction: action.BaseAction{PipelineCache: cache.NewCache()},
+		Manifest:   &common.ArtifactManifest{Arg: common.ArtifactArgument{Base: base, Output: output}},
+	}
+	actions := []executor{&GenerateDigests{artifactAction}}
+	if base != "" {
+		actions = []executor{&LoadBase{artifactAction}, &PruneBaseFiles{artifactAction}, &WriteDelta{artifactAction}, &GenerateDigests{artifactAction}}
+	}
+	for _, a := range actions {
+		if err := a.Execute(runtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	src := filepath.Join(runtime.workDir, common.Artifact)
+	if err := coreutil.Tar(src, output, src); err != nil {
+		t.Fatal(err)
+	}
+	return output
+}
+
+func unArchive(workDir, artifact string) error {
+	u := &UnArchive{KubeAction: common.KubeAction{KubeConf: &common.KubeConf{Arg: common.Argument{Artifact: artifact}}}}
+	return u.Execute(&fakeRuntime{workDir: workDir})
+}
+
+func TestPruneBaseFiles(t *testing.T) {
+	base := export(t, map[string]string{"kube/kubeadm": "v1", "kube/kubelet": "v1"}, "")
+	delta := export(t, map[string]string{"kube/kubeadm": "v1", "kube/kubelet": "v2", "kube/kubectl": "v1"}, base)
+
+	files, err := ReadArtifactFiles(delta, "kube/kubeadm", "kube/kubelet", "kube/kubectl", DigestsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := files["kube/kubeadm"]; ok {
+		t.Error("the file which is the same as in the base artifact should be pruned")
+	}
+	for _, name := range []string{"kube/kubelet", "kube/kubectl"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("the delta artifact should contain %s", name)
+		}
+	}
+
+	digests := ParseDigests(files[DigestsFile])
+	for _, name := range []string{"kube/kubeadm", "kube/kubelet", "kube/kubectl", ManifestFile, DeltaFile} {
+		if _, ok := digests[name]; !ok {
+			t.Errorf("the digests of the delta artifact should list %s", name)
+		}
+	}
+}
+
+func TestCheckDeltaBase(t *testing.T) {
+	base := export(t, map[string]string{"kube/kubeadm": "v1"}, "")
+	other := export(t, map[string]string{"kube/kubeadm": "v0"}, "")
+	delta := export(t, map[string]string{"kube/kubeadm": "v2"}, base)
+
+	workDir := t.TempDir()
+	if _, err := checkDeltaBase(delta, workDir); err == nil {
+		t.Error("a delta artifact should not be imported into an empty work dir")
+	}
+	if isDelta, err := checkDeltaBase(other, workDir); err != nil || isDelta {
+		t.Errorf("checkDeltaBase() of a full artifact = %v, %v", isDelta, err)
+	}
+
+	if err := unArchive(workDir, other); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := checkDeltaBase(delta, workDir); err == nil {
+		t.Error("a delta artifact should not be imported onto another artifact")
+	}
+
+	if err := unArchive(workDir, base); err != nil {
+		t.Fatal(err)
+	}
+	if isDelta, err := checkDeltaBase(delta, workDir); err != nil || !isDelta {
+		t.Errorf("checkDeltaBase() of a delta onto its base = %v, %v", isDelta, err)
+	}
+}
+
+func TestImportDeltas(t *testing.T) {
+	base := export(t, map[string]string{"kube/kubeadm": "v1", "kube/kubelet": "v1"}, "")
+	delta1 := export(t, map[string]string{"kube/kubeadm": "v1", "kube/kubelet": "v2"}, base)
+	// both a delta of the original base and a delta of the previous delta can be imported.
+	delta2 := export(t, map[string]string{"kube/kubeadm": "v1", "kube/kubelet": "v3"}, base)
+	delta3 := export(t, map[string]string{"kube/kubeadm": "v1", "kube/kubelet": "v3", "kube/kubectl": "v1"}, delta2)
+
+	workDir := t.TempDir()
+	for _, artifact := range []string{base, delta1, delta2, delta3} {
+		if err := unArchive(workDir, artifact); err != nil {
+			t.Fatalf("import %s: %v", artifact, err)
+		}
+		if err := VerifyDigests(workDir); err != nil {
+			t.Fatalf("verify %s: %v", artifact, err)
+		}
+	}
+	for name, want := range map[string]string{"kube/kubeadm": "v1", "kube/kubelet": "v3", "kube/kubectl": "v1"} {
+		if got, err := os.ReadFile(filepath.Join(workDir, name)); err != nil || string(got) != want {
+			t.Errorf("%s = %q, %v, want %q", name, got, err, want)
+		}
+	}
+
+	// a full artifact starts the history over.
+	other := export(t, map[string]string{"kube/kubeadm": "v0"}, "")
+	if err := unArchive(workDir, other); err != nil {
+		t.Fatal(err)
+	}
+	if err := unArchive(workDir, delta2); err == nil {
+		t.Error("a delta artifact should not be imported onto another full artifact")
+	}
+}
@@ -46,6 +46,7 @@ func K3sFilesDownloadHTTP(kubeConf *common.KubeConf, path, version, arch string,
 	}
 
 	binariesMap := make(map[string]*files.KubeBinary)
+	downloads := make([]*files.KubeBinary, 0, len(binaries))
 	for _, binary := range binaries {
 		if err := binary.CreateBaseDir(); err != nil {
 			return errors.Wrapf(errors.WithStack(err), "create file %s base dir failed", binary.FileName)
@@ -64,9 +65,11 @@ func K3sFilesDownloadHTTP(kubeConf *common.KubeConf, path, version, arch string,
 			}
 		}
 
-		if err := binary.Download(); err != nil {
-			return fmt.Errorf("Failed to download %s binary: %s error: %w ", binary.ID, binary.GetCmd(), err)
-		}
+		downloads = append(downloads, binary)
+	}
+
+	if err := files.DownloadBinaries(downloads, files.DefaultDownloadParallel); err != nil {
+		return err
 	}
 
 	pipelineCache.Set(common.KubeBinaries+"-"+arch, binariesMap)
@@ -104,6 +107,7 @@ func K3sArtifactBinariesDownload(manifest *common.ArtifactManifest, path, arch,
 		binaries = append(binaries, crictl)
 	}
 
+	downloads := make([]*files.KubeBinary, 0, len(binaries))
 	for _, binary := range binaries {
 		if err := binary.CreateBaseDir(); err != nil {
 			return errors.Wrapf(errors.WithStack(err), "create file %s base dir failed", binary.FileName)
@@ -120,9 +124,11 @@ func K3sArtifactBinariesDownload(manifest *common.ArtifactManifest, path, arch,
 			}
 		}
 
-		if err := binary.Download(); err != nil {
-			return fmt.Errorf("Failed to download %s binary: %s error: %w ", binary.ID, binary.GetCmd(), err)
-		}
+		downloads = append(downloads, binary)
+	}
+
+	if err := files.DownloadBinaries(downloads, files.DefaultDownloadParallel); err != nil {
+		return err
 	}
 
 	return nil
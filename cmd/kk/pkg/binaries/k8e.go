@@ -40,6 +40,7 @@ func K8eFilesDownloadHTTP(kubeConf *common.KubeConf, path, version, arch string,
 
 	binaries := []*files.KubeBinary{k8e, helm, kubecni, etcd}
 	binariesMap := make(map[string]*files.KubeBinary)
+	downloads := make([]*files.KubeBinary, 0, len(binaries))
 	for _, binary := range binaries {
 		if err := binary.CreateBaseDir(); err != nil {
 			return errors.Wrapf(errors.WithStack(err), "create file %s base dir failed", binary.FileName)
@@ -58,9 +59,11 @@ func K8eFilesDownloadHTTP(kubeConf *common.KubeConf, path, version, arch string,
 			}
 		}
 
-		if err := binary.Download(); err != nil {
-			return fmt.Errorf("Failed to download %s binary: %s error: %w ", binary.ID, binary.GetCmd(), err)
-		}
+		downloads = append(downloads, binary)
+	}
+
+	if err := files.DownloadBinaries(downloads, files.DefaultDownloadParallel); err != nil {
+		return err
 	}
 
 	pipelineCache.Set(common.KubeBinaries+"-"+arch, binariesMap)
@@ -98,6 +101,7 @@ func K8eArtifactBinariesDownload(manifest *common.ArtifactManifest, path, arch,
 		binaries = append(binaries, crictl)
 	}
 
+	downloads := make([]*files.KubeBinary, 0, len(binaries))
 	for _, binary := range binaries {
 		if err := binary.CreateBaseDir(); err != nil {
 			return errors.Wrapf(errors.WithStack(err), "create file %s base dir failed", binary.FileName)
@@ -114,9 +118,11 @@ func K8eArtifactBinariesDownload(manifest *common.ArtifactManifest, path, arch,
 			}
 		}
 
-		if err := binary.Download(); err != nil {
-			return fmt.Errorf("Failed to download %s binary: %s error: %w ", binary.ID, binary.GetCmd(), err)
-		}
+		downloads = append(downloads, binary)
+	}
+
+	if err := files.DownloadBinaries(downloads, files.DefaultDownloadParallel); err != nil {
+		return err
 	}
 
 	return nil
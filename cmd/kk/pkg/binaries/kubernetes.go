@@ -17,6 +17,7 @@
 package binaries
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 
@@ -43,14 +44,21 @@ func K8sFilesDownloadHTTP(kubeConf *common.KubeConf, path, version, arch string,
 	crictl := files.NewKubeBinary("crictl", arch, kubekeyapiv1alpha2.DefaultCrictlVersion, path, kubeConf.Arg.DownloadCommand)
 	containerd := files.NewKubeBinary("containerd", arch, kubekeyapiv1alpha2.DefaultContainerdVersion, path, kubeConf.Arg.DownloadCommand)
 	runc := files.NewKubeBinary("runc", arch, kubekeyapiv1alpha2.DefaultRuncVersion, path, kubeConf.Arg.DownloadCommand)
+	crio := files.NewKubeBinary("crio", arch, kubekeyapiv1alpha2.DefaultCrioVersion, path, kubeConf.Arg.DownloadCommand)
+	criDockerd := files.NewKubeBinary("cri-dockerd", arch, kubekeyapiv1alpha2.DefaultCriDockerdVersion, path, kubeConf.Arg.DownloadCommand)
 	calicoctl := files.NewKubeBinary("calicoctl", arch, kubekeyapiv1alpha2.DefaultCalicoVersion, path, kubeConf.Arg.DownloadCommand)
 
 	binaries := []*files.KubeBinary{kubeadm, kubelet, kubectl, helm, kubecni, crictl, etcd}
 
 	if kubeConf.Cluster.Kubernetes.ContainerManager == kubekeyapiv1alpha2.Docker {
 		binaries = append(binaries, docker)
+		if kubeConf.Cluster.Kubernetes.EnableCriDockerd() {
+			binaries = append(binaries, criDockerd)
+		}
 	} else if kubeConf.Cluster.Kubernetes.ContainerManager == kubekeyapiv1alpha2.Containerd {
 		binaries = append(binaries, containerd, runc)
+	} else if kubeConf.Cluster.Kubernetes.ContainerManager == kubekeyapiv1alpha2.Crio {
+		binaries = append(binaries, crio)
 	}
 
 	if kubeConf.Cluster.Network.Plugin == "calico" {
@@ -58,6 +66,7 @@ func K8sFilesDownloadHTTP(kubeConf *common.KubeConf, path, version, arch string,
 	}
 
 	binariesMap := make(map[string]*files.KubeBinary)
+	downloads := make([]*files.KubeBinary, 0, len(binaries))
 	for _, binary := range binaries {
 		if err := binary.CreateBaseDir(); err != nil {
 			return errors.Wrapf(errors.WithStack(err), "create file %s base dir failed", binary.FileName)
@@ -77,19 +86,28 @@ func K8sFilesDownloadHTTP(kubeConf *common.KubeConf, path, version, arch string,
 			}
 		}
 
-		if err := binary.Download(); err != nil {
-			return fmt.Errorf("Failed to download %s binary: %s error: %w ", binary.ID, binary.GetCmd(), err)
-		}
+		downloads = append(downloads, binary)
+	}
+
+	if err := files.DownloadBinaries(downloads, files.DefaultDownloadParallel); err != nil {
+		return err
 	}
 
 	if kubeConf.Cluster.KubeSphere.Version == "v2.1.1" {
 		logger.Log.Infoln(fmt.Sprintf("Downloading %s ...", "helm2"))
 		if util.IsExist(fmt.Sprintf("%s/helm2", helm.BaseDir)) == false {
-			cmd := kubeConf.Arg.DownloadCommand(fmt.Sprintf("%s/helm2", helm.BaseDir),
-				fmt.Sprintf("https://kubernetes-helm.pek3b.qingstor.com/linux-%s/%s/helm", helm.Arch, "v2.16.9"))
-			if output, err := exec.Command("/bin/sh", "-c", cmd).CombinedOutput(); err != nil {
-				fmt.Println(string(output))
-				return errors.Wrap(err, "Failed to download helm2 binary")
+			helm2Path := fmt.Sprintf("%s/helm2", helm.BaseDir)
+			helm2Url := fmt.Sprintf("https://kubernetes-helm.pek3b.qingstor.com/linux-%s/%s/helm", helm.Arch, "v2.16.9")
+			if kubeConf.Arg.DownloadCommand == nil {
+				if err := files.DefaultDownloader.Download(context.Background(), helm2Url, helm2Path, ""); err != nil {
+					return errors.Wrap(err, "Failed to download helm2 binary")
+				}
+			} else {
+				cmd := kubeConf.Arg.DownloadCommand(helm2Path, helm2Url)
+				if output, err := exec.Command("/bin/sh", "-c", cmd).CombinedOutput(); err != nil {
+					fmt.Println(string(output))
+					return errors.Wrap(err, "Failed to download helm2 binary")
+				}
 			}
 		}
 	}
@@ -122,6 +140,10 @@ func KubernetesArtifactBinariesDownload(manifest *common.ArtifactManifest, path,
 				runc := files.NewKubeBinary("runc", arch, kubekeyapiv1alpha2.DefaultRuncVersion, path, manifest.Arg.DownloadCommand)
 				containerManagerArr = append(containerManagerArr, runc)
 			}
+			if c.Type == "docker" && (&kubekeyapiv1alpha2.Kubernetes{Version: k8sVersion}).DockershimRemoved() {
+				criDockerd := files.NewKubeBinary("cri-dockerd", arch, kubekeyapiv1alpha2.DefaultCriDockerdVersion, path, manifest.Arg.DownloadCommand)
+				containerManagerArr = append(containerManagerArr, criDockerd)
+			}
 		}
 	}
 
@@ -130,6 +152,7 @@ func KubernetesArtifactBinariesDownload(manifest *common.ArtifactManifest, path,
 		binaries = append(binaries, crictl)
 	}
 
+	downloads := make([]*files.KubeBinary, 0, len(binaries))
 	for _, binary := range binaries {
 		if err := binary.CreateBaseDir(); err != nil {
 			return errors.Wrapf(errors.WithStack(err), "create file %s base dir failed", binary.FileName)
@@ -146,9 +169,11 @@ func KubernetesArtifactBinariesDownload(manifest *common.ArtifactManifest, path,
 			}
 		}
 
-		if err := binary.Download(); err != nil {
-			return fmt.Errorf("Failed to download %s binary: %s error: %w ", binary.ID, binary.GetCmd(), err)
-		}
+		downloads = append(downloads, binary)
+	}
+
+	if err := files.DownloadBinaries(downloads, files.DefaultDownloadParallel); err != nil {
+		return err
 	}
 
 	return nil
@@ -162,14 +187,24 @@ func CriDownloadHTTP(kubeConf *common.KubeConf, path, arch string, pipelineCache
 	case common.Docker:
 		docker := files.NewKubeBinary("docker", arch, kubekeyapiv1alpha2.DefaultDockerVersion, path, kubeConf.Arg.DownloadCommand)
 		binaries = append(binaries, docker)
+		if kubeConf.Cluster.Kubernetes.DockershimRemoved() {
+			criDockerd := files.NewKubeBinary("cri-dockerd", arch, kubekeyapiv1alpha2.DefaultCriDockerdVersion, path, kubeConf.Arg.DownloadCommand)
+			crictl := files.NewKubeBinary("crictl", arch, kubekeyapiv1alpha2.DefaultCrictlVersion, path, kubeConf.Arg.DownloadCommand)
+			binaries = append(binaries, criDockerd, crictl)
+		}
 	case common.Containerd:
 		containerd := files.NewKubeBinary("containerd", arch, kubekeyapiv1alpha2.DefaultContainerdVersion, path, kubeConf.Arg.DownloadCommand)
 		runc := files.NewKubeBinary("runc", arch, kubekeyapiv1alpha2.DefaultRuncVersion, path, kubeConf.Arg.DownloadCommand)
 		crictl := files.NewKubeBinary("crictl", arch, kubekeyapiv1alpha2.DefaultCrictlVersion, path, kubeConf.Arg.DownloadCommand)
 		binaries = append(binaries, containerd, runc, crictl)
+	case common.Crio:
+		crio := files.NewKubeBinary("crio", arch, kubekeyapiv1alpha2.DefaultCrioVersion, path, kubeConf.Arg.DownloadCommand)
+		crictl := files.NewKubeBinary("crictl", arch, kubekeyapiv1alpha2.DefaultCrictlVersion, path, kubeConf.Arg.DownloadCommand)
+		binaries = append(binaries, crio, crictl)
 	default:
 	}
 	binariesMap := make(map[string]*files.KubeBinary)
+	downloads := make([]*files.KubeBinary, 0, len(binaries))
 	for _, binary := range binaries {
 		if err := binary.CreateBaseDir(); err != nil {
 			return errors.Wrapf(errors.WithStack(err), "create file %s base dir failed", binary.FileName)
@@ -189,9 +224,11 @@ func CriDownloadHTTP(kubeConf *common.KubeConf, path, arch string, pipelineCache
 			}
 		}
 
-		if err := binary.Download(); err != nil {
-			return fmt.Errorf("Failed to download %s binary: %s error: %w ", binary.ID, binary.GetCmd(), err)
-		}
+		downloads = append(downloads, binary)
+	}
+
+	if err := files.DownloadBinaries(downloads, files.DefaultDownloadParallel); err != nil {
+		return err
 	}
 
 	pipelineCache.Set(common.KubeBinaries+"-"+arch, binariesMap)
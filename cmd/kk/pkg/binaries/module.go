@@ -28,6 +28,10 @@ type NodeBinariesModule struct {
 	common.KubeModule
 }
 
+func (n *NodeBinariesModule) Repeatable() bool {
+	return true
+}
+
 func (n *NodeBinariesModule) Init() {
 	n.Name = "NodeBinariesModule"
 	n.Desc = "Download installation binaries"
@@ -47,6 +51,10 @@ type K3sNodeBinariesModule struct {
 	common.KubeModule
 }
 
+func (k *K3sNodeBinariesModule) Repeatable() bool {
+	return true
+}
+
 func (k *K3sNodeBinariesModule) Init() {
 	k.Name = "K3sNodeBinariesModule"
 	k.Desc = "Download installation binaries"
@@ -66,6 +74,10 @@ type K8eNodeBinariesModule struct {
 	common.KubeModule
 }
 
+func (k *K8eNodeBinariesModule) Repeatable() bool {
+	return true
+}
+
 func (k *K8eNodeBinariesModule) Init() {
 	k.Name = "K8eNodeBinariesModule"
 	k.Desc = "Download installation binaries"
@@ -142,6 +154,10 @@ type RegistryPackageModule struct {
 	common.KubeModule
 }
 
+func (n *RegistryPackageModule) Repeatable() bool {
+	return true
+}
+
 func (n *RegistryPackageModule) Init() {
 	n.Name = "RegistryPackageModule"
 	n.Desc = "Download registry package"
@@ -165,6 +181,10 @@ type CriBinariesModule struct {
 	common.KubeModule
 }
 
+func (i *CriBinariesModule) Repeatable() bool {
+	return true
+}
+
 func (i *CriBinariesModule) Init() {
 	i.Name = "CriBinariesModule"
 	i.Desc = "Download Cri package"
@@ -173,6 +193,8 @@ func (i *CriBinariesModule) Init() {
 		i.Tasks = CriBinaries(i)
 	case common.Containerd:
 		i.Tasks = CriBinaries(i)
+	case common.Crio:
+		i.Tasks = CriBinaries(i)
 	default:
 	}
 
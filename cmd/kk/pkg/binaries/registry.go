@@ -47,6 +47,7 @@ func RegistryPackageDownloadHTTP(kubeConf *common.KubeConf, path, arch string, p
 	}
 
 	binariesMap := make(map[string]*files.KubeBinary)
+	downloads := make([]*files.KubeBinary, 0, len(binaries))
 	for _, binary := range binaries {
 		if err := binary.CreateBaseDir(); err != nil {
 			return errors.Wrapf(errors.WithStack(err), "create file %s base dir failed", binary.FileName)
@@ -64,9 +65,11 @@ func RegistryPackageDownloadHTTP(kubeConf *common.KubeConf, path, arch string, p
 			}
 		}
 
-		if err := binary.Download(); err != nil {
-			return fmt.Errorf("Failed to download %s binary: %s error: %w ", binary.ID, binary.GetCmd(), err)
-		}
+		downloads = append(downloads, binary)
+	}
+
+	if err := files.DownloadBinaries(downloads, files.DefaultDownloadParallel); err != nil {
+		return err
 	}
 
 	pipelineCache.Set(common.KubeBinaries+"-"+arch, binariesMap)
@@ -101,6 +104,7 @@ func RegistryBinariesDownload(manifest *common.ArtifactManifest, path, arch stri
 		}
 	}
 
+	downloads := make([]*files.KubeBinary, 0, len(binaries))
 	for _, binary := range binaries {
 		if err := binary.CreateBaseDir(); err != nil {
 			return errors.Wrapf(errors.WithStack(err), "create file %s base dir failed", binary.FileName)
@@ -118,9 +122,11 @@ func RegistryBinariesDownload(manifest *common.ArtifactManifest, path, arch stri
 			}
 		}
 
-		if err := binary.Download(); err != nil {
-			return fmt.Errorf("Failed to download %s binary: %s error: %w ", binary.ID, binary.GetCmd(), err)
-		}
+		downloads = append(downloads, binary)
+	}
+
+	if err := files.DownloadBinaries(downloads, files.DefaultDownloadParallel); err != nil {
+		return err
 	}
 	return nil
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/module"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/task"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/plan"
 )
 
 type InstallConfirmModule struct {
@@ -31,6 +32,10 @@ func (i *InstallConfirmModule) IsSkip() bool {
 	return i.Skip
 }
 
+func (i *InstallConfirmModule) Repeatable() bool {
+	return true
+}
+
 func (i *InstallConfirmModule) Init() {
 	i.Name = "ConfirmModule"
 	i.Desc = "Display confirmation form"
@@ -94,6 +99,55 @@ func (d *DeleteNodeConfirmModule) Init() {
 	}
 }
 
+type RestoreETCDConfirmModule struct {
+	common.KubeModule
+	Skip bool
+}
+
+func (r *RestoreETCDConfirmModule) IsSkip() bool {
+	return r.Skip
+}
+
+func (r *RestoreETCDConfirmModule) Init() {
+	r.Name = "RestoreETCDConfirmModule"
+	r.Desc = "Display restore etcd confirmation form"
+
+	display := &task.LocalTask{
+		Name:   "ConfirmForm",
+		Desc:   "Display confirmation form",
+		Action: new(RestoreETCDConfirm),
+	}
+
+	r.Tasks = []task.Interface{
+		display,
+	}
+}
+
+type ApplyConfirmModule struct {
+	common.KubeModule
+	Skip bool
+	Plan *plan.Plan
+}
+
+func (a *ApplyConfirmModule) IsSkip() bool {
+	return a.Skip || !a.Plan.HasChanges()
+}
+
+func (a *ApplyConfirmModule) Init() {
+	a.Name = "ApplyConfirmModule"
+	a.Desc = "Display apply confirmation form"
+
+	display := &task.LocalTask{
+		Name:   "ConfirmForm",
+		Desc:   "Display confirmation form",
+		Action: new(ApplyConfirm),
+	}
+
+	a.Tasks = []task.Interface{
+		display,
+	}
+}
+
 type UpgradeConfirmModule struct {
 	common.KubeModule
 	Skip bool
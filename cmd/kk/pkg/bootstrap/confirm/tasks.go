@@ -107,18 +107,12 @@ func (i *InstallationConfirm) Execute(runtime connector.Runtime) error {
 	fmt.Println("https://github.com/kubesphere/kubekey#requirements-and-recommendations")
 	fmt.Println("")
 
-	if k8sVersion, err := versionutil.ParseGeneric(i.KubeConf.Cluster.Kubernetes.Version); err == nil {
-		if k8sVersion.AtLeast(versionutil.MustParseSemantic("v1.24.0")) && i.KubeConf.Cluster.Kubernetes.ContainerManager == common.Docker {
-			fmt.Println("[Notice]")
-			fmt.Println("Incorrect runtime. Please specify a container runtime other than Docker to install Kubernetes v1.24 or later.")
-			fmt.Println("You can set \"spec.kubernetes.containerManager\" in the configuration file to \"containerd\" or add \"--container-manager containerd\" to the \"./kk create cluster\" command.")
-			fmt.Println("For more information, see:")
-			fmt.Println("https://github.com/kubesphere/kubekey/blob/master/docs/commands/kk-create-cluster.md")
-			fmt.Println("https://kubernetes.io/docs/setup/production-environment/container-runtimes/#container-runtimes")
-			fmt.Println("https://kubernetes.io/blog/2022/02/17/dockershim-faq/")
-			fmt.Println("")
-			stopFlag = true
-		}
+	if i.KubeConf.Cluster.Kubernetes.EnableCriDockerd() {
+		fmt.Println("[Notice]")
+		fmt.Println("Kubernetes v1.24 and later no longer support dockershim, cri-dockerd will be installed to connect kubelet to Docker.")
+		fmt.Println("For more information, see:")
+		fmt.Println("https://github.com/Mirantis/cri-dockerd")
+		fmt.Println("")
 	}
 
 	if stopFlag {
@@ -180,6 +174,67 @@ func (d *DeleteConfirm) Execute(runtime connector.Runtime) error {
 	return nil
 }
 
+type RestoreETCDConfirm struct {
+	common.KubeAction
+}
+
+func (r *RestoreETCDConfirm) Execute(runtime connector.Runtime) error {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Printf("The etcd data of the cluster will be replaced by the snapshot %s, "+
+		"and kube-apiserver will be stopped during the restore.\n", r.KubeConf.Arg.EtcdSnapshot)
+
+	confirmOK := false
+	for !confirmOK {
+		fmt.Printf("Are you sure to restore etcd? [yes/no]: ")
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		input = strings.ToLower(strings.TrimSpace(input))
+
+		switch input {
+		case "yes", "y":
+			confirmOK = true
+		case "no", "n":
+			os.Exit(0)
+		default:
+			continue
+		}
+	}
+
+	return nil
+}
+
+type ApplyConfirm struct {
+	common.KubeAction
+}
+
+func (a *ApplyConfirm) Execute(runtime connector.Runtime) error {
+	reader := bufio.NewReader(os.Stdin)
+
+	confirmOK := false
+	for !confirmOK {
+		fmt.Printf("Do you want to perform these actions? [yes/no]: ")
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		input = strings.ToLower(strings.TrimSpace(input))
+
+		switch input {
+		case "yes", "y":
+			confirmOK = true
+		case "no", "n":
+			os.Exit(0)
+		default:
+			continue
+		}
+	}
+
+	return nil
+}
+
 type UpgradeConfirm struct {
 	common.KubeAction
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/bootstrap/os/templates"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/action"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/prepare"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/task"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
@@ -98,15 +99,27 @@ func (c *ConfigureOSModule) Init() {
 
 type ClearNodeOSModule struct {
 	common.KubeModule
+	Skip bool
+	// Hosts are the nodes to clear, the workers by default.
+	Hosts []connector.Host
+}
+
+func (c *ClearNodeOSModule) IsSkip() bool {
+	return c.Skip
 }
 
 func (c *ClearNodeOSModule) Init() {
 	c.Name = "ClearNodeOSModule"
 
+	hosts := c.Hosts
+	if len(hosts) == 0 {
+		hosts = c.Runtime.GetHostsByRole(common.Worker)
+	}
+
 	stopKubelet := &task.RemoteTask{
 		Name:     "StopKubelet",
 		Desc:     "Stop Kubelet",
-		Hosts:    c.Runtime.GetHostsByRole(common.Worker),
+		Hosts:    hosts,
 		Prepare:  new(DeleteNode),
 		Action:   new(StopKubelet),
 		Parallel: true,
@@ -115,7 +128,7 @@ func (c *ClearNodeOSModule) Init() {
 	resetNetworkConfig := &task.RemoteTask{
 		Name:     "ResetNetworkConfig",
 		Desc:     "Reset os network config",
-		Hosts:    c.Runtime.GetHostsByRole(common.Worker),
+		Hosts:    hosts,
 		Prepare:  new(DeleteNode),
 		Action:   new(ResetNetworkConfig),
 		Parallel: true,
@@ -124,7 +137,7 @@ func (c *ClearNodeOSModule) Init() {
 	removeFiles := &task.RemoteTask{
 		Name:     "RemoveFiles",
 		Desc:     "Remove node files",
-		Hosts:    c.Runtime.GetHostsByRole(common.Worker),
+		Hosts:    hosts,
 		Prepare:  new(DeleteNode),
 		Action:   new(RemoveNodeFiles),
 		Parallel: true,
@@ -133,7 +146,7 @@ func (c *ClearNodeOSModule) Init() {
 	daemonReload := &task.RemoteTask{
 		Name:     "DaemonReload",
 		Desc:     "Systemd daemon reload",
-		Hosts:    c.Runtime.GetHostsByRole(common.Worker),
+		Hosts:    hosts,
 		Prepare:  new(DeleteNode),
 		Action:   new(DaemonReload),
 		Parallel: true,
@@ -25,9 +25,10 @@ import (
 
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var InitOsScriptTmpl = template.Must(template.New("initOS.sh").Parse(
+var InitOsScriptTmpl = template.Must(util.ParseTemplate(template.New("initOS.sh"),
 	dedent.Dedent(`#!/usr/bin/env bash
 
 # Copyright 2020 The KubeSphere Authors.
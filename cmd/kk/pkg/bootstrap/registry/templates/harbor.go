@@ -15,6 +15,7 @@ package templates
 
 import (
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/registry"
 	"strings"
 	"text/template"
@@ -24,7 +25,7 @@ import (
 
 var (
 	// HarborServiceTempl defines the template of registry's configuration file.
-	HarborServiceTempl = template.Must(template.New("harborSerivce").Parse(
+	HarborServiceTempl = template.Must(util.ParseTemplate(template.New("harborSerivce"),
 		dedent.Dedent(`[Unit]
 Description=Harbor
 After=docker.service systemd-networkd.service systemd-resolved.service
@@ -39,7 +40,7 @@ Restart=on-failure
 WantedBy=multi-user.target
     `)))
 	// HarborConfigTempl defines the template of registry's configuration file.
-	HarborConfigTempl = template.Must(template.New("harborConfig").Parse(
+	HarborConfigTempl = template.Must(util.ParseTemplate(template.New("harborConfig"),
 		dedent.Dedent(`# Configuration file of Harbor
 
 # The IP address or hostname to access admin UI and registry service.
@@ -146,4 +147,4 @@ func Password(kubeConf *common.KubeConf, domain string) string {
 	}
 
 	return "Harbor12345"
-}
\ No newline at end of file
+}
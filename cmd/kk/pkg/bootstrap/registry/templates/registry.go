@@ -16,11 +16,13 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 var (
 	// RegistryServiceTempl defines the template of registry service for systemd.
-	RegistryServiceTempl = template.Must(template.New("registryService").Parse(
+	RegistryServiceTempl = template.Must(util.ParseTemplate(template.New("registryService"),
 		dedent.Dedent(`[Unit]
 Description=v2 Registry server for Container
 After=network.target
@@ -33,7 +35,7 @@ WantedBy=multi-user.target
     `)))
 
 	// RegistryConfigTempl defines the template of registry's configuration file.
-	RegistryConfigTempl = template.Must(template.New("registryConfig").Parse(
+	RegistryConfigTempl = template.Must(util.ParseTemplate(template.New("registryConfig"),
 		dedent.Dedent(`version: 0.1
 log:
   fields:
@@ -19,10 +19,12 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 // K8sCertsRenewScript defines the template of k8s-certs-renew timer for systemd.
-var K8sCertsRenewScript = template.Must(template.New("k8s-certs-renew.sh").Parse(
+var K8sCertsRenewScript = template.Must(util.ParseTemplate(template.New("k8s-certs-renew.sh"),
 	dedent.Dedent(`#!/bin/bash
 {{- if .IsKubeadmAlphaCerts }}
 kubeadmCerts='/usr/local/bin/kubeadm alpha certs'
@@ -19,11 +19,13 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 var (
 	// K8sCertsRenewService defines the template of k8s-certs-renew service for systemd.
-	K8sCertsRenewService = template.Must(template.New("k8s-certs-renew.service").Parse(
+	K8sCertsRenewService = template.Must(util.ParseTemplate(template.New("k8s-certs-renew.service"),
 		dedent.Dedent(`[Unit]
 Description=Renew K8S control plane certificates
 [Service]
@@ -32,7 +34,7 @@ ExecStart=/usr/local/bin/kube-scripts/k8s-certs-renew.sh
     `)))
 
 	// K8sCertsRenewTimer defines the template of k8s-certs-renew timer for systemd.
-	K8sCertsRenewTimer = template.Must(template.New("k8s-certs-renew.timer").Parse(
+	K8sCertsRenewTimer = template.Must(util.ParseTemplate(template.New("k8s-certs-renew.timer"),
 		dedent.Dedent(`[Unit]
 Description=Timer to renew K8S control plane certificates
 [Timer]
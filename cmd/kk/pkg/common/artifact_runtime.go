@@ -26,6 +26,7 @@ import (
 
 	kubekeyv1alpha2 "github.com/kubesphere/kubekey/v3/cmd/kk/apis/kubekey/v1alpha2"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/event"
 )
 
 type ArtifactArgument struct {
@@ -35,6 +36,9 @@ type ArtifactArgument struct {
 	Debug           bool
 	IgnoreErr       bool
 	DownloadCommand func(path, url string) string
+	Events          string
+	SignKey         string
+	Base            string
 }
 
 type ArtifactRuntime struct {
@@ -48,6 +52,13 @@ func NewArtifactRuntime(arg ArtifactArgument) (*ArtifactRuntime, error) {
 	if err != nil {
 		return nil, err
 	}
+	if arg.Events != "" {
+		sink, err := event.NewSink(arg.Events)
+		if err != nil {
+			return nil, err
+		}
+		localRuntime.SetEventSink(sink)
+	}
 
 	fp, err := filepath.Abs(arg.ManifestFile)
 	if err != nil {
@@ -27,6 +27,13 @@ const (
 	File     = "file"
 	Operator = "operator"
 
+	DryRunScript = "script"
+	DryRunJSON   = "json"
+
+	DefaultClusterConfigName      = "kubekey-cluster-config"
+	DefaultClusterConfigNamespace = "kubekey-system"
+	ClusterConfigKey              = "config.yaml"
+
 	Master        = "master"
 	Worker        = "worker"
 	ETCD          = "etcd"
@@ -46,6 +53,7 @@ const (
 	KubeManifestDir              = "/etc/kubernetes/manifests"
 	KubeScriptDir                = "/usr/local/bin/kube-scripts"
 	KubeletFlexvolumesPluginsDir = "/usr/libexec/kubernetes/kubelet-plugins/volume/exec"
+	KubeUpgradeBackupDir         = "/var/backups/kube_upgrade"
 
 	ETCDCertDir     = "/etc/ssl/etcd/ssl"
 	RegistryCertDir = "/etc/ssl/registry/ssl"
@@ -67,6 +75,7 @@ const (
 	Crio       = "crio"
 	Isula      = "isula"
 	Runc       = "runc"
+	CriDockerd = "cri-dockerd"
 
 	// global cache key
 	// PreCheckModule
@@ -80,6 +89,10 @@ const (
 	PlanK8sVersion         = "planK8sVersion"
 	NodeK8sVersion         = "NodeK8sVersion"
 
+	// ArtifactDeltaModule
+	ArtifactBaseSpec    = "artifactBaseSpec"
+	ArtifactBaseDigests = "artifactBaseDigests"
+
 	// ETCDModule
 	ETCDCluster = "etcdCluster"
 	ETCDName    = "etcdName"
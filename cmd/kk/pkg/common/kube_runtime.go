@@ -17,8 +17,19 @@
 package common
 
 import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
 	kubekeyapiv1alpha2 "github.com/kubesphere/kubekey/v3/cmd/kk/apis/kubekey/v1alpha2"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/event"
+	"github.com/kubesphere/kubekey/v3/util/hostkey"
 )
 
 type KubeRuntime struct {
@@ -26,6 +37,7 @@ type KubeRuntime struct {
 	ClusterName string
 	Cluster     *kubekeyapiv1alpha2.ClusterSpec
 	Kubeconfig  string
+	ConfigHash  string
 	Arg         Argument
 }
 
@@ -46,6 +58,7 @@ type Argument struct {
 	ContainerManager    string
 	FromCluster         bool
 	KubeConfig          string
+	ClusterConfigName   string
 	Artifact            string
 	InstallPackages     bool
 	ImagesDir           string
@@ -53,6 +66,18 @@ type Argument struct {
 	DeleteCRI           bool
 	Role                string
 	Type                string
+	Resume              bool
+	HostKeyChecking     string
+	KnownHostsFile      string
+	EtcdSnapshot        string
+	DryRun              bool
+	DryRunFormat        string
+	Events              string
+	Concurrency         int
+	BatchSize           string
+	MaxFailures         string
+	VerifyKey           string
+	TemplateDir         string
 }
 
 func NewKubeRuntime(flag string, arg Argument) (*KubeRuntime, error) {
@@ -62,7 +87,43 @@ func NewKubeRuntime(flag string, arg Argument) (*KubeRuntime, error) {
 		return nil, err
 	}
 
-	base := connector.NewBaseRuntime(cluster.Name, connector.NewDialer(), arg.Debug, arg.IgnoreErr)
+	configHash, err := clusterConfigHash(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var conn connector.Connector = connector.NewDialer()
+	if arg.DryRun {
+		// nothing is changed in dry-run mode, so there is nothing to confirm or resume.
+		conn = connector.NewRecorder()
+		arg.SkipConfirmCheck = true
+		arg.Resume = false
+	}
+	base := connector.NewBaseRuntime(cluster.Name, conn, arg.Debug, arg.IgnoreErr)
+	if arg.Events != "" {
+		sink, err := event.NewSink(arg.Events)
+		if err != nil {
+			return nil, err
+		}
+		base.SetEventSink(sink)
+	}
+	base.SetRollout(connector.Rollout{
+		Concurrency: arg.Concurrency,
+		BatchSize:   parseIntOrPercent(arg.BatchSize),
+		MaxFailures: parseIntOrPercent(arg.MaxFailures),
+		// a cluster cannot go on without any of its control plane or etcd hosts.
+		CriticalRoles: []string{Master, ETCD},
+	})
+	if arg.TemplateDir != "" {
+		dir, err := filepath.Abs(arg.TemplateDir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get the absolute path of %s failed", arg.TemplateDir)
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return nil, errors.Errorf("the template dir %s is not a directory", arg.TemplateDir)
+		}
+		base.SetTemplateDir(dir)
+	}
 
 	clusterSpec := &cluster.Spec
 	defaultCluster, roleGroups := clusterSpec.SetDefaultClusterSpec()
@@ -73,6 +134,9 @@ func NewKubeRuntime(flag string, arg Argument) (*KubeRuntime, error) {
 			if host.IsRole(Master) || host.IsRole(Worker) {
 				host.SetRole(K8s)
 			}
+			if err := setHostKeyChecking(host, arg); err != nil {
+				return nil, err
+			}
 			if _, ok := hostSet[host.GetName()]; !ok {
 				hostSet[host.GetName()] = struct{}{}
 				base.AppendHost(host)
@@ -86,6 +150,7 @@ func NewKubeRuntime(flag string, arg Argument) (*KubeRuntime, error) {
 	r := &KubeRuntime{
 		Cluster:     defaultCluster,
 		ClusterName: cluster.Name,
+		ConfigHash:  configHash,
 		Arg:         arg,
 	}
 	r.BaseRuntime = base
@@ -93,8 +158,57 @@ func NewKubeRuntime(flag string, arg Argument) (*KubeRuntime, error) {
 	return r, nil
 }
 
+// Host returns the host of the given name, including the hosts of the configuration which are not in any role group,
+// or nil if there is no such host.
+func (k *KubeRuntime) Host(name string) (connector.Host, error) {
+	for _, host := range k.GetAllHosts() {
+		if host.GetName() == name {
+			return host, nil
+		}
+	}
+	host, ok := k.Cluster.Host(name)
+	if !ok {
+		return nil, nil
+	}
+	if err := setHostKeyChecking(host, k.Arg); err != nil {
+		return nil, err
+	}
+	return host, nil
+}
+
+func setHostKeyChecking(host *kubekeyapiv1alpha2.KubeHost, arg Argument) error {
+	if host.GetHostKeyChecking() == "" {
+		host.SetHostKeyChecking(arg.HostKeyChecking)
+	}
+	if host.GetKnownHostsFile() == "" {
+		host.SetKnownHostsFile(arg.KnownHostsFile)
+	}
+	if err := hostkey.Validate(host.GetHostKeyChecking()); err != nil {
+		return errors.Wrapf(err, "invalid host %s", host.GetName())
+	}
+	return nil
+}
+
 // Copy is used to create a copy for Runtime.
 func (k *KubeRuntime) Copy() connector.Runtime {
 	runtime := *k
 	return &runtime
 }
+
+// clusterConfigHash returns the sha256 of the loaded cluster config, it is used to check whether a pipeline can be resumed.
+func clusterConfigHash(cluster *kubekeyapiv1alpha2.Cluster) (string, error) {
+	data, err := json.Marshal(cluster)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// parseIntOrPercent parses a number or a percentage such as "10%", an empty string means it is not set.
+func parseIntOrPercent(s string) *intstr.IntOrString {
+	if s == "" {
+		return nil
+	}
+	v := intstr.Parse(s)
+	return &v
+}
@@ -18,8 +18,11 @@ package common
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"os/user"
@@ -31,9 +34,14 @@ import (
 
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/homedir"
 
 	kubekeyapiv1alpha2 "github.com/kubesphere/kubekey/v3/cmd/kk/apis/kubekey/v1alpha2"
+	kubekeyclient "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/client/kubernetes"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/version/kubesphere"
 )
@@ -53,7 +61,7 @@ func NewLoader(flag string, arg Argument) Loader {
 	case File:
 		return NewFileLoader(arg)
 	case Operator:
-		return &ConfigMapLoader{}
+		return NewConfigMapLoader(arg)
 	case AllInOne:
 		return NewDefaultLoader(arg)
 	default:
@@ -173,9 +181,6 @@ func NewFileLoader(arg Argument) *FileLoader {
 }
 
 func (f FileLoader) Load() (*kubekeyapiv1alpha2.Cluster, error) {
-	var objName string
-
-	clusterCfg := kubekeyapiv1alpha2.Cluster{}
 	fp, err := filepath.Abs(f.FilePath)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to look up current directory")
@@ -189,7 +194,15 @@ func (f FileLoader) Load() (*kubekeyapiv1alpha2.Cluster, error) {
 		return nil, errors.Wrap(err, "Unable to open the given cluster configuration file")
 	}
 	defer file.Close()
-	b1 := bufio.NewReader(file)
+	return f.load(file)
+}
+
+// load parses the cluster configuration from the reader, which has the same content as the configuration file.
+func (f FileLoader) load(r io.Reader) (*kubekeyapiv1alpha2.Cluster, error) {
+	var objName string
+
+	clusterCfg := kubekeyapiv1alpha2.Cluster{}
+	b1 := bufio.NewReader(r)
 	for {
 		result := make(map[string]interface{})
 		content, err := k8syaml.NewYAMLReader(b1).Read()
@@ -269,11 +282,87 @@ func (f FileLoader) Load() (*kubekeyapiv1alpha2.Cluster, error) {
 	return &clusterCfg, nil
 }
 
+// ConfigMapLoader loads the cluster configuration from a ConfigMap or a Secret in the target cluster.
+// The object stores the same content as the configuration file under the ClusterConfigKey.
 type ConfigMapLoader struct {
+	arg        Argument
+	KubeConfig string
+	Namespace  string
+	Name       string
+	Client     kubernetes.Interface
+}
+
+func NewConfigMapLoader(arg Argument) *ConfigMapLoader {
+	name := arg.ClusterConfigName
+	if name == "" {
+		name = DefaultClusterConfigName
+	}
+	namespace := arg.Namespace
+	if namespace == "" {
+		namespace = DefaultClusterConfigNamespace
+	}
+	return &ConfigMapLoader{
+		arg:        arg,
+		KubeConfig: arg.KubeConfig,
+		Namespace:  namespace,
+		Name:       name,
+	}
 }
 
 func (c ConfigMapLoader) Load() (*kubekeyapiv1alpha2.Cluster, error) {
-	return nil, nil
+	if c.Client == nil {
+		kubeConfig := c.KubeConfig
+		if kubeConfig == "" {
+			kubeConfig = filepath.Join(homedir.HomeDir(), ".kube", "config")
+		}
+		client, err := kubekeyclient.NewClient(kubeConfig)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to create the kubernetes client by the kubeconfig %s", kubeConfig)
+		}
+		c.Client = client
+	}
+
+	content, err := c.fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	clusterCfg, err := NewFileLoader(c.arg).load(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	if clusterCfg.Name == "" {
+		return nil, errors.Errorf("No Cluster object found in %s/%s", c.Namespace, c.Name)
+	}
+	return clusterCfg, nil
+}
+
+// fetch returns the cluster configuration stored in the ConfigMap, or in the Secret if the ConfigMap does not exist.
+func (c ConfigMapLoader) fetch() ([]byte, error) {
+	cm, err := c.Client.CoreV1().ConfigMaps(c.Namespace).Get(context.TODO(), c.Name, metav1.GetOptions{})
+	if err == nil {
+		content, ok := cm.Data[ClusterConfigKey]
+		if !ok {
+			return nil, errors.Errorf("ConfigMap %s/%s has no key %s", c.Namespace, c.Name, ClusterConfigKey)
+		}
+		return []byte(content), nil
+	}
+	if !kubeerrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "Failed to get ConfigMap %s/%s", c.Namespace, c.Name)
+	}
+
+	secret, err := c.Client.CoreV1().Secrets(c.Namespace).Get(context.TODO(), c.Name, metav1.GetOptions{})
+	if err != nil {
+		if kubeerrors.IsNotFound(err) {
+			return nil, errors.Errorf("Neither ConfigMap nor Secret %s/%s is found", c.Namespace, c.Name)
+		}
+		return nil, errors.Wrapf(err, "Failed to get Secret %s/%s", c.Namespace, c.Name)
+	}
+	content, ok := secret.Data[ClusterConfigKey]
+	if !ok {
+		return nil, errors.Errorf("Secret %s/%s has no key %s", c.Namespace, c.Name, ClusterConfigKey)
+	}
+	return content, nil
 }
 
 func defaultKSConfig(ks *kubekeyapiv1alpha2.KubeSphere, version string) error {
@@ -0,0 +1,109 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+
+	kubekeyapiv1alpha2 "github.com/kubesphere/kubekey/v3/cmd/kk/apis/kubekey/v1alpha2"
+)
+
+func testCluster() *kubekeyapiv1alpha2.Cluster {
+	return &kubekeyapiv1alpha2.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "kubekey.kubesphere.io/v1alpha2",
+			Kind:       "Cluster",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: "sample"},
+		Spec: kubekeyapiv1alpha2.ClusterSpec{
+			Hosts: []kubekeyapiv1alpha2.HostCfg{
+				{Name: "node1", Address: "172.16.0.2", InternalAddress: "172.16.0.2", User: "ubuntu", Password: "Qcloud@123"},
+				{Name: "node2", Address: "172.16.0.3", InternalAddress: "172.16.0.3", User: "ubuntu", Password: "Qcloud@123"},
+			},
+			RoleGroups: map[string][]string{
+				ETCD:   {"node1"},
+				Master: {"node1"},
+				Worker: {"node1", "node2"},
+			},
+			Kubernetes: kubekeyapiv1alpha2.Kubernetes{
+				Version:          "v1.23.10",
+				ClusterName:      "cluster.local",
+				ContainerManager: Containerd,
+			},
+		},
+	}
+}
+
+func TestConfigMapLoader_Load(t *testing.T) {
+	want := testCluster()
+	content, err := yaml.Marshal(want)
+	if err != nil {
+		t.Fatalf("marshal cluster failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		object runtime.Object
+	}{
+		{
+			name: "configmap",
+			object: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: DefaultClusterConfigName, Namespace: "kubekey-test"},
+				Data:       map[string]string{ClusterConfigKey: string(content)},
+			},
+		},
+		{
+			name: "secret",
+			object: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: DefaultClusterConfigName, Namespace: "kubekey-test"},
+				Data:       map[string][]byte{ClusterConfigKey: content},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := NewConfigMapLoader(Argument{Namespace: "kubekey-test"})
+			loader.Client = fake.NewSimpleClientset(tt.object)
+
+			got, err := loader.Load()
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got.Name != want.Name {
+				t.Errorf("Load() got name = %s, want %s", got.Name, want.Name)
+			}
+			if !reflect.DeepEqual(got.Spec, want.Spec) {
+				t.Errorf("Load() got spec = %+v, want %+v", got.Spec, want.Spec)
+			}
+		})
+	}
+}
+
+func TestConfigMapLoader_LoadNotFound(t *testing.T) {
+	loader := NewConfigMapLoader(Argument{})
+	loader.Client = fake.NewSimpleClientset()
+	if _, err := loader.Load(); err == nil {
+		t.Errorf("Load() error = nil, want not found error")
+	}
+}
@@ -226,10 +226,19 @@ func (i *RestartCri) Execute(runtime connector.Runtime) error {
 		if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf("systemctl daemon-reload && systemctl restart docker "), true); err != nil {
 			return errors.Wrap(err, "restart docker")
 		}
+		if i.KubeConf.Cluster.Kubernetes.DockershimRemoved() {
+			if _, err := runtime.GetRunner().SudoCmd("systemctl restart cri-docker", true); err != nil {
+				return errors.Wrap(err, "restart cri-dockerd")
+			}
+		}
 	case common.Containerd:
 		if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf("systemctl daemon-reload && systemctl restart containerd"), true); err != nil {
 			return errors.Wrap(err, "restart containerd")
 		}
+	case common.Crio:
+		if _, err := runtime.GetRunner().SudoCmd("systemctl daemon-reload && systemctl restart crio", true); err != nil {
+			return errors.Wrap(err, "restart crio")
+		}
 
 	default:
 		logger.Log.Fatalf("Unsupported container runtime: %s", strings.TrimSpace(i.KubeConf.Arg.Type))
@@ -244,17 +253,33 @@ type EditKubeletCri struct {
 func (i *EditKubeletCri) Execute(runtime connector.Runtime) error {
 	switch i.KubeConf.Arg.Type {
 	case common.Docker:
+		if i.KubeConf.Cluster.Kubernetes.DockershimRemoved() {
+			if _, err := runtime.GetRunner().SudoCmd(
+				"sed -i 's#--container-runtime-endpoint=[^ ]*#--container-runtime-endpoint=unix:///var/run/cri-dockerd.sock#' /var/lib/kubelet/kubeadm-flags.env",
+				true); err != nil {
+				return errors.Wrap(err, "Change KubeletTo CriDockerd failed")
+			}
+			break
+		}
 		if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf(
-			"sed -i 's#--container-runtime=remote --container-runtime-endpoint=unix:///run/containerd/containerd.sock --pod#--pod#' /var/lib/kubelet/kubeadm-flags.env"),
+			"sed -i 's#--container-runtime=remote --container-runtime-endpoint=[^ ]* --pod#--pod#' /var/lib/kubelet/kubeadm-flags.env"),
 			true); err != nil {
 			return errors.Wrap(err, "Change KubeletTo Containerd failed")
 		}
 	case common.Containerd:
 		if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf(
-			"sed -i 's#--network-plugin=cni --pod#--network-plugin=cni --container-runtime=remote --container-runtime-endpoint=unix:///run/containerd/containerd.sock --pod#' /var/lib/kubelet/kubeadm-flags.env"),
+			"sed -i -e 's#--container-runtime-endpoint=unix:///var/run/\\(crio/crio\\|cri-dockerd\\).sock#--container-runtime-endpoint=unix:///run/containerd/containerd.sock#' "+
+				"-e 's#--network-plugin=cni --pod#--network-plugin=cni --container-runtime=remote --container-runtime-endpoint=unix:///run/containerd/containerd.sock --pod#' /var/lib/kubelet/kubeadm-flags.env"),
 			true); err != nil {
 			return errors.Wrap(err, "Change KubeletTo Containerd failed")
 		}
+	case common.Crio:
+		if _, err := runtime.GetRunner().SudoCmd(
+			"sed -i -e 's#--container-runtime-endpoint=unix:///\\(run/containerd/containerd\\|var/run/cri-dockerd\\).sock#--container-runtime-endpoint=unix:///var/run/crio/crio.sock#' "+
+				"-e 's#--network-plugin=cni --pod#--network-plugin=cni --container-runtime=remote --container-runtime-endpoint=unix:///var/run/crio/crio.sock --pod#' /var/lib/kubelet/kubeadm-flags.env",
+			true); err != nil {
+			return errors.Wrap(err, "Change KubeletTo Crio failed")
+		}
 
 	default:
 		logger.Log.Fatalf("Unsupported container runtime: %s", strings.TrimSpace(i.KubeConf.Arg.Type))
@@ -345,6 +370,18 @@ func MigrateSelfNodeCriTasks(runtime connector.Runtime, kubeAction common.KubeAc
 			Parallel: false,
 		}
 		tasks = append(tasks, CordonNode, DrainNode, Uninstall)
+	case common.Crio:
+		Uninstall := &task.RemoteTask{
+			Name:  "UninstallCrio",
+			Desc:  "Uninstall crio",
+			Hosts: []connector.Host{host},
+			Prepare: &prepare.PrepareCollection{
+				&CrioExist{Not: false},
+			},
+			Action:   new(DisableCrio),
+			Parallel: false,
+		}
+		tasks = append(tasks, CordonNode, DrainNode, Uninstall)
 	}
 	if kubeAction.KubeConf.Arg.Type == common.Docker {
 		syncBinaries := &task.RemoteTask{
@@ -415,8 +452,11 @@ func MigrateSelfNodeCriTasks(runtime connector.Runtime, kubeAction common.KubeAc
 			Parallel: false,
 		}
 
-		tasks = append(tasks, syncBinaries, generateDockerService, generateDockerConfig, enableDocker, dockerLoginRegistry,
-			RestartCri, EditKubeletCri, RestartKubeletNode, UnCordonNode)
+		tasks = append(tasks, syncBinaries, generateDockerService, generateDockerConfig, enableDocker, dockerLoginRegistry)
+		if kubeAction.KubeConf.Cluster.Kubernetes.DockershimRemoved() {
+			tasks = append(tasks, criDockerdInstallTasks(runtime, kubeAction.KubeConf, []connector.Host{host}, false)...)
+		}
+		tasks = append(tasks, RestartCri, EditKubeletCri, RestartKubeletNode, UnCordonNode)
 	}
 	if kubeAction.KubeConf.Arg.Type == common.Containerd {
 		syncContainerd := &task.RemoteTask{
@@ -506,6 +546,10 @@ func MigrateSelfNodeCriTasks(runtime connector.Runtime, kubeAction common.KubeAc
 		tasks = append(tasks, syncContainerd, syncCrictlBinaries, generateContainerdService, generateContainerdConfig,
 			generateCrictlConfig, enableContainerd, RestartCri, EditKubeletCri, RestartKubeletNode, UnCordonNode)
 	}
+	if kubeAction.KubeConf.Arg.Type == common.Crio {
+		tasks = append(tasks, crioInstallTasks(runtime, kubeAction.KubeConf, []connector.Host{host}, false)...)
+		tasks = append(tasks, RestartCri, EditKubeletCri, RestartKubeletNode, UnCordonNode)
+	}
 
 	for i := range tasks {
 		t := tasks[i]
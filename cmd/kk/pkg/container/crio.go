@@ -0,0 +1,139 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package container
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/container/templates"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/files"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/registry"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/utils"
+)
+
+type SyncCrio struct {
+	common.KubeAction
+}
+
+func (s *SyncCrio) Execute(runtime connector.Runtime) error {
+	if err := utils.ResetTmpDir(runtime); err != nil {
+		return err
+	}
+
+	binariesMapObj, ok := s.PipelineCache.Get(common.KubeBinaries + "-" + runtime.RemoteHost().GetArch())
+	if !ok {
+		return errors.New("get KubeBinary by pipeline cache failed")
+	}
+	binariesMap := binariesMapObj.(map[string]*files.KubeBinary)
+
+	crio, ok := binariesMap[common.Crio]
+	if !ok {
+		return errors.New("get KubeBinary key crio by pipeline cache failed")
+	}
+
+	dst := filepath.Join(common.TmpDir, crio.FileName)
+	if err := runtime.GetRunner().Scp(crio.Path(), dst); err != nil {
+		return errors.Wrap(errors.WithStack(err), "sync crio binaries failed")
+	}
+
+	// the crictl in the bundle is replaced by the one synced by SyncCrictlBinaries.
+	if _, err := runtime.GetRunner().SudoCmd(
+		fmt.Sprintf("cd %s && tar -zxf %s && rm -f cri-o/bin/crictl && mkdir -p /usr/bin && install -m 755 cri-o/bin/* /usr/bin/ && rm -rf cri-o",
+			common.TmpDir, dst),
+		false); err != nil {
+		return errors.Wrap(errors.WithStack(err), "install crio binaries failed")
+	}
+	return nil
+}
+
+type SyncCrioRegistryCerts struct {
+	common.KubeAction
+}
+
+func (s *SyncCrioRegistryCerts) Execute(runtime connector.Runtime) error {
+	auths := registry.DockerRegistryAuthEntries(s.KubeConf.Cluster.Registry.Auths)
+	for repo, entry := range auths {
+		dir := filepath.Join("/etc/containers/certs.d", repo)
+		links := map[string]string{"ca.crt": entry.CAFile, "client.cert": entry.CertFile, "client.key": entry.KeyFile}
+		for name, file := range links {
+			if file == "" {
+				continue
+			}
+			if _, err := runtime.GetRunner().SudoCmd(
+				fmt.Sprintf("mkdir -p %s && ln -sf %s %s", dir, file, filepath.Join(dir, name)), false); err != nil {
+				return errors.Wrapf(errors.WithStack(err), "link the %s of registry %s failed", name, repo)
+			}
+		}
+	}
+	return nil
+}
+
+type EnableCrio struct {
+	common.KubeAction
+}
+
+func (e *EnableCrio) Execute(runtime connector.Runtime) error {
+	if _, err := runtime.GetRunner().SudoCmd(
+		"systemctl daemon-reload && systemctl enable crio && systemctl start crio",
+		false); err != nil {
+		return errors.Wrap(errors.WithStack(err), "enable and start crio failed")
+	}
+	return nil
+}
+
+type DisableCrio struct {
+	common.KubeAction
+}
+
+func (d *DisableCrio) Execute(runtime connector.Runtime) error {
+	if _, err := runtime.GetRunner().SudoCmd(
+		"systemctl disable crio && systemctl stop crio", true); err != nil {
+		return errors.Wrap(errors.WithStack(err), "disable and stop crio failed")
+	}
+
+	// remove crio related files
+	files := []string{
+		"/usr/bin/crio*",
+		"/usr/bin/conmon*",
+		"/usr/bin/pinns",
+		"/usr/bin/runc",
+		"/usr/bin/crun",
+		"/usr/bin/crictl",
+		filepath.Join("/etc/systemd/system", templates.CrioService.Name()),
+		"/etc/crio",
+		filepath.Join("/etc/containers", templates.CrioRegistries.Name()),
+		filepath.Join("/etc/containers", templates.CrioPolicy.Name()),
+		"/etc/containers/certs.d",
+		filepath.Join("/etc", templates.CrictlConfig.Name()),
+		"/var/run/containers/storage",
+	}
+	if d.KubeConf.Cluster.Registry.DataRoot != "" {
+		files = append(files, d.KubeConf.Cluster.Registry.DataRoot)
+	} else {
+		files = append(files, "/var/lib/containers/storage")
+	}
+
+	for _, file := range files {
+		_, _ = runtime.GetRunner().SudoCmd(fmt.Sprintf("rm -rf %s", file), true)
+	}
+	return nil
+}
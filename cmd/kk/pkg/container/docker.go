@@ -77,6 +77,52 @@ func (e *EnableDocker) Execute(runtime connector.Runtime) error {
 	return nil
 }
 
+type SyncCriDockerdBinaries struct {
+	common.KubeAction
+}
+
+func (s *SyncCriDockerdBinaries) Execute(runtime connector.Runtime) error {
+	if err := utils.ResetTmpDir(runtime); err != nil {
+		return err
+	}
+
+	binariesMapObj, ok := s.PipelineCache.Get(common.KubeBinaries + "-" + runtime.RemoteHost().GetArch())
+	if !ok {
+		return errors.New("get KubeBinary by pipeline cache failed")
+	}
+	binariesMap := binariesMapObj.(map[string]*files.KubeBinary)
+
+	criDockerd, ok := binariesMap[common.CriDockerd]
+	if !ok {
+		return errors.New("get KubeBinary key cri-dockerd by pipeline cache failed")
+	}
+
+	dst := filepath.Join(common.TmpDir, criDockerd.FileName)
+	if err := runtime.GetRunner().Scp(criDockerd.Path(), dst); err != nil {
+		return errors.Wrap(errors.WithStack(err), "sync cri-dockerd binaries failed")
+	}
+
+	if _, err := runtime.GetRunner().SudoCmd(
+		fmt.Sprintf("mkdir -p /usr/bin && tar -zxf %s && install -m 755 cri-dockerd/cri-dockerd /usr/bin/cri-dockerd && rm -rf cri-dockerd", dst),
+		false); err != nil {
+		return errors.Wrap(errors.WithStack(err), "install cri-dockerd binaries failed")
+	}
+	return nil
+}
+
+type EnableCriDockerd struct {
+	common.KubeAction
+}
+
+func (e *EnableCriDockerd) Execute(runtime connector.Runtime) error {
+	if _, err := runtime.GetRunner().SudoCmd(
+		"systemctl daemon-reload && systemctl enable cri-docker && systemctl enable --now cri-docker.socket && systemctl start cri-docker",
+		false); err != nil {
+		return errors.Wrap(errors.WithStack(err), "enable and start cri-dockerd failed")
+	}
+	return nil
+}
+
 type DockerLoginRegistry struct {
 	common.KubeAction
 }
@@ -114,6 +160,7 @@ type DisableDocker struct {
 }
 
 func (d *DisableDocker) Execute(runtime connector.Runtime) error {
+	_, _ = runtime.GetRunner().SudoCmd("systemctl disable --now cri-docker.socket cri-docker", true)
 	if _, err := runtime.GetRunner().SudoCmd("systemctl disable docker && systemctl stop docker",
 		false); err != nil {
 		return errors.Wrap(errors.WithStack(err), fmt.Sprintf("disable and stop docker failed"))
@@ -127,6 +174,9 @@ func (d *DisableDocker) Execute(runtime connector.Runtime) error {
 		"/usr/bin/containerd*",
 		filepath.Join("/etc/systemd/system", templates.DockerService.Name()),
 		filepath.Join("/etc/docker", templates.DockerConfig.Name()),
+		"/usr/bin/cri-dockerd",
+		filepath.Join("/etc/systemd/system", templates.CriDockerdService.Name()),
+		filepath.Join("/etc/systemd/system", templates.CriDockerdSocket.Name()),
 	}
 	if d.KubeConf.Cluster.Registry.DataRoot != "" {
 		files = append(files, d.KubeConf.Cluster.Registry.DataRoot)
@@ -20,9 +20,11 @@ import (
 	"path/filepath"
 	"strings"
 
+	kubekeyapiv1alpha2 "github.com/kubesphere/kubekey/v3/cmd/kk/apis/kubekey/v1alpha2"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/container/templates"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/action"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/prepare"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/task"
@@ -51,7 +53,7 @@ func (i *InstallContainerModule) Init() {
 	case common.Containerd:
 		i.Tasks = InstallContainerd(i)
 	case common.Crio:
-		// TODO: Add the steps of cri-o's installation.
+		i.Tasks = InstallCrio(i)
 	case common.Isula:
 		// TODO: Add the steps of iSula's installation.
 	default:
@@ -134,13 +136,17 @@ func InstallDocker(m *InstallContainerModule) []task.Interface {
 		Parallel: true,
 	}
 
-	return []task.Interface{
+	tasks := []task.Interface{
 		syncBinaries,
 		generateDockerService,
 		generateDockerConfig,
 		enableDocker,
 		dockerLoginRegistry,
 	}
+	if m.KubeConf.Cluster.Kubernetes.EnableCriDockerd() {
+		tasks = append(tasks, criDockerdInstallTasks(m.Runtime, m.KubeConf, m.Runtime.GetHostsByRole(common.K8s), true, &kubernetes.NodeInCluster{Not: true})...)
+	}
+	return tasks
 }
 
 func InstallContainerd(m *InstallContainerModule) []task.Interface {
@@ -247,6 +253,248 @@ func InstallContainerd(m *InstallContainerModule) []task.Interface {
 	}
 }
 
+func InstallCrio(m *InstallContainerModule) []task.Interface {
+	return crioInstallTasks(m.Runtime, m.KubeConf, m.Runtime.GetHostsByRole(common.K8s), true, &kubernetes.NodeInCluster{Not: true})
+}
+
+// crioInstallTasks returns the tasks which install cri-o on the hosts, the prepares are checked before each task.
+func crioInstallTasks(runtime connector.ModuleRuntime, kubeConf *common.KubeConf, hosts []connector.Host, parallel bool, prepares ...prepare.Prepare) []task.Interface {
+	withPrepares := func(p ...prepare.Prepare) *prepare.PrepareCollection {
+		return joinPrepares(prepares, p...)
+	}
+	auths := registry.DockerRegistryAuthEntries(kubeConf.Cluster.Registry.Auths)
+	crioAuths := templates.CrioAuths(auths)
+	authFile := ""
+	if len(crioAuths) > 0 {
+		authFile = templates.CrioAuthFile
+	}
+
+	syncCrio := &task.RemoteTask{
+		Name:     "SyncCrio",
+		Desc:     "Sync crio binaries",
+		Hosts:    hosts,
+		Prepare:  withPrepares(&CrioExist{Not: true}),
+		Action:   new(SyncCrio),
+		Parallel: parallel,
+		Retry:    2,
+	}
+
+	syncCrictlBinaries := &task.RemoteTask{
+		Name:     "SyncCrictlBinaries",
+		Desc:     "Sync crictl binaries",
+		Hosts:    hosts,
+		Prepare:  withPrepares(&CrictlExist{Not: true}),
+		Action:   new(SyncCrictlBinaries),
+		Parallel: parallel,
+		Retry:    2,
+	}
+
+	generateCrioService := &task.RemoteTask{
+		Name:    "GenerateCrioService",
+		Desc:    "Generate crio service",
+		Hosts:   hosts,
+		Prepare: withPrepares(&CrioExist{Not: true}),
+		Action: &action.Template{
+			Template: templates.CrioService,
+			Dst:      filepath.Join("/etc/systemd/system", templates.CrioService.Name()),
+		},
+		Parallel: parallel,
+	}
+
+	generateCrioConfig := &task.RemoteTask{
+		Name:    "GenerateCrioConfig",
+		Desc:    "Generate crio config",
+		Hosts:   hosts,
+		Prepare: withPrepares(&CrioExist{Not: true}),
+		Action: &action.Template{
+			Template: templates.CrioConfig,
+			Dst:      filepath.Join("/etc/crio", templates.CrioConfig.Name()),
+			Data: util.Data{
+				"DataRoot":     templates.DataRoot(kubeConf),
+				"SandBoxImage": images.GetImage(runtime, kubeConf, "pause").ImageName(),
+				"AuthFile":     authFile,
+			},
+		},
+		Parallel: parallel,
+	}
+
+	generateCrioRegistries := &task.RemoteTask{
+		Name:    "GenerateCrioRegistries",
+		Desc:    "Generate crio registries config",
+		Hosts:   hosts,
+		Prepare: withPrepares(&CrioExist{Not: true}),
+		Action: &action.Template{
+			Template: templates.CrioRegistries,
+			Dst:      filepath.Join("/etc/containers", templates.CrioRegistries.Name()),
+			Data: util.Data{
+				"Mirrors":    templates.CrioMirrors(kubeConf),
+				"Registries": templates.CrioInsecureRegistries(kubeConf, auths),
+			},
+		},
+		Parallel: parallel,
+	}
+
+	generateCrioPolicy := &task.RemoteTask{
+		Name:    "GenerateCrioPolicy",
+		Desc:    "Generate crio image signature policy",
+		Hosts:   hosts,
+		Prepare: withPrepares(&CrioExist{Not: true}),
+		Action: &action.Template{
+			Template: templates.CrioPolicy,
+			Dst:      filepath.Join("/etc/containers", templates.CrioPolicy.Name()),
+		},
+		Parallel: parallel,
+	}
+
+	syncCrioRegistryCerts := &task.RemoteTask{
+		Name:     "SyncCrioRegistryCerts",
+		Desc:     "Sync crio registry certs",
+		Hosts:    hosts,
+		Prepare:  withPrepares(&CrioExist{Not: true}, &PrivateRegistryAuth{}),
+		Action:   new(SyncCrioRegistryCerts),
+		Parallel: parallel,
+	}
+
+	generateCrictlConfig := &task.RemoteTask{
+		Name:    "GenerateCrictlConfig",
+		Desc:    "Generate crictl config",
+		Hosts:   hosts,
+		Prepare: withPrepares(&CrioExist{Not: true}),
+		Action: &action.Template{
+			Template: templates.CrictlConfig,
+			Dst:      filepath.Join("/etc/", templates.CrictlConfig.Name()),
+			Data: util.Data{
+				"Endpoint": kubekeyapiv1alpha2.DefaultCrioEndpoint,
+			},
+		},
+		Parallel: parallel,
+	}
+
+	enableCrio := &task.RemoteTask{
+		Name:     "EnableCrio",
+		Desc:     "Enable crio",
+		Hosts:    hosts,
+		Prepare:  withPrepares(&CrioExist{Not: true}),
+		Action:   new(EnableCrio),
+		Parallel: parallel,
+	}
+
+	tasks := []task.Interface{
+		syncCrio,
+		syncCrictlBinaries,
+		generateCrioService,
+		generateCrioConfig,
+		generateCrioRegistries,
+		generateCrioPolicy,
+	}
+	if authFile != "" {
+		generateCrioAuth := &task.RemoteTask{
+			Name:    "GenerateCrioAuth",
+			Desc:    "Generate crio registry auths",
+			Hosts:   hosts,
+			Prepare: withPrepares(&CrioExist{Not: true}),
+			Action: &action.Template{
+				Template: templates.CrioAuth,
+				Dst:      authFile,
+				Data: util.Data{
+					"Auths": crioAuths,
+				},
+			},
+			Parallel: parallel,
+		}
+		tasks = append(tasks, generateCrioAuth)
+	}
+	return append(tasks, syncCrioRegistryCerts, generateCrictlConfig, enableCrio)
+}
+
+// criDockerdInstallTasks returns the tasks which install cri-dockerd on the hosts, the prepares are checked before each task.
+func criDockerdInstallTasks(runtime connector.ModuleRuntime, kubeConf *common.KubeConf, hosts []connector.Host, parallel bool, prepares ...prepare.Prepare) []task.Interface {
+	syncCriDockerd := &task.RemoteTask{
+		Name:     "SyncCriDockerdBinaries",
+		Desc:     "Sync cri-dockerd binaries",
+		Hosts:    hosts,
+		Prepare:  joinPrepares(prepares, &CriDockerdExist{Not: true}),
+		Action:   new(SyncCriDockerdBinaries),
+		Parallel: parallel,
+		Retry:    2,
+	}
+
+	syncCrictlBinaries := &task.RemoteTask{
+		Name:     "SyncCrictlBinaries",
+		Desc:     "Sync crictl binaries",
+		Hosts:    hosts,
+		Prepare:  joinPrepares(prepares, &CrictlExist{Not: true}),
+		Action:   new(SyncCrictlBinaries),
+		Parallel: parallel,
+		Retry:    2,
+	}
+
+	generateCriDockerdService := &task.RemoteTask{
+		Name:    "GenerateCriDockerdService",
+		Desc:    "Generate cri-dockerd service",
+		Hosts:   hosts,
+		Prepare: joinPrepares(prepares, &CriDockerdExist{Not: true}),
+		Action: &action.Template{
+			Template: templates.CriDockerdService,
+			Dst:      filepath.Join("/etc/systemd/system", templates.CriDockerdService.Name()),
+			Data: util.Data{
+				"SandBoxImage": images.GetImage(runtime, kubeConf, "pause").ImageName(),
+			},
+		},
+		Parallel: parallel,
+	}
+
+	generateCriDockerdSocket := &task.RemoteTask{
+		Name:    "GenerateCriDockerdSocket",
+		Desc:    "Generate cri-dockerd socket",
+		Hosts:   hosts,
+		Prepare: joinPrepares(prepares, &CriDockerdExist{Not: true}),
+		Action: &action.Template{
+			Template: templates.CriDockerdSocket,
+			Dst:      filepath.Join("/etc/systemd/system", templates.CriDockerdSocket.Name()),
+		},
+		Parallel: parallel,
+	}
+
+	generateCrictlConfig := &task.RemoteTask{
+		Name:    "GenerateCrictlConfig",
+		Desc:    "Generate crictl config",
+		Hosts:   hosts,
+		Prepare: joinPrepares(prepares, &CriDockerdExist{Not: true}),
+		Action: &action.Template{
+			Template: templates.CrictlConfig,
+			Dst:      filepath.Join("/etc/", templates.CrictlConfig.Name()),
+			Data: util.Data{
+				"Endpoint": kubekeyapiv1alpha2.DefaultCriDockerdEndpoint,
+			},
+		},
+		Parallel: parallel,
+	}
+
+	enableCriDockerd := &task.RemoteTask{
+		Name:     "EnableCriDockerd",
+		Desc:     "Enable cri-dockerd",
+		Hosts:    hosts,
+		Prepare:  joinPrepares(prepares, &CriDockerdExist{Not: true}),
+		Action:   new(EnableCriDockerd),
+		Parallel: parallel,
+	}
+
+	return []task.Interface{
+		syncCriDockerd,
+		syncCrictlBinaries,
+		generateCriDockerdService,
+		generateCriDockerdSocket,
+		generateCrictlConfig,
+		enableCriDockerd,
+	}
+}
+
+func joinPrepares(prepares []prepare.Prepare, p ...prepare.Prepare) *prepare.PrepareCollection {
+	collection := append(append(prepare.PrepareCollection{}, prepares...), p...)
+	return &collection
+}
+
 type UninstallContainerModule struct {
 	common.KubeModule
 	Skip bool
@@ -266,7 +514,7 @@ func (i *UninstallContainerModule) Init() {
 	case common.Containerd:
 		i.Tasks = UninstallContainerd(i)
 	case common.Crio:
-		// TODO: Add the steps of cri-o's installation.
+		i.Tasks = UninstallCrio(i)
 	case common.Isula:
 		// TODO: Add the steps of iSula's installation.
 	default:
@@ -309,6 +557,23 @@ func UninstallContainerd(m *UninstallContainerModule) []task.Interface {
 	}
 }
 
+func UninstallCrio(m *UninstallContainerModule) []task.Interface {
+	disableCrio := &task.RemoteTask{
+		Name:  "UninstallCrio",
+		Desc:  "Uninstall crio",
+		Hosts: m.Runtime.GetHostsByRole(common.K8s),
+		Prepare: &prepare.PrepareCollection{
+			&CrioExist{Not: false},
+		},
+		Action:   new(DisableCrio),
+		Parallel: true,
+	}
+
+	return []task.Interface{
+		disableCrio,
+	}
+}
+
 type CriMigrateModule struct {
 	common.KubeModule
 
@@ -90,3 +90,41 @@ func (p *PrivateRegistryAuth) PreCheck(runtime connector.Runtime) (bool, error)
 	}
 	return true, nil
 }
+
+type CrioExist struct {
+	common.KubePrepare
+	Not bool
+}
+
+func (c *CrioExist) PreCheck(runtime connector.Runtime) (bool, error) {
+	output, err := runtime.GetRunner().SudoCmd(
+		"if [ -z $(which crio) ] || [ ! -e /var/run/crio/crio.sock ]; "+
+			"then echo 'not exist'; "+
+			"fi", false)
+	if err != nil {
+		return false, err
+	}
+	if strings.Contains(output, "not exist") {
+		return c.Not, nil
+	}
+	return !c.Not, nil
+}
+
+type CriDockerdExist struct {
+	common.KubePrepare
+	Not bool
+}
+
+func (c *CriDockerdExist) PreCheck(runtime connector.Runtime) (bool, error) {
+	output, err := runtime.GetRunner().SudoCmd(
+		"if [ -z $(which cri-dockerd) ] || [ ! -e /var/run/cri-dockerd.sock ]; "+
+			"then echo 'not exist'; "+
+			"fi", false)
+	if err != nil {
+		return false, err
+	}
+	if strings.Contains(output, "not exist") {
+		return c.Not, nil
+	}
+	return !c.Not, nil
+}
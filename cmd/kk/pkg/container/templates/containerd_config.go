@@ -20,9 +20,11 @@ import (
 	"text/template"
 
 	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var ContainerdConfig = template.Must(template.New("config.toml").Parse(
+var ContainerdConfig = template.Must(util.ParseTemplate(template.New("config.toml"),
 	dedent.Dedent(`version = 2
 {{- if .DataRoot }}
 root = {{ .DataRoot }}
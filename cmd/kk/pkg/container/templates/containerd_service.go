@@ -19,9 +19,11 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var ContainerdService = template.Must(template.New("containerd.service").Parse(
+var ContainerdService = template.Must(util.ParseTemplate(template.New("containerd.service"),
 	dedent.Dedent(`[Unit]
 Description=containerd container runtime
 Documentation=https://containerd.io
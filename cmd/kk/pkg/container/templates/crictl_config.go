@@ -19,9 +19,11 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var CrictlConfig = template.Must(template.New("crictl.yaml").Parse(
+var CrictlConfig = template.Must(util.ParseTemplate(template.New("crictl.yaml"),
 	dedent.Dedent(`runtime-endpoint: {{ .Endpoint }}
 image-endpoint: {{ .Endpoint }}
 timeout: 5
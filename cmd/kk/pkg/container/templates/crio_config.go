@@ -0,0 +1,188 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package templates
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/registry"
+)
+
+const CrioAuthFile = "/etc/crio/auth.json"
+
+var CrioConfig = template.Must(util.ParseTemplate(template.New("crio.conf"),
+	dedent.Dedent(`[crio]
+{{- if .DataRoot }}
+root = {{ .DataRoot }}
+{{- else }}
+root = "/var/lib/containers/storage"
+{{- end }}
+runroot = "/var/run/containers/storage"
+storage_driver = "overlay"
+log_dir = "/var/log/crio/pods"
+
+[crio.api]
+listen = "/var/run/crio/crio.sock"
+
+[crio.runtime]
+default_runtime = "runc"
+conmon = "/usr/bin/conmon"
+conmon_cgroup = "pod"
+cgroup_manager = "systemd"
+pinns_path = "/usr/bin/pinns"
+
+[crio.runtime.runtimes.runc]
+runtime_path = "/usr/bin/runc"
+runtime_type = "oci"
+runtime_root = "/run/runc"
+
+[crio.image]
+pause_image = "{{ .SandBoxImage }}"
+{{- if .AuthFile }}
+global_auth_file = "{{ .AuthFile }}"
+{{- end }}
+
+[crio.network]
+network_dir = "/etc/cni/net.d/"
+plugin_dirs = ["/opt/cni/bin/"]
+    `)))
+
+var CrioRegistries = template.Must(util.ParseTemplate(template.New("registries.conf"),
+	dedent.Dedent(`unqualified-search-registries = ["docker.io"]
+
+[[registry]]
+prefix = "docker.io"
+location = "registry-1.docker.io"
+{{- range .Mirrors }}
+
+[[registry.mirror]]
+location = "{{ .Location }}"
+insecure = {{ .Insecure }}
+{{- end }}
+{{- range .Registries }}
+
+[[registry]]
+prefix = "{{ .Location }}"
+location = "{{ .Location }}"
+insecure = {{ .Insecure }}
+{{- end }}
+    `)))
+
+var CrioPolicy = template.Must(util.ParseTemplate(template.New("policy.json"),
+	dedent.Dedent(`{
+  "default": [
+    {
+      "type": "insecureAcceptAnything"
+    }
+  ]
+}
+    `)))
+
+var CrioAuth = template.Must(util.ParseTemplate(template.New("auth.json"),
+	dedent.Dedent(`{
+  "auths": {
+    {{- range $i, $auth := .Auths }}
+    {{- if $i }},{{ end }}
+    "{{ $auth.Location }}": {
+      "auth": "{{ $auth.Auth }}"
+    }
+    {{- end }}
+  }
+}
+    `)))
+
+// CrioRegistry is a registry or a mirror of docker.io in the registries.conf of cri-o.
+type CrioRegistry struct {
+	Location string
+	Insecure bool
+	Auth     string
+}
+
+// CrioMirrors returns the registry mirrors without the scheme, the mirrors served by http are insecure.
+func CrioMirrors(kubeConf *common.KubeConf) []CrioRegistry {
+	var mirrors []CrioRegistry
+	for _, mirror := range kubeConf.Cluster.Registry.RegistryMirrors {
+		location, insecure := trimScheme(mirror)
+		mirrors = append(mirrors, CrioRegistry{Location: location, Insecure: insecure})
+	}
+	return mirrors
+}
+
+// CrioInsecureRegistries returns the insecure registries and the registries of the auths which skip the tls verification.
+func CrioInsecureRegistries(kubeConf *common.KubeConf, auths map[string]*registry.DockerRegistryEntry) []CrioRegistry {
+	var registries []CrioRegistry
+	seen := make(map[string]struct{})
+	add := func(repo string) {
+		location, _ := trimScheme(repo)
+		if _, ok := seen[location]; ok {
+			return
+		}
+		seen[location] = struct{}{}
+		registries = append(registries, CrioRegistry{Location: location, Insecure: true})
+	}
+
+	for _, repo := range kubeConf.Cluster.Registry.InsecureRegistries {
+		add(repo)
+	}
+	for _, repo := range sortedRepos(auths) {
+		if auths[repo].SkipTLSVerify || auths[repo].PlainHTTP {
+			add(repo)
+		}
+	}
+	return registries
+}
+
+// CrioAuths returns the base64 encoded credentials of the auths which have a username.
+func CrioAuths(auths map[string]*registry.DockerRegistryEntry) []CrioRegistry {
+	var result []CrioRegistry
+	for _, repo := range sortedRepos(auths) {
+		entry := auths[repo]
+		if entry.Username == "" {
+			continue
+		}
+		location, _ := trimScheme(repo)
+		result = append(result, CrioRegistry{
+			Location: location,
+			Auth:     base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", entry.Username, entry.Password))),
+		})
+	}
+	return result
+}
+
+func trimScheme(repo string) (string, bool) {
+	if strings.HasPrefix(repo, "http://") {
+		return strings.TrimSuffix(strings.TrimPrefix(repo, "http://"), "/"), true
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(repo, "https://"), "/"), false
+}
+
+func sortedRepos(auths map[string]*registry.DockerRegistryEntry) []string {
+	repos := make([]string, 0, len(auths))
+	for repo := range auths {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	return repos
+}
@@ -0,0 +1,80 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package templates
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	kubekeyapiv1alpha2 "github.com/kubesphere/kubekey/v3/cmd/kk/apis/kubekey/v1alpha2"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/registry"
+)
+
+func TestCrioRegistries(t *testing.T) {
+	kubeConf := &common.KubeConf{Cluster: &kubekeyapiv1alpha2.ClusterSpec{
+		Registry: kubekeyapiv1alpha2.RegistryConfig{
+			RegistryMirrors:    []string{"https://mirror.example.com/", "http://10.0.0.1:5000"},
+			InsecureRegistries: []string{"dockerhub.kubekey.local"},
+		},
+	}}
+	auths := map[string]*registry.DockerRegistryEntry{
+		"harbor.example.com":      {Username: "admin", Password: "Harbor12345", SkipTLSVerify: true},
+		"dockerhub.kubekey.local": {Username: "admin", Password: "secret", PlainHTTP: true},
+	}
+
+	var out bytes.Buffer
+	if err := CrioRegistries.Execute(&out, map[string]interface{}{
+		"Mirrors":    CrioMirrors(kubeConf),
+		"Registries": CrioInsecureRegistries(kubeConf, auths),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"[[registry.mirror]]\nlocation = \"mirror.example.com\"\ninsecure = false\n",
+		"[[registry.mirror]]\nlocation = \"10.0.0.1:5000\"\ninsecure = true\n",
+		"[[registry]]\nprefix = \"dockerhub.kubekey.local\"\nlocation = \"dockerhub.kubekey.local\"\ninsecure = true\n",
+		"[[registry]]\nprefix = \"harbor.example.com\"\nlocation = \"harbor.example.com\"\ninsecure = true\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("registries.conf does not contain %q:\n%s", want, out.String())
+		}
+	}
+	if n := strings.Count(out.String(), "dockerhub.kubekey.local\"\ninsecure"); n != 1 {
+		t.Errorf("dockerhub.kubekey.local is listed %d times, want 1", n)
+	}
+
+	out.Reset()
+	if err := CrioAuth.Execute(&out, map[string]interface{}{"Auths": CrioAuths(auths)}); err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "auths": {
+    "dockerhub.kubekey.local": {
+      "auth": "YWRtaW46c2VjcmV0"
+    },
+    "harbor.example.com": {
+      "auth": "YWRtaW46SGFyYm9yMTIzNDU="
+    }
+  }
+}
+`
+	if out.String() != want {
+		t.Errorf("unexpected auth.json:\n%s", out.String())
+	}
+}
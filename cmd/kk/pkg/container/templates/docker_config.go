@@ -25,9 +25,10 @@ import (
 	"github.com/lithammer/dedent"
 
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var DockerConfig = template.Must(template.New("daemon.json").Parse(
+var DockerConfig = template.Must(util.ParseTemplate(template.New("daemon.json"),
 	dedent.Dedent(`{
   "log-opts": {
     "max-size": "5m",
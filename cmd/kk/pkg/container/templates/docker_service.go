@@ -19,9 +19,11 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var DockerService = template.Must(template.New("docker.service").Parse(
+var DockerService = template.Must(util.ParseTemplate(template.New("docker.service"),
 	dedent.Dedent(`[Unit]
 Description=Docker Application Container Engine
 Documentation=https://docs.docker.com
@@ -35,11 +35,21 @@ type Template struct {
 }
 
 func (t *Template) Execute(runtime connector.Runtime) error {
-	templateStr, err := util.Render(t.Template, t.Data)
+	tmpl, err := util.OverrideTemplate(t.Template, runtime.GetTemplateDir())
+	if err != nil {
+		return err
+	}
+
+	templateStr, err := util.Render(tmpl, t.Data)
 	if err != nil {
 		return errors.Wrap(errors.WithStack(err), fmt.Sprintf("render template %s failed", t.Template.Name()))
 	}
 
+	if r, ok := runtime.GetRunner().Conn.(connector.TemplateRecorder); ok {
+		r.RecordTemplate(t.Template.Name(), t.Dst, templateStr)
+		return nil
+	}
+
 	fileName := filepath.Join(runtime.GetHostWorkDir(), t.Template.Name())
 	if err := util.WriteFile(fileName, []byte(templateStr)); err != nil {
 		return errors.Wrap(errors.WithStack(err), fmt.Sprintf("write file %s failed", fileName))
@@ -21,22 +21,38 @@ import (
 )
 
 type BaseHost struct {
-	Name            string `yaml:"name,omitempty" json:"name,omitempty"`
-	Address         string `yaml:"address,omitempty" json:"address,omitempty"`
-	InternalAddress string `yaml:"internalAddress,omitempty" json:"internalAddress,omitempty"`
-	Port            int    `yaml:"port,omitempty" json:"port,omitempty"`
-	User            string `yaml:"user,omitempty" json:"user,omitempty"`
-	Password        string `yaml:"password,omitempty" json:"password,omitempty"`
-	PrivateKey      string `yaml:"privateKey,omitempty" json:"privateKey,omitempty"`
-	PrivateKeyPath  string `yaml:"privateKeyPath,omitempty" json:"privateKeyPath,omitempty"`
-	Arch            string `yaml:"arch,omitempty" json:"arch,omitempty"`
-	Timeout         int64  `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	Name            string    `yaml:"name,omitempty" json:"name,omitempty"`
+	Address         string    `yaml:"address,omitempty" json:"address,omitempty"`
+	InternalAddress string    `yaml:"internalAddress,omitempty" json:"internalAddress,omitempty"`
+	Port            int       `yaml:"port,omitempty" json:"port,omitempty"`
+	User            string    `yaml:"user,omitempty" json:"user,omitempty"`
+	Password        string    `yaml:"password,omitempty" json:"password,omitempty"`
+	PrivateKey      string    `yaml:"privateKey,omitempty" json:"privateKey,omitempty"`
+	PrivateKeyPath  string    `yaml:"privateKeyPath,omitempty" json:"privateKeyPath,omitempty"`
+	Arch            string    `yaml:"arch,omitempty" json:"arch,omitempty"`
+	Timeout         int64     `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	HostKeyChecking string    `yaml:"hostKeyChecking,omitempty" json:"hostKeyChecking,omitempty"`
+	KnownHostsFile  string    `yaml:"knownHostsFile,omitempty" json:"knownHostsFile,omitempty"`
+	Bastions        []Bastion `yaml:"bastions,omitempty" json:"bastions,omitempty"`
+
+	// InternalAddresses are all the internal addresses of a dual-stack host, the first one is InternalAddress.
+	InternalAddresses []string `json:"-"`
 
 	Roles     []string        `json:"-"`
 	RoleTable map[string]bool `json:"-"`
 	Cache     *cache.Cache    `json:"-"`
 }
 
+// Bastion is a jump host on the SSH path to a host. The credentials of the host are used when none is set.
+type Bastion struct {
+	Address        string `yaml:"address,omitempty" json:"address,omitempty"`
+	Port           int    `yaml:"port,omitempty" json:"port,omitempty"`
+	User           string `yaml:"user,omitempty" json:"user,omitempty"`
+	Password       string `yaml:"password,omitempty" json:"password,omitempty"`
+	PrivateKey     string `yaml:"privateKey,omitempty" json:"privateKey,omitempty"`
+	PrivateKeyPath string `yaml:"privateKeyPath,omitempty" json:"privateKeyPath,omitempty"`
+}
+
 func NewHost() *BaseHost {
 	return &BaseHost{
 		Roles:     make([]string, 0, 0),
@@ -69,6 +85,14 @@ func (b *BaseHost) SetInternalAddress(str string) {
 	b.InternalAddress = str
 }
 
+// GetInternalAddresses returns the internal addresses of each IP family of the host.
+func (b *BaseHost) GetInternalAddresses() []string {
+	if len(b.InternalAddresses) == 0 {
+		return []string{b.InternalAddress}
+	}
+	return b.InternalAddresses
+}
+
 func (b *BaseHost) GetPort() int {
 	return b.Port
 }
@@ -125,6 +149,30 @@ func (b *BaseHost) SetTimeout(timeout int64) {
 	b.Timeout = timeout
 }
 
+func (b *BaseHost) GetHostKeyChecking() string {
+	return b.HostKeyChecking
+}
+
+func (b *BaseHost) SetHostKeyChecking(mode string) {
+	b.HostKeyChecking = mode
+}
+
+func (b *BaseHost) GetKnownHostsFile() string {
+	return b.KnownHostsFile
+}
+
+func (b *BaseHost) SetKnownHostsFile(path string) {
+	b.KnownHostsFile = path
+}
+
+func (b *BaseHost) GetBastions() []Bastion {
+	return b.Bastions
+}
+
+func (b *BaseHost) SetBastions(bastions []Bastion) {
+	b.Bastions = bastions
+}
+
 func (b *BaseHost) GetRoles() []string {
 	return b.Roles
 }
@@ -18,6 +18,7 @@ package connector
 
 import (
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/cache"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/event"
 	"io"
 	"os"
 )
@@ -59,6 +60,12 @@ type Runtime interface {
 	SetRunner(r *Runner)
 	GetConnector() Connector
 	SetConnector(c Connector)
+	GetEventSink() event.Sink
+	SetEventSink(s event.Sink)
+	GetRollout() Rollout
+	SetRollout(r Rollout)
+	GetTemplateDir() string
+	SetTemplateDir(dir string)
 	RemoteHost() Host
 	Copy() Runtime
 	ModuleRuntime
@@ -71,6 +78,7 @@ type Host interface {
 	SetAddress(str string)
 	GetInternalAddress() string
 	SetInternalAddress(str string)
+	GetInternalAddresses() []string
 	GetPort() int
 	SetPort(port int)
 	GetUser() string
@@ -85,6 +93,12 @@ type Host interface {
 	SetArch(arch string)
 	GetTimeout() int64
 	SetTimeout(timeout int64)
+	GetHostKeyChecking() string
+	SetHostKeyChecking(mode string)
+	GetKnownHostsFile() string
+	SetKnownHostsFile(path string)
+	GetBastions() []Bastion
+	SetBastions(bastions []Bastion)
 	GetRoles() []string
 	SetRoles(roles []string)
 	IsRole(role string) bool
@@ -0,0 +1,258 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package connector
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	RecordTask     = "task"
+	RecordCommand  = "command"
+	RecordScp      = "scp"
+	RecordFetch    = "fetch"
+	RecordTemplate = "template"
+	RecordNote     = "note"
+
+	// maxRecordedContent is the max size of a copied file whose content is kept in the report.
+	maxRecordedContent = 1 << 20
+)
+
+// Record is a remote operation recorded in dry-run mode.
+type Record struct {
+	Type    string `json:"type"`
+	Task    string `json:"task,omitempty"`
+	Command string `json:"command,omitempty"`
+	Src     string `json:"src,omitempty"`
+	Dst     string `json:"dst,omitempty"`
+	Content string `json:"content,omitempty"`
+	Size    int64  `json:"size,omitempty"`
+	Sha256  string `json:"sha256,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Recorder is a Connector which records the remote operations of each host instead of executing them.
+// The recorded commands always succeed with an empty output, and the remote files never exist.
+type Recorder struct {
+	lock    sync.Mutex
+	hosts   []string
+	records map[string][]Record
+	task    map[string]string
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{
+		records: make(map[string][]Record),
+		task:    make(map[string]string),
+	}
+}
+
+func (r *Recorder) Connect(host Host) (Connection, error) {
+	return &recordConnection{recorder: r, host: host.GetName()}, nil
+}
+
+func (r *Recorder) Close(_ Host) {}
+
+// Begin marks the following records of the host as the ones of the task.
+func (r *Recorder) Begin(host, task string) {
+	r.lock.Lock()
+	r.task[host] = task
+	r.lock.Unlock()
+	r.Record(host, Record{Type: RecordTask})
+}
+
+// Note records a message of the host, such as a failure which is ignored in dry-run mode.
+func (r *Recorder) Note(host, message string) {
+	r.Record(host, Record{Type: RecordNote, Message: message})
+}
+
+func (r *Recorder) Record(host string, record Record) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.records[host]; !ok {
+		r.hosts = append(r.hosts, host)
+	}
+	record.Task = r.task[host]
+	r.records[host] = append(r.records[host], record)
+}
+
+// Hosts returns the hosts in the order they are recorded.
+func (r *Recorder) Hosts() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]string(nil), r.hosts...)
+}
+
+// Records returns the records of the host.
+func (r *Recorder) Records(host string) []Record {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]Record(nil), r.records[host]...)
+}
+
+// WriteJSON writes the records of all the hosts as a JSON report.
+func (r *Recorder) WriteJSON(w io.Writer) error {
+	report := make(map[string][]Record)
+	for _, host := range r.Hosts() {
+		report[host] = r.Records(host)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// WriteScript writes the records of the host as a shell script.
+func (r *Recorder) WriteScript(w io.Writer, host string) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "#!/usr/bin/env bash\n# The operations KubeKey would perform on %s, recorded in dry-run mode.\n", host)
+	for _, record := range r.Records(host) {
+		switch record.Type {
+		case RecordTask:
+			fmt.Fprintf(&buf, "\n# Task: %s\n", record.Task)
+		case RecordCommand:
+			fmt.Fprintln(&buf, record.Command)
+		case RecordNote:
+			fmt.Fprintf(&buf, "# NOTE: %s\n", strings.ReplaceAll(record.Message, "\n", "\n# "))
+		case RecordFetch:
+			fmt.Fprintf(&buf, "# fetch %s to the local %s\n", record.Src, record.Dst)
+		case RecordTemplate:
+			fmt.Fprintf(&buf, "# render %s\n", record.Src)
+			writeHeredoc(&buf, record.Dst, record.Content)
+		case RecordScp:
+			switch {
+			case record.Content != "":
+				fmt.Fprintf(&buf, "# copy the local %s\n", record.Src)
+				writeHeredoc(&buf, record.Dst, record.Content)
+			case record.Sha256 == "":
+				fmt.Fprintf(&buf, "# copy the local %s to %s\n", record.Src, record.Dst)
+			default:
+				fmt.Fprintf(&buf, "# copy the local %s to %s (%d bytes, sha256 %s)\n", record.Src, record.Dst, record.Size, record.Sha256)
+			}
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// WriteScripts writes a shell script for each host into the dir, and returns the paths of the scripts.
+func (r *Recorder) WriteScripts(dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "create dir %s failed", dir)
+	}
+
+	var paths []string
+	for _, host := range r.Hosts() {
+		path := filepath.Join(dir, host+".sh")
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+		if err != nil {
+			return nil, errors.Wrapf(err, "create file %s failed", path)
+		}
+		if err := r.WriteScript(f, host); err != nil {
+			_ = f.Close()
+			return nil, errors.Wrapf(err, "write file %s failed", path)
+		}
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func writeHeredoc(w io.Writer, dst, content string) {
+	fmt.Fprintf(w, "cat > %s <<'KUBEKEY_EOF'\n%s", dst, content)
+	if !strings.HasSuffix(content, "\n") {
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, "KUBEKEY_EOF")
+}
+
+// TemplateRecorder is implemented by the connections which record the rendered templates instead of copying them.
+type TemplateRecorder interface {
+	RecordTemplate(name, dst, content string)
+}
+
+type recordConnection struct {
+	recorder *Recorder
+	host     string
+}
+
+func (c *recordConnection) Exec(cmd string, _ Host) (string, int, error) {
+	c.recorder.Record(c.host, Record{Type: RecordCommand, Command: cmd})
+	return "", 0, nil
+}
+
+func (c *recordConnection) PExec(cmd string, _ io.Reader, _ io.Writer, _ io.Writer, _ Host) (int, error) {
+	c.recorder.Record(c.host, Record{Type: RecordCommand, Command: cmd})
+	return 0, nil
+}
+
+func (c *recordConnection) Fetch(local, remote string, _ Host) error {
+	c.recorder.Record(c.host, Record{Type: RecordFetch, Src: remote, Dst: local})
+	return nil
+}
+
+func (c *recordConnection) Scp(local, remote string, _ Host) error {
+	record := Record{Type: RecordScp, Src: local, Dst: remote}
+	if fi, err := os.Stat(local); err == nil && !fi.IsDir() {
+		record.Size = fi.Size()
+		if content, err := os.ReadFile(local); err == nil {
+			record.Sha256 = fmt.Sprintf("%x", sha256.Sum256(content))
+			if fi.Size() <= maxRecordedContent && utf8.Valid(content) && !bytes.ContainsRune(content, 0) {
+				record.Content = string(content)
+			}
+		}
+	}
+	c.recorder.Record(c.host, record)
+	return nil
+}
+
+func (c *recordConnection) RemoteFileExist(_ string, _ Host) bool {
+	return false
+}
+
+func (c *recordConnection) RemoteDirExist(_ string, _ Host) (bool, error) {
+	return false, nil
+}
+
+func (c *recordConnection) MkDirAll(path string, mode string, _ Host) error {
+	c.recorder.Record(c.host, Record{Type: RecordCommand, Command: SudoPrefix(mkDirAllCmd(path, mode))})
+	return nil
+}
+
+func (c *recordConnection) Chmod(path string, mode os.FileMode) error {
+	c.recorder.Record(c.host, Record{Type: RecordCommand, Command: fmt.Sprintf("chmod %o %s", mode, filepath.Dir(path))})
+	return nil
+}
+
+func (c *recordConnection) Close() {}
+
+func (c *recordConnection) RecordTemplate(name, dst, content string) {
+	c.recorder.Record(c.host, Record{Type: RecordTemplate, Src: name, Dst: dst, Content: content})
+}
@@ -0,0 +1,87 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package connector
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	local := filepath.Join(t.TempDir(), "kubelet.conf")
+	if err := os.WriteFile(local, []byte("KUBELET_ARGS=--v=2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRecorder()
+	host := NewHost()
+	host.SetName("node1")
+	conn, err := r.Connect(host)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r.Begin("node1", "InstallKubelet")
+	if out, code, err := conn.Exec("systemctl restart kubelet", host); out != "" || code != 0 || err != nil {
+		t.Fatalf("Exec() = %q, %d, %v, want empty output", out, code, err)
+	}
+	if err := conn.Scp(local, "/etc/kubelet.conf", host); err != nil {
+		t.Fatal(err)
+	}
+	if conn.RemoteFileExist("/etc/kubelet.conf", host) {
+		t.Fatal("remote files should never exist in dry-run mode")
+	}
+	conn.(TemplateRecorder).RecordTemplate("kubeadm-config", "/etc/kubernetes/kubeadm-config.yaml", "kind: ClusterConfiguration")
+	r.Note("node1", "the task failed")
+
+	var script bytes.Buffer
+	if err := r.WriteScript(&script, "node1"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Task: InstallKubelet\n",
+		"systemctl restart kubelet\n",
+		"cat > /etc/kubelet.conf <<'KUBEKEY_EOF'\nKUBELET_ARGS=--v=2\nKUBEKEY_EOF\n",
+		"# render kubeadm-config\ncat > /etc/kubernetes/kubeadm-config.yaml <<'KUBEKEY_EOF'\nkind: ClusterConfiguration\nKUBEKEY_EOF\n",
+		"# NOTE: the task failed\n",
+	} {
+		if !strings.Contains(script.String(), want) {
+			t.Errorf("script does not contain %q:\n%s", want, script.String())
+		}
+	}
+
+	var report bytes.Buffer
+	if err := r.WriteJSON(&report); err != nil {
+		t.Fatal(err)
+	}
+	records := make(map[string][]Record)
+	if err := json.Unmarshal(report.Bytes(), &records); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(records["node1"]); got != 5 {
+		t.Fatalf("got %d records, want 5", got)
+	}
+	for _, record := range records["node1"] {
+		if record.Task != "InstallKubelet" {
+			t.Errorf("record %+v has task %q, want InstallKubelet", record, record.Task)
+		}
+	}
+}
@@ -22,16 +22,34 @@ import (
 	"path/filepath"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/event"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
+// Rollout limits how many hosts the remote tasks run on at the same time.
+type Rollout struct {
+	// Concurrency is the max number of hosts which run a task at the same time, 0 means the default.
+	Concurrency int
+	// BatchSize runs the hosts of a task in waves, it is a number or a percentage of the hosts.
+	BatchSize *intstr.IntOrString
+	// MaxFailures is the number or the percentage of the hosts which may fail before a task fails,
+	// the hosts which fail within it are removed from the pipeline.
+	MaxFailures *intstr.IntOrString
+	// CriticalRoles are the roles of the hosts whose failure is never tolerated by MaxFailures.
+	CriticalRoles []string
+}
+
 type BaseRuntime struct {
 	ObjName         string
 	connector       Connector
 	runner          *Runner
+	eventSink       event.Sink
+	rollout         Rollout
+	templateDir     string
 	workDir         string
 	verbose         bool
 	ignoreErr       bool
@@ -85,6 +103,35 @@ func (b *BaseRuntime) SetConnector(c Connector) {
 	b.connector = c
 }
 
+// GetEventSink returns the sink of the pipeline events, the events are discarded if it is not set.
+func (b *BaseRuntime) GetEventSink() event.Sink {
+	if b.eventSink == nil {
+		return event.Discard
+	}
+	return b.eventSink
+}
+
+func (b *BaseRuntime) SetEventSink(s event.Sink) {
+	b.eventSink = s
+}
+
+func (b *BaseRuntime) GetRollout() Rollout {
+	return b.rollout
+}
+
+func (b *BaseRuntime) SetRollout(r Rollout) {
+	b.rollout = r
+}
+
+// GetTemplateDir returns the directory of the templates which override the built-in ones.
+func (b *BaseRuntime) GetTemplateDir() string {
+	return b.templateDir
+}
+
+func (b *BaseRuntime) SetTemplateDir(dir string) {
+	b.templateDir = dir
+}
+
 func (b *BaseRuntime) GenerateWorkDir() error {
 	currentDir, err := filepath.Abs(filepath.Dir(os.Args[0]))
 	if err != nil {
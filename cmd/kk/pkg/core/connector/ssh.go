@@ -39,6 +39,7 @@ import (
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/common"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
+	"github.com/kubesphere/kubekey/v3/util/hostkey"
 )
 
 type Cfg struct {
@@ -53,6 +54,11 @@ type Cfg struct {
 	Bastion     string
 	BastionPort int
 	BastionUser string
+	// Bastions is the chain of jump hosts, the connection goes through them in order.
+	Bastions []Bastion
+
+	HostKeyChecking string
+	KnownHostsFile  string
 }
 
 const socketEnvPrefix = "env:"
@@ -61,6 +67,7 @@ type connection struct {
 	mu         sync.Mutex
 	sftpclient *sftp.Client
 	sshclient  *ssh.Client
+	bastions   []*ssh.Client
 	ctx        context.Context
 	cancel     context.CancelFunc
 }
@@ -71,18 +78,9 @@ func NewConnection(cfg Cfg) (Connection, error) {
 		return nil, errors.Wrap(err, "Failed to validate ssh connection parameters")
 	}
 
-	authMethods := make([]ssh.AuthMethod, 0)
-
-	if len(cfg.Password) > 0 {
-		authMethods = append(authMethods, ssh.Password(cfg.Password))
-	}
-
-	if len(cfg.PrivateKey) > 0 {
-		signer, parseErr := ssh.ParsePrivateKey([]byte(cfg.PrivateKey))
-		if parseErr != nil {
-			return nil, errors.Wrap(parseErr, "The given SSH key could not be parsed")
-		}
-		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	authMethods, err := newAuthMethods(cfg.Password, cfg.PrivateKey)
+	if err != nil {
+		return nil, err
 	}
 
 	if len(cfg.AgentSocket) > 0 {
@@ -112,27 +110,9 @@ func NewConnection(cfg Cfg) (Connection, error) {
 		authMethods = append(authMethods, ssh.PublicKeys(signers...))
 	}
 
-	sshConfig := &ssh.ClientConfig{
-		User:            cfg.Username,
-		Timeout:         cfg.Timeout,
-		Auth:            authMethods,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-	}
-
-	targetHost := cfg.Address
-	targetPort := strconv.Itoa(cfg.Port)
-
-	if cfg.Bastion != "" {
-		targetHost = cfg.Bastion
-		targetPort = strconv.Itoa(cfg.BastionPort)
-		sshConfig.User = cfg.BastionUser
-	}
-
-	endpoint := net.JoinHostPort(targetHost, targetPort)
-
-	client, err := ssh.Dial("tcp", endpoint, sshConfig)
+	hostKeyCallback, err := hostkey.Callback(cfg.HostKeyChecking, cfg.KnownHostsFile)
 	if err != nil {
-		return nil, errors.Wrapf(err, "could not establish connection to %s", endpoint)
+		return nil, errors.Wrap(err, "Failed to create the host key callback")
 	}
 
 	ctx, cancelFn := context.WithCancel(context.Background())
@@ -141,38 +121,93 @@ func NewConnection(cfg Cfg) (Connection, error) {
 		cancel: cancelFn,
 	}
 
-	if cfg.Bastion == "" {
-		sshConn.sshclient = client
-		sftpClient, err := sftp.NewClient(sshConn.sshclient)
+	var client *ssh.Client
+	for _, bastion := range cfg.Bastions {
+		bastionAuthMethods := authMethods
+		if len(bastion.Password) > 0 || len(bastion.PrivateKey) > 0 {
+			bastionAuthMethods, err = newAuthMethods(bastion.Password, bastion.PrivateKey)
+			if err != nil {
+				sshConn.Close()
+				return nil, errors.Wrapf(err, "bastion %s", bastion.Address)
+			}
+		}
+
+		bastionConfig := &ssh.ClientConfig{
+			User:            bastion.User,
+			Timeout:         cfg.Timeout,
+			Auth:            bastionAuthMethods,
+			HostKeyCallback: hostKeyCallback,
+		}
+		client, err = dial(client, net.JoinHostPort(bastion.Address, strconv.Itoa(bastion.Port)), bastionConfig)
 		if err != nil {
-			return nil, errors.Wrapf(err, "new sftp client failed: %v", err)
+			sshConn.Close()
+			return nil, err
 		}
-		sshConn.sftpclient = sftpClient
-		return sshConn, nil
+		sshConn.bastions = append(sshConn.bastions, client)
 	}
 
-	endpointBehindBastion := net.JoinHostPort(cfg.Address, strconv.Itoa(cfg.Port))
-
-	conn, err := client.Dial("tcp", endpointBehindBastion)
-	if err != nil {
-		return nil, errors.Wrapf(err, "could not establish connection to %s", endpointBehindBastion)
+	sshConfig := &ssh.ClientConfig{
+		User:            cfg.Username,
+		Timeout:         cfg.Timeout,
+		Auth:            authMethods,
+		HostKeyCallback: hostKeyCallback,
 	}
-
-	sshConfig.User = cfg.Username
-	ncc, chans, reqs, err := ssh.NewClientConn(conn, endpointBehindBastion, sshConfig)
+	sshConn.sshclient, err = dial(client, net.JoinHostPort(cfg.Address, strconv.Itoa(cfg.Port)), sshConfig)
 	if err != nil {
-		return nil, errors.Wrapf(err, "could not establish connection to %s", endpointBehindBastion)
+		sshConn.Close()
+		return nil, err
 	}
 
-	sshConn.sshclient = ssh.NewClient(ncc, chans, reqs)
+	// the sftp client runs on top of the ssh client, so the file copies go through the bastions as well.
 	sftpClient, err := sftp.NewClient(sshConn.sshclient)
 	if err != nil {
+		sshConn.Close()
 		return nil, errors.Wrapf(err, "new sftp client failed: %v", err)
 	}
 	sshConn.sftpclient = sftpClient
 	return sshConn, nil
 }
 
+// dial connects to the endpoint directly, or through the given bastion client if it is not nil.
+func dial(bastion *ssh.Client, endpoint string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	if bastion == nil {
+		client, err := ssh.Dial("tcp", endpoint, config)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not establish connection to %s", endpoint)
+		}
+		return client, nil
+	}
+
+	conn, err := bastion.Dial("tcp", endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not establish connection to %s via bastion %s", endpoint, bastion.RemoteAddr())
+	}
+
+	ncc, chans, reqs, err := ssh.NewClientConn(conn, endpoint, config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.Wrapf(err, "could not establish connection to %s via bastion %s", endpoint, bastion.RemoteAddr())
+	}
+	return ssh.NewClient(ncc, chans, reqs), nil
+}
+
+func newAuthMethods(password, privateKey string) ([]ssh.AuthMethod, error) {
+	authMethods := make([]ssh.AuthMethod, 0)
+
+	if len(password) > 0 {
+		authMethods = append(authMethods, ssh.Password(password))
+	}
+
+	if len(privateKey) > 0 {
+		signer, parseErr := ssh.ParsePrivateKey([]byte(privateKey))
+		if parseErr != nil {
+			return nil, errors.Wrap(parseErr, "The given SSH key could not be parsed")
+		}
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	}
+	return authMethods, nil
+}
+
 func validateOptions(cfg Cfg) (Cfg, error) {
 	if len(cfg.Username) == 0 {
 		return cfg, errors.New("No username specified for SSH connection")
@@ -200,13 +235,37 @@ func validateOptions(cfg Cfg) (Cfg, error) {
 		cfg.Port = 22
 	}
 
-	if cfg.BastionPort <= 0 {
-		cfg.BastionPort = 22
+	if cfg.Bastion != "" {
+		cfg.Bastions = append([]Bastion{{Address: cfg.Bastion, Port: cfg.BastionPort, User: cfg.BastionUser}}, cfg.Bastions...)
+		cfg.Bastion, cfg.BastionPort, cfg.BastionUser = "", 0, ""
 	}
 
-	if cfg.BastionUser == "" {
-		cfg.BastionUser = cfg.Username
+	bastions := make([]Bastion, 0, len(cfg.Bastions))
+	for _, bastion := range cfg.Bastions {
+		if len(bastion.Address) == 0 {
+			return cfg, errors.New("No address specified for SSH bastion")
+		}
+
+		if len(bastion.PrivateKey) == 0 && len(bastion.PrivateKeyPath) > 0 {
+			content, err := os.ReadFile(bastion.PrivateKeyPath)
+			if err != nil {
+				return cfg, errors.Wrapf(err, "Failed to read the keyfile %q of bastion %s", bastion.PrivateKeyPath, bastion.Address)
+			}
+
+			bastion.PrivateKey = string(content)
+			bastion.PrivateKeyPath = ""
+		}
+
+		if bastion.Port <= 0 {
+			bastion.Port = 22
+		}
+
+		if bastion.User == "" {
+			bastion.User = cfg.Username
+		}
+		bastions = append(bastions, bastion)
 	}
+	cfg.Bastions = bastions
 
 	if cfg.Timeout == 0 {
 		cfg.Timeout = 15 * time.Second
@@ -219,19 +278,23 @@ func (c *connection) Close() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.sshclient == nil && c.sftpclient == nil {
+	if c.sshclient == nil && c.sftpclient == nil && len(c.bastions) == 0 {
 		return
 	}
 	c.cancel()
 
+	if c.sftpclient != nil {
+		c.sftpclient.Close()
+		c.sftpclient = nil
+	}
 	if c.sshclient != nil {
 		c.sshclient.Close()
 		c.sshclient = nil
 	}
-	if c.sftpclient != nil {
-		c.sftpclient.Close()
-		c.sftpclient = nil
+	for i := len(c.bastions) - 1; i >= 0; i-- {
+		c.bastions[i].Close()
 	}
+	c.bastions = nil
 }
 
 func (c *connection) session() (*ssh.Session, error) {
@@ -581,18 +644,21 @@ func (c *connection) RemoteDirExist(dst string, host Host) (bool, error) {
 }
 
 func (c *connection) MkDirAll(path string, mode string, host Host) error {
+	if _, _, err := c.Exec(SudoPrefix(mkDirAllCmd(path, mode)), host); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func mkDirAllCmd(path string, mode string) string {
 	if mode == "" {
 		mode = "775"
 	}
-	mkDstDir := fmt.Sprintf("mkdir -p -m %s %s || true", mode, path)
 	if strings.Contains(path, common.TmpDir) {
-		mkDstDir = fmt.Sprintf("mkdir -p  %s && chmod -R  %s  %s || true", path, mode, common.TmpDir)
-	}
-	if _, _, err := c.Exec(SudoPrefix(mkDstDir), host); err != nil {
-		return err
+		return fmt.Sprintf("mkdir -p  %s && chmod -R  %s  %s || true", path, mode, common.TmpDir)
 	}
-
-	return nil
+	return fmt.Sprintf("mkdir -p -m %s %s || true", mode, path)
 }
 
 func (c *connection) Chmod(path string, mode os.FileMode) error {
@@ -0,0 +1,223 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package connector
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"net"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// testSSHServer accepts the password, forwards the direct-tcpip channels and serves sftp. It records the users
+// which log in and the destinations which are forwarded.
+type testSSHServer struct {
+	addr     string
+	mu       sync.Mutex
+	users    []string
+	forwards []string
+}
+
+func newTestSSHServer(t *testing.T, password string) *testSSHServer {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &testSSHServer{}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if string(pass) != password {
+				return nil, errors.New("wrong password")
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.users = append(s.users, c.User())
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+	s.addr = l.Addr().String()
+	go func() {
+		for {
+			nc, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(nc, config)
+		}
+	}()
+	return s
+}
+
+func (s *testSSHServer) serve(nc net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(nc, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newCh := range chans {
+		switch newCh.ChannelType() {
+		case "direct-tcpip":
+			go s.forward(newCh)
+		case "session":
+			go serveSftp(newCh)
+		default:
+			_ = newCh.Reject(ssh.UnknownChannelType, newCh.ChannelType())
+		}
+	}
+}
+
+func (s *testSSHServer) forward(newCh ssh.NewChannel) {
+	var payload struct {
+		DestAddr string
+		DestPort uint32
+		OrigAddr string
+		OrigPort uint32
+	}
+	if err := ssh.Unmarshal(newCh.ExtraData(), &payload); err != nil {
+		_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	dest := net.JoinHostPort(payload.DestAddr, strconv.Itoa(int(payload.DestPort)))
+	s.mu.Lock()
+	s.forwards = append(s.forwards, dest)
+	s.mu.Unlock()
+
+	conn, err := net.Dial("tcp", dest)
+	if err != nil {
+		_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	ch, reqs, err := newCh.Accept()
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	go func() {
+		_, _ = io.Copy(ch, conn)
+		_ = ch.Close()
+	}()
+	_, _ = io.Copy(conn, ch)
+	_ = conn.Close()
+}
+
+func serveSftp(newCh ssh.NewChannel) {
+	ch, reqs, err := newCh.Accept()
+	if err != nil {
+		return
+	}
+	defer ch.Close()
+	for req := range reqs {
+		ok := req.Type == "subsystem" && string(req.Payload[4:]) == "sftp"
+		_ = req.Reply(ok, nil)
+		if ok {
+			server, err := sftp.NewServer(ch)
+			if err != nil {
+				return
+			}
+			_ = server.Serve()
+			return
+		}
+	}
+}
+
+func splitHostPort(t *testing.T, addr string) (string, int) {
+	t.Helper()
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return host, p
+}
+
+func TestNewConnectionBastions(t *testing.T) {
+	target := newTestSSHServer(t, "secret")
+	first := newTestSSHServer(t, "jump")
+	second := newTestSSHServer(t, "secret")
+
+	targetHost, targetPort := splitHostPort(t, target.addr)
+	firstHost, firstPort := splitHostPort(t, first.addr)
+	secondHost, secondPort := splitHostPort(t, second.addr)
+	cfg := Cfg{
+		Username: "root",
+		Password: "secret",
+		Address:  targetHost,
+		Port:     targetPort,
+		Timeout:  5 * time.Second,
+		// the legacy bastion is the first hop, the second one uses the credentials of the host.
+		Bastion:     firstHost,
+		BastionPort: firstPort,
+		Bastions:    []Bastion{{Address: secondHost, Port: secondPort}},
+	}
+
+	if _, err := NewConnection(cfg); err == nil {
+		t.Fatal("the first bastion should reject the password of the host")
+	}
+
+	cfg.Bastions = []Bastion{{Address: firstHost, Port: firstPort, User: "jump", Password: "jump"}, {Address: secondHost, Port: secondPort}}
+	cfg.Bastion, cfg.BastionPort = "", 0
+	conn, err := NewConnection(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+
+	for _, tt := range []struct {
+		name            string
+		server          *testSSHServer
+		users, forwards []string
+	}{
+		{name: "first bastion", server: first, users: []string{"jump"}, forwards: []string{second.addr}},
+		{name: "second bastion", server: second, users: []string{"root"}, forwards: []string{target.addr}},
+		{name: "target", server: target, users: []string{"root"}},
+	} {
+		tt.server.mu.Lock()
+		if !reflect.DeepEqual(tt.server.users, tt.users) {
+			t.Errorf("%s: users = %v, want %v", tt.name, tt.server.users, tt.users)
+		}
+		if !reflect.DeepEqual(tt.server.forwards, tt.forwards) {
+			t.Errorf("%s: forwards = %v, want %v", tt.name, tt.server.forwards, tt.forwards)
+		}
+		tt.server.mu.Unlock()
+	}
+}
@@ -111,6 +111,28 @@ func (t *TaskResult) AppendErr(host connector.Host, err error) {
 	t.Status = FAILED
 }
 
+// FailedHosts returns the hosts which failed the task.
+func (t *TaskResult) FailedHosts() []connector.Host {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var hosts []connector.Host
+	for i := range t.ActionResults {
+		if t.ActionResults[i].Status == FAILED {
+			hosts = append(hosts, t.ActionResults[i].Host)
+		}
+	}
+	return hosts
+}
+
+// Tolerate marks a failed task as not failed, the results of the failed hosts are kept.
+func (t *TaskResult) Tolerate() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.Status == FAILED {
+		t.Status = NULL
+	}
+}
+
 func (t *TaskResult) IsFailed() bool {
 	t.mu.Lock()
 	defer t.mu.Unlock()
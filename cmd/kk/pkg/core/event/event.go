@@ -0,0 +1,76 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package event
+
+import (
+	"time"
+)
+
+const (
+	Pipeline = "pipeline"
+	Module   = "module"
+	Task     = "task"
+	Host     = "host"
+
+	Start  = "start"
+	Finish = "finish"
+)
+
+// Event is the start or the finish of a pipeline, module, task or the task on a host.
+type Event struct {
+	Time     time.Time `json:"time"`
+	Kind     string    `json:"kind"`
+	Phase    string    `json:"phase"`
+	Pipeline string    `json:"pipeline,omitempty"`
+	Module   string    `json:"module,omitempty"`
+	Task     string    `json:"task,omitempty"`
+	Host     string    `json:"host,omitempty"`
+	Status   string    `json:"status,omitempty"`
+	// Duration is in seconds, it is only set on the finish events.
+	Duration float64 `json:"duration,omitempty"`
+	Error    string  `json:"error,omitempty"`
+}
+
+// Sink receives the events of the pipelines.
+type Sink interface {
+	Emit(e Event)
+	Close() error
+}
+
+// Discard is a Sink which drops all the events.
+var Discard Sink = discard{}
+
+type discard struct{}
+
+func (discard) Emit(_ Event) {}
+
+func (discard) Close() error { return nil }
+
+// Finished returns a finish event of the kind with the status, duration and error filled.
+func Finished(kind, status string, start, end time.Time, err error) Event {
+	e := Event{
+		Time:     end,
+		Kind:     kind,
+		Phase:    Finish,
+		Status:   status,
+		Duration: end.Sub(start).Seconds(),
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	return e
+}
@@ -0,0 +1,157 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package event
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
+)
+
+const (
+	stdoutTarget = "stdout"
+	fileScheme   = "file://"
+	unixScheme   = "unix://"
+)
+
+// NewSink returns a Sink which writes the events as JSON lines to the target. The target is "stdout",
+// "unix://<path>" for a unix socket, or "file://<path>" and a plain path for a file which is appended.
+// The target is opened again by the first event after the sink is closed, e.g. by the next pipeline.
+func NewSink(target string) (Sink, error) {
+	var open func() (io.WriteCloser, error)
+	switch {
+	case target == stdoutTarget:
+		open = func() (io.WriteCloser, error) {
+			return nopCloser{os.Stdout}, nil
+		}
+	case strings.HasPrefix(target, unixScheme):
+		path := strings.TrimPrefix(target, unixScheme)
+		open = func() (io.WriteCloser, error) {
+			conn, err := net.Dial("unix", path)
+			if err != nil {
+				return nil, errors.Wrapf(err, "connect to the event socket %s failed", path)
+			}
+			return conn, nil
+		}
+	default:
+		path := strings.TrimPrefix(target, fileScheme)
+		if path == "" {
+			return nil, errors.Errorf("invalid event target [%s]", target)
+		}
+		open = func() (io.WriteCloser, error) {
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+			if err != nil {
+				return nil, errors.Wrapf(err, "open the event file %s failed", path)
+			}
+			return f, nil
+		}
+	}
+
+	w, err := open()
+	if err != nil {
+		return nil, err
+	}
+	return &JSONSink{w: w, open: open}, nil
+}
+
+// JSONSink writes one JSON object per line. The pipeline and module of an event are filled from the
+// latest start events when they are empty, because the tasks do not know which pipeline they belong to.
+type JSONSink struct {
+	mu       sync.Mutex
+	w        io.WriteCloser
+	open     func() (io.WriteCloser, error)
+	err      error
+	pipeline string
+	module   string
+}
+
+// NewJSONSink returns a JSONSink writing to w, the events emitted after it is closed are dropped.
+func NewJSONSink(w io.WriteCloser) *JSONSink {
+	return &JSONSink{w: w}
+}
+
+func (s *JSONSink) Emit(e Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	switch {
+	case e.Kind == Pipeline && e.Phase == Start:
+		s.pipeline, s.module = e.Pipeline, ""
+	case e.Kind == Module && e.Phase == Start:
+		s.module = e.Module
+	}
+	if e.Pipeline == "" {
+		e.Pipeline = s.pipeline
+	}
+	if e.Module == "" && e.Kind != Pipeline {
+		e.Module = s.module
+	}
+
+	// stop writing after the first failure, a broken socket must not break the pipeline.
+	if s.err != nil {
+		return
+	}
+	if s.w == nil {
+		if s.open == nil {
+			return
+		}
+		w, err := s.open()
+		if err != nil {
+			s.err = err
+			logger.Log.Warnf("reopen the pipeline event target failed, no more events are written: %v", err)
+			return
+		}
+		s.w = w
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		s.err = err
+		return
+	}
+	if _, err := s.w.Write(append(line, '\n')); err != nil {
+		s.err = err
+		logger.Log.Warnf("write pipeline event failed, no more events are written: %v", err)
+	}
+}
+
+func (s *JSONSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.w == nil {
+		return nil
+	}
+	err := s.w.Close()
+	s.w = nil
+	return err
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
@@ -0,0 +1,119 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package event
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink, err := NewSink("file://" + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	sink.Emit(Event{Kind: Pipeline, Phase: Start, Pipeline: "CreateClusterPipeline"})
+	sink.Emit(Event{Kind: Module, Phase: Start, Module: "GreetingsModule"})
+	sink.Emit(Event{Kind: Host, Phase: Start, Task: "Greeting", Host: "node1"})
+	failed := Finished(Host, "failed", start, start.Add(1500*time.Millisecond), errors.New("connection refused"))
+	failed.Task, failed.Host = "Greeting", "node1"
+	sink.Emit(failed)
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		events = append(events, e)
+	}
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want 4", len(events))
+	}
+
+	for _, e := range events {
+		if e.Time.IsZero() {
+			t.Errorf("event %+v has no time", e)
+		}
+		if e.Pipeline != "CreateClusterPipeline" {
+			t.Errorf("event %+v has pipeline %q, want CreateClusterPipeline", e, e.Pipeline)
+		}
+	}
+	if events[0].Module != "" {
+		t.Errorf("pipeline event has module %q", events[0].Module)
+	}
+	got := events[3]
+	if got.Module != "GreetingsModule" || got.Phase != Finish || got.Status != "failed" ||
+		got.Duration != 1.5 || got.Error != "connection refused" {
+		t.Errorf("unexpected host finish event %+v", got)
+	}
+}
+
+func TestNewSinkInvalidTarget(t *testing.T) {
+	if _, err := NewSink("unix://" + filepath.Join(t.TempDir(), "missing.sock")); err == nil {
+		t.Error("connecting to a missing socket should fail")
+	}
+	if _, err := NewSink("file://"); err == nil {
+		t.Error("an empty file path should be rejected")
+	}
+}
+
+func TestFileSinkReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink, err := NewSink(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// every pipeline closes the sink when it finishes, the next one still writes its events.
+	for _, name := range []string{"ApplyPlanPipeline", "ApplyPipeline"} {
+		sink.Emit(Event{Kind: Pipeline, Phase: Start, Pipeline: name})
+		if err := sink.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Errorf("closing a closed sink: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(content), "\n"); lines != 2 {
+		t.Errorf("got %d events, want 2", lines)
+	}
+}
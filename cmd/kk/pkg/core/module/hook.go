@@ -17,7 +17,11 @@
 package module
 
 import (
+	"time"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/ending"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/event"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/hook"
 )
 
@@ -46,3 +50,30 @@ func (p *PostHook) Init(module Module, result *ending.ModuleResult) {
 	p.Module = module
 	p.Result = result
 }
+
+// EventPostHook emits the finish event of the module to the event sink of the runtime, the finish events of
+// the hosts are emitted by the tasks.
+type EventPostHook struct {
+	PostHook
+}
+
+func (e *EventPostHook) Try() error {
+	b, ok := e.Module.(*BaseModule)
+	if !ok {
+		return nil
+	}
+	runtime, ok := b.Runtime.(connector.Runtime)
+	if !ok {
+		return nil
+	}
+
+	res := e.Result
+	end := res.EndTime
+	if end.IsZero() {
+		end = time.Now()
+	}
+	finished := event.Finished(event.Module, res.Status.String(), res.StartTime, end, res.CombineResult)
+	finished.Module = b.Name
+	runtime.GetEventSink().Emit(finished)
+	return nil
+}
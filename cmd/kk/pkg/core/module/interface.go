@@ -23,6 +23,7 @@ import (
 )
 
 type Module interface {
+	GetName() string
 	IsSkip() bool
 	Default(runtime connector.Runtime, pipelineCache *cache.Cache, moduleCache *cache.Cache)
 	Init()
@@ -34,3 +35,9 @@ type Module interface {
 	AppendPostHook(h PostHookInterface)
 	CallPostHook(result *ending.ModuleResult) error
 }
+
+// Repeatable is implemented by the modules which collect state into the pipeline or host cache
+// for the modules after them. They are executed again even if a resumed pipeline has finished them.
+type Repeatable interface {
+	Repeatable() bool
+}
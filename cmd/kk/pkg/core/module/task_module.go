@@ -21,6 +21,7 @@ import (
 
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/ending"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/event"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/task"
 )
@@ -46,12 +47,18 @@ func (b *BaseTaskModule) Run(result *ending.ModuleResult) {
 		t.Init(b.Runtime.(connector.Runtime), b.ModuleCache, b.PipelineCache)
 
 		logger.Log.Infof("[%s] %s", b.Name, t.GetDesc())
+		sink := b.Runtime.(connector.Runtime).GetEventSink()
+		sink.Emit(event.Event{Kind: event.Task, Phase: event.Start, Module: b.Name, Task: t.GetName()})
 		res := t.Execute()
 		for j := range res.ActionResults {
 			ac := res.ActionResults[j]
 			logger.Log.Infof("%s: [%s]", ac.Status.String(), ac.Host.GetName())
 			result.AppendHostResult(ac)
 
+			e := event.Finished(event.Host, ac.Status.String(), ac.StartTime, ac.EndTime, ac.Error)
+			e.Module, e.Task, e.Host = b.Name, t.GetName(), ac.Host.GetName()
+			sink.Emit(e)
+
 			if _, ok := t.(*task.RemoteTask); ok {
 				if b.Runtime.GetIgnoreErr() {
 					if len(b.Runtime.GetAllHosts()) > 0 {
@@ -67,6 +74,13 @@ func (b *BaseTaskModule) Run(result *ending.ModuleResult) {
 			}
 		}
 
+		e := event.Finished(event.Task, res.Status.String(), res.StartTime, res.EndTime, nil)
+		if res.IsFailed() {
+			e.Error = res.CombineErr().Error()
+		}
+		e.Module, e.Task = b.Name, t.GetName()
+		sink.Emit(e)
+
 		if res.IsFailed() {
 			t.ExecuteRollback()
 			result.ErrResult(errors.Wrapf(res.CombineErr(), "Module[%s] exec failed", b.Name))
@@ -0,0 +1,180 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipeline
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/ending"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/module"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
+)
+
+const JournalDir = "journal"
+
+// Journal is the on-disk checkpoint of a pipeline. It records every finished module
+// and its per-host results, so that a failed pipeline can be resumed.
+type Journal struct {
+	Pipeline   string         `json:"pipeline"`
+	ConfigHash string         `json:"configHash"`
+	Modules    []ModuleRecord `json:"modules"`
+	path       string
+}
+
+type ModuleRecord struct {
+	Index     int          `json:"index"`
+	Name      string       `json:"name"`
+	Status    string       `json:"status"`
+	Error     string       `json:"error,omitempty"`
+	StartTime time.Time    `json:"startTime"`
+	EndTime   time.Time    `json:"endTime"`
+	Hosts     []HostRecord `json:"hosts,omitempty"`
+}
+
+type HostRecord struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// JournalPath returns the journal file of the named pipeline under the work dir.
+func JournalPath(workDir, pipelineName string) string {
+	return filepath.Join(workDir, JournalDir, pipelineName+".json")
+}
+
+func NewJournal(path, pipelineName, configHash string) *Journal {
+	return &Journal{
+		Pipeline:   pipelineName,
+		ConfigHash: configHash,
+		Modules:    make([]ModuleRecord, 0),
+		path:       path,
+	}
+}
+
+// LoadJournal reads the journal from the given path. It returns nil without error if the journal does not exist.
+func LoadJournal(path string) (*Journal, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "read journal %s failed", path)
+	}
+
+	j := &Journal{}
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal journal %s failed", path)
+	}
+	j.path = path
+	return j, nil
+}
+
+// Matches reports whether the journal was written by the same pipeline with the same cluster config.
+func (j *Journal) Matches(pipelineName, configHash string) bool {
+	return j.Pipeline == pipelineName && j.ConfigHash == configHash
+}
+
+// Finished reports whether the module at the given index has been executed successfully.
+func (j *Journal) Finished(index int, m module.Module) bool {
+	for i := range j.Modules {
+		r := j.Modules[i]
+		if r.Index == index && r.Name == ModuleName(m) {
+			return r.Status == ending.SUCCESS.String()
+		}
+	}
+	return false
+}
+
+// Record adds the result of the module at the given index to the journal, replacing the previous one.
+func (j *Journal) Record(index int, m module.Module, result *ending.ModuleResult) {
+	r := ModuleRecord{
+		Index:     index,
+		Name:      ModuleName(m),
+		Status:    result.Status.String(),
+		StartTime: result.StartTime,
+		EndTime:   result.EndTime,
+	}
+	if result.CombineResult != nil {
+		r.Error = result.CombineResult.Error()
+	}
+	for name, h := range result.HostResults {
+		hr := HostRecord{
+			Name:      name,
+			Status:    h.GetStatus().String(),
+			StartTime: h.GetStartTime(),
+			EndTime:   h.GetEndTime(),
+		}
+		if h.GetErr() != nil {
+			hr.Error = h.GetErr().Error()
+		}
+		r.Hosts = append(r.Hosts, hr)
+	}
+	sort.Slice(r.Hosts, func(a, b int) bool { return r.Hosts[a].Name < r.Hosts[b].Name })
+
+	for i := range j.Modules {
+		if j.Modules[i].Index == index {
+			j.Modules[i] = r
+			return
+		}
+	}
+	j.Modules = append(j.Modules, r)
+}
+
+// Save writes the journal atomically to its path, the journal without a path is not saved.
+func (j *Journal) Save() error {
+	if j.path == "" {
+		return nil
+	}
+	if err := util.CreateDir(filepath.Dir(j.path)); err != nil {
+		return errors.Wrap(err, "create journal dir failed")
+	}
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal journal failed")
+	}
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return errors.Wrapf(err, "write journal %s failed", tmp)
+	}
+	return os.Rename(tmp, j.path)
+}
+
+// Remove deletes the journal file.
+func (j *Journal) Remove() error {
+	if j.path == "" {
+		return nil
+	}
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "remove journal %s failed", j.path)
+	}
+	return nil
+}
+
+// ModuleName returns the type name of the module, e.g. "kubernetes.StatusModule".
+func ModuleName(m module.Module) string {
+	return strings.TrimPrefix(reflect.TypeOf(m).String(), "*")
+}
@@ -0,0 +1,84 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/ending"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/module"
+)
+
+type firstModule struct {
+	module.BaseTaskModule
+}
+
+type secondModule struct {
+	module.BaseTaskModule
+}
+
+func TestJournal_RecordAndLoad(t *testing.T) {
+	path := JournalPath(t.TempDir(), "TestPipeline")
+	j := NewJournal(path, "TestPipeline", "hash")
+
+	succeed := ending.NewModuleResult()
+	succeed.AppendHostResult(&ending.ActionResult{Host: &connector.BaseHost{Name: "node1"}, Status: ending.SUCCESS})
+	succeed.NormalResult()
+	j.Record(0, &firstModule{}, succeed)
+
+	failed := ending.NewModuleResult()
+	failed.AppendHostResult(&ending.ActionResult{Host: &connector.BaseHost{Name: "node1"}, Status: ending.FAILED, Error: errors.New("boom")})
+	failed.ErrResult(errors.New("boom"))
+	j.Record(1, &secondModule{}, failed)
+
+	if err := j.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadJournal(path)
+	if err != nil {
+		t.Fatalf("LoadJournal() error = %v", err)
+	}
+	if !loaded.Matches("TestPipeline", "hash") {
+		t.Errorf("Matches() got = false, want true")
+	}
+	if loaded.Matches("TestPipeline", "other") {
+		t.Errorf("Matches() with another config hash got = true, want false")
+	}
+	if !loaded.Finished(0, &firstModule{}) {
+		t.Errorf("Finished(0) got = false, want true")
+	}
+	if loaded.Finished(0, &secondModule{}) {
+		t.Errorf("Finished(0) with another module got = true, want false")
+	}
+	if loaded.Finished(1, &secondModule{}) {
+		t.Errorf("Finished(1) got = true, want false")
+	}
+	if got := loaded.Modules[1].Hosts[0].Error; got != "boom" {
+		t.Errorf("host error got = %s, want boom", got)
+	}
+
+	if err := loaded.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if j, err := LoadJournal(path); err != nil || j != nil {
+		t.Errorf("LoadJournal() after Remove() got = %v, %v, want nil, nil", j, err)
+	}
+}
@@ -20,12 +20,14 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/cache"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/ending"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/event"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/module"
 )
@@ -51,12 +53,19 @@ type Pipeline struct {
 	PipelineCache   *cache.Cache
 	ModuleCachePool sync.Pool
 	ModulePostHooks []module.PostHookInterface
+	// Resume skips the modules which have been finished by a previous run with the same ConfigHash.
+	Resume     bool
+	ConfigHash string
+	journal    *Journal
 }
 
 func (p *Pipeline) Init() error {
 	fmt.Print(logo)
 	p.PipelineCache = cache.NewCache()
 	p.SpecHosts = len(p.Runtime.GetAllHosts())
+	if err := p.initJournal(); err != nil {
+		return err
+	}
 	//if err := p.Runtime.GenerateWorkDir(); err != nil {
 	//	return err
 	//}
@@ -67,6 +76,25 @@ func (p *Pipeline) Init() error {
 }
 
 func (p *Pipeline) Start() error {
+	sink := p.Runtime.GetEventSink()
+	start := time.Now()
+	sink.Emit(event.Event{Time: start, Kind: event.Pipeline, Phase: event.Start, Pipeline: p.Name})
+
+	err := p.start()
+	status := ending.SUCCESS
+	if err != nil {
+		status = ending.FAILED
+	}
+	finished := event.Finished(event.Pipeline, status.String(), start, time.Now(), err)
+	finished.Pipeline = p.Name
+	sink.Emit(finished)
+	if closeErr := sink.Close(); closeErr != nil {
+		logger.Log.Warnf("Pipeline[%s] close the event sink failed: %v", p.Name, closeErr)
+	}
+	return err
+}
+
+func (p *Pipeline) start() error {
 	if err := p.Init(); err != nil {
 		return errors.Wrapf(err, "Pipeline[%s] execute failed", p.Name)
 	}
@@ -75,17 +103,29 @@ func (p *Pipeline) Start() error {
 		if m.IsSkip() {
 			continue
 		}
+		if p.Resume && p.journal.Finished(i, m) {
+			if r, ok := m.(module.Repeatable); !ok || !r.Repeatable() {
+				logger.Log.Infof("Module[%s] has been finished in the previous run, skip it", ModuleName(m))
+				continue
+			}
+		}
 
 		moduleCache := p.newModuleCache()
 		m.Default(p.Runtime, p.PipelineCache, moduleCache)
 		m.AutoAssert()
 		m.Init()
+		m.AppendPostHook(new(module.EventPostHook))
 		for j := range p.ModulePostHooks {
 			m.AppendPostHook(p.ModulePostHooks[j])
 		}
+		p.Runtime.GetEventSink().Emit(event.Event{Kind: event.Module, Phase: event.Start, Module: m.GetName()})
 
 		res := p.RunModule(m)
 		err := m.CallPostHook(res)
+		p.journal.Record(i, m, res)
+		if saveErr := p.journal.Save(); saveErr != nil {
+			logger.Log.Warnf("Pipeline[%s] save journal failed: %v", p.Name, saveErr)
+		}
 		if res.IsFailed() {
 			return errors.Wrapf(res.CombineResult, "Pipeline[%s] execute failed", p.Name)
 		}
@@ -104,10 +144,38 @@ func (p *Pipeline) Start() error {
 	if p.SpecHosts != len(p.Runtime.GetAllHosts()) {
 		return errors.Errorf("Pipeline[%s] execute failed: there are some error in your spec hosts", p.Name)
 	}
+	if err := p.journal.Remove(); err != nil {
+		logger.Log.Warnf("Pipeline[%s] remove journal failed: %v", p.Name, err)
+	}
 	logger.Log.Infof("Pipeline[%s] execute successfully", p.Name)
 	return nil
 }
 
+func (p *Pipeline) initJournal() error {
+	if _, ok := p.Runtime.GetConnector().(*connector.Recorder); ok {
+		// the modules finished in dry-run mode must not be skipped by a later run.
+		p.journal = NewJournal("", p.Name, p.ConfigHash)
+		return nil
+	}
+
+	path := JournalPath(p.Runtime.GetWorkDir(), p.Name)
+	if p.Resume {
+		j, err := LoadJournal(path)
+		if err != nil {
+			return err
+		}
+		if j != nil && j.Matches(p.Name, p.ConfigHash) {
+			p.journal = j
+			return nil
+		}
+		if j != nil {
+			logger.Log.Warnf("Pipeline[%s] the cluster config has changed since the previous run, start from the beginning", p.Name)
+		}
+	}
+	p.journal = NewJournal(path, p.Name, p.ConfigHash)
+	return nil
+}
+
 func (p *Pipeline) RunModule(m module.Module) *ending.ModuleResult {
 	m.Slogan()
 
@@ -0,0 +1,178 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/action"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/ending"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/event"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/module"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/task"
+)
+
+const binariesKey = "KubeBinaries-amd64"
+
+type nopConnector struct{}
+
+func (n *nopConnector) Connect(host connector.Host) (connector.Connection, error) {
+	return connector.NewRecorder().Connect(host)
+}
+
+func (n *nopConnector) Close(_ connector.Host) {}
+
+type setBinaries struct {
+	action.BaseAction
+}
+
+func (s *setBinaries) Execute(_ connector.Runtime) error {
+	s.PipelineCache.Set(binariesKey, "binaries")
+	return nil
+}
+
+type getBinaries struct {
+	action.BaseAction
+}
+
+func (g *getBinaries) Execute(_ connector.Runtime) error {
+	if _, ok := g.PipelineCache.Get(binariesKey); !ok {
+		return errors.New("get KubeBinary by pipeline cache failed")
+	}
+	return nil
+}
+
+// producerModule sets the pipeline cache like the binaries modules.
+type producerModule struct {
+	module.BaseTaskModule
+	repeatable bool
+}
+
+func (p *producerModule) Repeatable() bool {
+	return p.repeatable
+}
+
+func (p *producerModule) Init() {
+	p.Name = "ProducerModule"
+	p.Tasks = []task.Interface{&task.LocalTask{Name: "SetBinaries", Action: new(setBinaries)}}
+}
+
+type consumerModule struct {
+	module.BaseTaskModule
+}
+
+func (c *consumerModule) Init() {
+	c.Name = "ConsumerModule"
+	c.Tasks = []task.Interface{&task.LocalTask{Name: "GetBinaries", Action: new(getBinaries)}}
+}
+
+type recordSink struct {
+	events []event.Event
+	closed bool
+}
+
+func (r *recordSink) Emit(e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *recordSink) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestPipeline_StartClosesEventSink(t *testing.T) {
+	logger.Log = logger.NewLogger(t.TempDir(), false)
+
+	runtime := connector.NewBaseRuntime("test", &nopConnector{}, false, false)
+	sink := &recordSink{}
+	runtime.SetEventSink(sink)
+	p := &Pipeline{
+		Name:    "TestEventPipeline",
+		Modules: []module.Module{&producerModule{}},
+		Runtime: &runtime,
+	}
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if !sink.closed {
+		t.Error("the event sink is not closed when the pipeline finishes")
+	}
+	if last := sink.events[len(sink.events)-1]; last.Kind != event.Pipeline || last.Phase != event.Finish {
+		t.Errorf("the last event is %+v, want the finish of the pipeline", last)
+	}
+}
+
+func TestPipeline_StartResume(t *testing.T) {
+	logger.Log = logger.NewLogger(t.TempDir(), false)
+
+	tests := []struct {
+		name       string
+		repeatable bool
+		wantErr    string
+	}{
+		{
+			name:       "a repeatable producer is executed again",
+			repeatable: true,
+		},
+		{
+			name:    "a skipped producer leaves the cache empty",
+			wantErr: "get KubeBinary by pipeline cache failed",
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtime := connector.NewBaseRuntime("test", &nopConnector{}, false, false)
+			producer := &producerModule{repeatable: tt.repeatable}
+			p := &Pipeline{
+				Name:       fmt.Sprintf("TestResumePipeline%d", i),
+				Modules:    []module.Module{producer, &consumerModule{}},
+				Runtime:    &runtime,
+				Resume:     true,
+				ConfigHash: "hash",
+			}
+
+			// the previous run finished the producer and failed in the consumer.
+			j := NewJournal(JournalPath(runtime.GetWorkDir(), p.Name), p.Name, p.ConfigHash)
+			succeed := ending.NewModuleResult()
+			succeed.NormalResult()
+			j.Record(0, producer, succeed)
+			if err := j.Save(); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			defer func() {
+				_ = j.Remove()
+			}()
+
+			err := p.Start()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Start() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Start() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
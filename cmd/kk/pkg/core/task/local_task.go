@@ -28,6 +28,7 @@ import (
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/common"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/ending"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/event"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/prepare"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/rollback"
@@ -52,6 +53,10 @@ type LocalTask struct {
 	TaskResult    *ending.TaskResult
 }
 
+func (l *LocalTask) GetName() string {
+	return l.Name
+}
+
 func (l *LocalTask) GetDesc() string {
 	return l.Desc
 }
@@ -136,6 +141,8 @@ func (l *LocalTask) Run(runtime connector.Runtime, host connector.Host, resCh ch
 		close(resCh)
 	}()
 
+	runtime.GetEventSink().Emit(event.Event{Kind: event.Host, Phase: event.Start, Task: l.Name, Host: host.GetName()})
+
 	runtime.SetRunner(&connector.Runner{
 		Conn: nil,
 		//Debug: runtime.Arg.Debug,
@@ -157,8 +164,14 @@ func (l *LocalTask) Run(runtime connector.Runtime, host connector.Host, resCh ch
 	l.Action.Init(l.ModuleCache, l.PipelineCache)
 	l.Action.AutoAssert(runtime)
 	if err := l.ExecuteWithRetry(runtime, host); err != nil {
-		res = err
-		return
+		recorder, ok := runtime.GetConnector().(*connector.Recorder)
+		if !ok {
+			res = err
+			return
+		}
+		// the local tasks may depend on the output of the remote commands, which is empty in dry-run mode.
+		logger.Log.Warnf("[%s] failed in dry-run mode: %v", l.Name, err)
+		recorder.Note(host.GetName(), fmt.Sprintf("%s failed in dry-run mode: %v", l.Name, err))
 	}
 	l.TaskResult.AppendSuccess(host)
 }
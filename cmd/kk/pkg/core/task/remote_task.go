@@ -23,11 +23,14 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/action"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/cache"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/common"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/ending"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/event"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/prepare"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/rollback"
@@ -46,6 +49,10 @@ type RemoteTask struct {
 	Delay       time.Duration
 	Timeout     time.Duration
 	Concurrency float64
+	// BatchSize runs the hosts in waves, it is a number or a percentage of the hosts.
+	BatchSize *intstr.IntOrString
+	// MaxFailures is the number or the percentage of the hosts which may fail before the task fails.
+	MaxFailures *intstr.IntOrString
 
 	PipelineCache *cache.Cache
 	ModuleCache   *cache.Cache
@@ -54,6 +61,10 @@ type RemoteTask struct {
 	TaskResult    *ending.TaskResult
 }
 
+func (t *RemoteTask) GetName() string {
+	return t.Name
+}
+
 func (t *RemoteTask) GetDesc() string {
 	return t.Desc
 }
@@ -70,26 +81,54 @@ func (t *RemoteTask) Execute() *ending.TaskResult {
 		return t.TaskResult
 	}
 
-	routinePool := make(chan struct{}, DefaultCon)
+	routinePool := make(chan struct{}, t.poolSize())
 	defer close(routinePool)
 
-	ctx, cancel := context.WithTimeout(context.Background(), t.Timeout)
-	defer cancel()
-	wg := &sync.WaitGroup{}
+	var hosts []int
 	for i := range t.Hosts {
 		if t.Hosts[i] == nil || t.Runtime.HostIsDeprecated(t.Hosts[i]) {
 			continue
 		}
-		selfRuntime := t.Runtime.Copy()
+		hosts = append(hosts, i)
+	}
 
-		wg.Add(1)
-		if t.Parallel {
-			go t.RunWithTimeout(ctx, selfRuntime, t.Hosts[i], i, wg, routinePool)
+	batchSize, maxFailures, err := t.calculateBatch(len(hosts))
+	if err != nil {
+		t.TaskResult.AppendErr(&connector.BaseHost{Name: common.LocalHost}, err)
+		t.TaskResult.ErrResult()
+		return t.TaskResult
+	}
+
+	for start := 0; start < len(hosts); start += batchSize {
+		end := start + batchSize
+		if end > len(hosts) {
+			end = len(hosts)
+		}
+		if batchSize < len(hosts) {
+			logger.Log.Infof("[%s] batch: hosts %d-%d of %d", t.Name, start+1, end, len(hosts))
+		}
+		t.runBatch(hosts[start:end], routinePool)
+
+		failed := t.TaskResult.FailedHosts()
+		if critical, _ := t.criticalHost(failed); len(failed) > maxFailures || critical != nil {
+			if end < len(hosts) {
+				logger.Log.Errorf("[%s] %d hosts failed, the remaining hosts are not started", t.Name, len(failed))
+			}
+			break
+		}
+	}
+
+	if failed := t.TaskResult.FailedHosts(); len(failed) != 0 && len(failed) <= maxFailures {
+		if critical, role := t.criticalHost(failed); critical != nil {
+			logger.Log.Errorf("[%s] host %s failed, the failures of the %s hosts are not allowed", t.Name, critical.GetName(), role)
 		} else {
-			t.RunWithTimeout(ctx, selfRuntime, t.Hosts[i], i, wg, routinePool)
+			for _, host := range failed {
+				logger.Log.Warnf("[%s] host %s failed and is removed from the pipeline, %d failures are allowed", t.Name, host.GetName(), maxFailures)
+				t.Runtime.DeleteHost(host)
+			}
+			t.TaskResult.Tolerate()
 		}
 	}
-	wg.Wait()
 
 	if t.TaskResult.IsFailed() {
 		t.TaskResult.ErrResult()
@@ -100,6 +139,24 @@ func (t *RemoteTask) Execute() *ending.TaskResult {
 	return t.TaskResult
 }
 
+// runBatch runs the task on the hosts of the indexes, the timeout of the task applies to each batch.
+func (t *RemoteTask) runBatch(indexes []int, routinePool chan struct{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), t.Timeout)
+	defer cancel()
+	wg := &sync.WaitGroup{}
+	for _, i := range indexes {
+		selfRuntime := t.Runtime.Copy()
+
+		wg.Add(1)
+		if t.Parallel {
+			go t.RunWithTimeout(ctx, selfRuntime, t.Hosts[i], i, wg, routinePool)
+		} else {
+			t.RunWithTimeout(ctx, selfRuntime, t.Hosts[i], i, wg, routinePool)
+		}
+	}
+	wg.Wait()
+}
+
 func (t *RemoteTask) RunWithTimeout(ctx context.Context, runtime connector.Runtime, host connector.Host, index int,
 	wg *sync.WaitGroup, pool chan struct{}) {
 
@@ -130,11 +187,18 @@ func (t *RemoteTask) Run(runtime connector.Runtime, host connector.Host, index i
 		close(resCh)
 	}()
 
+	runtime.GetEventSink().Emit(event.Event{Kind: event.Host, Phase: event.Start, Task: t.Name, Host: host.GetName()})
+
 	if err := t.ConfigureSelfRuntime(runtime, host, index); err != nil {
 		res = err
 		return
 	}
 
+	if recorder, ok := runtime.GetConnector().(*connector.Recorder); ok {
+		t.DryRun(runtime, host, recorder)
+		return
+	}
+
 	t.Prepare.Init(t.ModuleCache, t.PipelineCache)
 	t.Prepare.AutoAssert(runtime)
 	if ok, err := t.WhenWithRetry(runtime); !ok {
@@ -158,6 +222,40 @@ func (t *RemoteTask) Run(runtime connector.Runtime, host connector.Host, index i
 	return
 }
 
+// DryRun runs the task once and records its remote operations. The failures are recorded as notes instead of
+// failing the pipeline, because the recorded commands have no output.
+func (t *RemoteTask) DryRun(runtime connector.Runtime, host connector.Host, recorder *connector.Recorder) {
+	name := t.Name
+	if t.Desc != "" {
+		name = fmt.Sprintf("%s (%s)", t.Name, t.Desc)
+	}
+	recorder.Begin(host.GetName(), name)
+
+	defer func() {
+		if r := recover(); r != nil {
+			recorder.Note(host.GetName(), fmt.Sprintf("the task panicked in dry-run mode: %v", r))
+			t.TaskResult.AppendSuccess(host)
+		}
+	}()
+
+	t.Prepare.Init(t.ModuleCache, t.PipelineCache)
+	t.Prepare.AutoAssert(runtime)
+	if ok, err := t.When(runtime); err != nil {
+		recorder.Note(host.GetName(), fmt.Sprintf("the prepare failed in dry-run mode: %v", err))
+	} else if !ok {
+		recorder.Note(host.GetName(), "the task is skipped")
+		t.TaskResult.AppendSkip(host)
+		return
+	}
+
+	t.Action.Init(t.ModuleCache, t.PipelineCache)
+	t.Action.AutoAssert(runtime)
+	if err := t.Action.Execute(runtime); err != nil {
+		recorder.Note(host.GetName(), fmt.Sprintf("the task failed in dry-run mode: %v", err))
+	}
+	t.TaskResult.AppendSuccess(host)
+}
+
 func (t *RemoteTask) ConfigureSelfRuntime(runtime connector.Runtime, host connector.Host, index int) error {
 	conn, err := runtime.GetConnector().Connect(host)
 	if err != nil {
@@ -242,7 +340,7 @@ func (t *RemoteTask) ExecuteRollback() {
 
 	ctx, cancel := context.WithTimeout(context.Background(), t.Timeout)
 	defer cancel()
-	routinePool := make(chan struct{}, DefaultCon)
+	routinePool := make(chan struct{}, t.poolSize())
 	defer close(routinePool)
 
 	rwg := &sync.WaitGroup{}
@@ -252,12 +350,12 @@ func (t *RemoteTask) ExecuteRollback() {
 		}
 		selfRuntime := t.Runtime.Copy()
 
+		rwg.Add(1)
 		if t.Parallel {
 			go t.RollbackWithTimeout(ctx, selfRuntime, ar.Host, i, ar, rwg, routinePool)
 		} else {
 			t.RollbackWithTimeout(ctx, selfRuntime, ar.Host, i, ar, rwg, routinePool)
 		}
-		rwg.Add(1)
 	}
 	rwg.Wait()
 }
@@ -345,3 +443,63 @@ func (t *RemoteTask) calculateConcurrency() int {
 	}
 	return res
 }
+
+// poolSize returns the max number of hosts running the task at the same time. It is the Concurrency of the task,
+// limited by the concurrency of the runtime.
+func (t *RemoteTask) poolSize() int {
+	limit := DefaultCon
+	if c := t.Runtime.GetRollout().Concurrency; c > 0 {
+		limit = c
+	}
+	if res := t.calculateConcurrency(); res < limit {
+		return res
+	}
+	return limit
+}
+
+// criticalHost returns the first of the failed hosts with a critical role and its role, its failure is never tolerated.
+func (t *RemoteTask) criticalHost(failed []connector.Host) (connector.Host, string) {
+	for _, host := range failed {
+		for _, role := range t.Runtime.GetRollout().CriticalRoles {
+			if host.IsRole(role) {
+				return host, role
+			}
+		}
+	}
+	return nil, ""
+}
+
+// calculateBatch returns the number of hosts of a batch and the number of hosts which may fail. The BatchSize and
+// MaxFailures of the task take precedence over the ones of the runtime.
+func (t *RemoteTask) calculateBatch(hosts int) (int, int, error) {
+	rollout := t.Runtime.GetRollout()
+	batchSize, maxFailures := rollout.BatchSize, rollout.MaxFailures
+	if t.BatchSize != nil {
+		batchSize = t.BatchSize
+	}
+	if t.MaxFailures != nil {
+		maxFailures = t.MaxFailures
+	}
+
+	size := hosts
+	if batchSize != nil {
+		n, err := intstr.GetScaledValueFromIntOrPercent(batchSize, hosts, true)
+		if err != nil {
+			return 0, 0, errors.Wrapf(err, "invalid batch size of the task %s", t.Name)
+		}
+		size = n
+	}
+	if size < 1 {
+		size = 1
+	}
+
+	failures := 0
+	if maxFailures != nil {
+		n, err := intstr.GetScaledValueFromIntOrPercent(maxFailures, hosts, false)
+		if err != nil {
+			return 0, 0, errors.Wrapf(err, "invalid max failures of the task %s", t.Name)
+		}
+		failures = n
+	}
+	return size, failures, nil
+}
@@ -17,9 +17,18 @@
 package task
 
 import (
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/action"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/ending"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/rollback"
 )
 
 func TestTask_calculateConcurrency(t1 *testing.T) {
@@ -139,3 +148,166 @@ func TestTask_calculateConcurrency(t1 *testing.T) {
 		})
 	}
 }
+
+// failConnector fails to connect to the hosts in fail and records the connected hosts.
+type failConnector struct {
+	mu        sync.Mutex
+	fail      map[string]bool
+	connected []string
+}
+
+func (f *failConnector) Connect(host connector.Host) (connector.Connection, error) {
+	f.mu.Lock()
+	f.connected = append(f.connected, host.GetName())
+	f.mu.Unlock()
+	if f.fail[host.GetName()] {
+		return nil, fmt.Errorf("connection refused")
+	}
+	return connector.NewRecorder().Connect(host)
+}
+
+func (f *failConnector) Close(_ connector.Host) {}
+
+func TestRemoteTask_ExecuteBatch(t1 *testing.T) {
+	logger.Log = logger.NewLogger(t1.TempDir(), false)
+
+	tests := []struct {
+		name          string
+		fail          []string
+		maxFailures   intstr.IntOrString
+		wantFailed    bool
+		wantConnected int
+		wantHosts     int
+	}{
+		{
+			name:          "all succeed",
+			maxFailures:   intstr.FromInt(0),
+			wantConnected: 10,
+			wantHosts:     10,
+		},
+		{
+			name:          "failures within the threshold",
+			fail:          []string{"node3"},
+			maxFailures:   intstr.FromString("10%"),
+			wantConnected: 10,
+			wantHosts:     9,
+		},
+		{
+			name:          "failures beyond the threshold stop the next batches",
+			fail:          []string{"node1", "node2"},
+			maxFailures:   intstr.FromString("10%"),
+			wantFailed:    true,
+			wantConnected: 2,
+			wantHosts:     10,
+		},
+		{
+			name:          "the failure of a critical host is not tolerated",
+			fail:          []string{"node1"},
+			maxFailures:   intstr.FromString("10%"),
+			wantFailed:    true,
+			wantConnected: 2,
+			wantHosts:     10,
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			conn := &failConnector{fail: make(map[string]bool)}
+			for _, name := range tt.fail {
+				conn.fail[name] = true
+			}
+
+			runtime := connector.NewBaseRuntime("test", conn, false, false)
+			var hosts []connector.Host
+			for i := 1; i <= 10; i++ {
+				host := connector.NewHost()
+				host.SetName(fmt.Sprintf("node%d", i))
+				host.SetRole("worker")
+				if i == 1 {
+					host.SetRole("master")
+				}
+				runtime.AppendHost(host)
+				hosts = append(hosts, host)
+			}
+			batchSize := intstr.FromString("20%")
+			runtime.SetRollout(connector.Rollout{BatchSize: &batchSize, MaxFailures: &tt.maxFailures, CriticalRoles: []string{"master"}})
+
+			t := &RemoteTask{
+				Name:     "test",
+				Hosts:    hosts,
+				Action:   new(action.BaseAction),
+				Parallel: true,
+			}
+			t.Init(&runtime, nil, nil)
+			res := t.Execute()
+
+			if res.IsFailed() != tt.wantFailed {
+				t1.Errorf("IsFailed() = %v, want %v: %v", res.IsFailed(), tt.wantFailed, res.CombineErr())
+			}
+			if len(conn.connected) != tt.wantConnected {
+				t1.Errorf("connected to %v, want %d hosts", conn.connected, tt.wantConnected)
+			}
+			if got := len(runtime.GetAllHosts()); got != tt.wantHosts {
+				t1.Errorf("%d hosts left in the runtime, want %d", got, tt.wantHosts)
+			}
+		})
+	}
+}
+
+type failAction struct {
+	action.BaseAction
+}
+
+func (f *failAction) Execute(_ connector.Runtime) error {
+	return fmt.Errorf("failed")
+}
+
+// recordRollback records the hosts it is executed on.
+type recordRollback struct {
+	rollback.BaseRollback
+	mu    sync.Mutex
+	hosts []string
+}
+
+func (r *recordRollback) Execute(runtime connector.Runtime, _ *ending.ActionResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hosts = append(r.hosts, runtime.RemoteHost().GetName())
+	return nil
+}
+
+func TestRemoteTask_ExecuteRollback(t1 *testing.T) {
+	logger.Log = logger.NewLogger(t1.TempDir(), false)
+
+	for _, parallel := range []bool{false, true} {
+		t1.Run(fmt.Sprintf("parallel=%v", parallel), func(t1 *testing.T) {
+			runtime := connector.NewBaseRuntime("test", &failConnector{fail: make(map[string]bool)}, false, false)
+			var hosts []connector.Host
+			for i := 1; i <= 3; i++ {
+				host := connector.NewHost()
+				host.SetName(fmt.Sprintf("node%d", i))
+				runtime.AppendHost(host)
+				hosts = append(hosts, host)
+			}
+
+			r := new(recordRollback)
+			t := &RemoteTask{
+				Name:     "test",
+				Hosts:    hosts,
+				Action:   new(failAction),
+				Rollback: r,
+				Parallel: parallel,
+				Retry:    1,
+				Delay:    time.Millisecond,
+			}
+			t.Init(&runtime, nil, nil)
+			if res := t.Execute(); !res.IsFailed() {
+				t1.Fatalf("the task is expected to fail")
+			}
+			t.ExecuteRollback()
+
+			if len(r.hosts) != len(hosts) {
+				t1.Errorf("rolled back on %v, want all of the %d hosts", r.hosts, len(hosts))
+			}
+		})
+	}
+}
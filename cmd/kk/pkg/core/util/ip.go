@@ -18,6 +18,7 @@ package util
 
 import (
 	"encoding/binary"
+	"math/big"
 	"net"
 	"strconv"
 	"strings"
@@ -57,11 +58,11 @@ func GetAvailableIPRange(ipStart, ipEnd string) []string {
 	}
 	firstIPNum := ipToInt(firstIP.To4())
 	EndIPNum := ipToInt(endIP.To4())
-	pos := int32(1)
+	pos := uint32(1)
 
 	newNum := firstIPNum
 
-	for newNum <= EndIPNum {
+	for newNum <= EndIPNum && newNum >= firstIPNum {
 		availableIPs = append(availableIPs, intToIP(newNum).String())
 		newNum = newNum + pos
 	}
@@ -73,16 +74,19 @@ func GetAvailableIP(ipAndMask string) []string {
 
 	ipAndMask = strings.TrimSpace(ipAndMask)
 	ipAndMask = IPAddressToCIDR(ipAndMask)
-	_, ipnet, _ := net.ParseCIDR(ipAndMask)
+	_, ipnet, err := net.ParseCIDR(ipAndMask)
+	if err != nil || ipnet.IP.To4() == nil {
+		return availableIPs
+	}
 
 	firstIP, _ := networkRange(ipnet)
 	ipNum := ipToInt(firstIP)
 	size := networkSize(ipnet.Mask)
-	pos := int32(1)
+	pos := uint32(1)
 	max := size - 2 // -1 for the broadcast address, -1 for the gateway address
 
-	var newNum int32
-	for attempt := int32(0); attempt < max; attempt++ {
+	var newNum uint32
+	for attempt := uint32(0); attempt < max; attempt++ {
 		newNum = ipNum + pos
 		pos = pos%max + 1
 		availableIPs = append(availableIPs, intToIP(newNum).String())
@@ -126,24 +130,75 @@ func networkRange(network *net.IPNet) (net.IP, net.IP) {
 	return firstIP, lastIP
 }
 
-func networkSize(mask net.IPMask) int32 {
+func networkSize(mask net.IPMask) uint32 {
 	m := net.IPv4Mask(0, 0, 0, 0)
 	for i := 0; i < net.IPv4len; i++ {
 		m[i] = ^mask[i]
 	}
-	return int32(binary.BigEndian.Uint32(m)) + 1
+	return binary.BigEndian.Uint32(m) + 1
 }
 
-func ipToInt(ip net.IP) int32 {
-	return int32(binary.BigEndian.Uint32(ip.To4()))
+func ipToInt(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
 }
 
-func intToIP(n int32) net.IP {
+func intToIP(n uint32) net.IP {
 	b := make([]byte, 4)
 	binary.BigEndian.PutUint32(b, uint32(n))
 	return net.IP(b)
 }
 
+// SplitCIDRs splits the comma separated CIDRs of a dual-stack network.
+func SplitCIDRs(cidrs string) []string {
+	var result []string
+	for _, cidr := range strings.Split(cidrs, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			result = append(result, cidr)
+		}
+	}
+	return result
+}
+
+// IsIPv6 tells whether an address or a CIDR is IPv6.
+func IsIPv6(address string) bool {
+	ip := net.ParseIP(strings.SplitN(address, "/", 2)[0])
+	return ip != nil && ip.To4() == nil
+}
+
+// BracketIP wraps an IPv6 address in brackets to be the host of a URL or an address with a port.
+func BracketIP(address string) string {
+	if IsIPv6(address) {
+		return "[" + address + "]"
+	}
+	return address
+}
+
+// NthIP returns the nth address of the network of an IPv4 or IPv6 CIDR, e.g. the 1st address of 10.233.0.0/18 is
+// 10.233.0.1 and the 3rd address of fd00:10:233::/108 is fd00:10:233::3.
+func NthIP(cidr string, n int64) (string, error) {
+	_, ipnet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid CIDR %s", cidr)
+	}
+
+	ip := ipnet.IP
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	num := new(big.Int).SetBytes(ip)
+	num.Add(num, big.NewInt(n))
+	b := num.Bytes()
+	if len(b) > len(ip) {
+		return "", errors.Errorf("the %dth address is out of %s", n, cidr)
+	}
+	result := make(net.IP, len(ip))
+	copy(result[len(ip)-len(b):], b)
+	if !ipnet.Contains(result) {
+		return "", errors.Errorf("the %dth address is out of %s", n, cidr)
+	}
+	return result.String(), nil
+}
+
 func GetLocalIP() (string, error) {
 	addrs, err := net.InterfaceAddrs()
 	if err != nil {
@@ -0,0 +1,69 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNthIP(t *testing.T) {
+	tests := []struct {
+		cidr    string
+		n       int64
+		want    string
+		wantErr bool
+	}{
+		{cidr: "10.233.0.0/18", n: 1, want: "10.233.0.1"},
+		{cidr: "10.233.0.0/18", n: 3, want: "10.233.0.3"},
+		{cidr: "172.16.255.0/24", n: 255, want: "172.16.255.255"},
+		{cidr: "192.168.0.0/30", n: 4, wantErr: true},
+		{cidr: "fd00:10:233::/108", n: 3, want: "fd00:10:233::3"},
+		{cidr: "fd00:10:233::ff00/120", n: 255, want: "fd00:10:233::ffff"},
+		{cidr: "fd00:10:233::/120", n: 256, wantErr: true},
+		{cidr: "invalid", n: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := NthIP(tt.cidr, tt.n)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NthIP(%s, %d) error = %v, wantErr %v", tt.cidr, tt.n, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NthIP(%s, %d) = %s, want %s", tt.cidr, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestSplitCIDRs(t *testing.T) {
+	got := SplitCIDRs("fd00:10:233::/108, 10.233.0.0/18,")
+	if want := []string{"fd00:10:233::/108", "10.233.0.0/18"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitCIDRs() = %v, want %v", got, want)
+	}
+	if !IsIPv6(got[0]) || IsIPv6(got[1]) {
+		t.Errorf("unexpected IP families of %v", got)
+	}
+}
+
+func TestParseIpHighAddress(t *testing.T) {
+	if got := ParseIp("192.168.0.1-192.168.0.3"); !reflect.DeepEqual(got, []string{"192.168.0.1", "192.168.0.2", "192.168.0.3"}) {
+		t.Errorf("ParseIp() = %v", got)
+	}
+	if got := ParseIp("192.168.0.0/30"); !reflect.DeepEqual(got, []string{"192.168.0.1", "192.168.0.2"}) {
+		t.Errorf("ParseIp() = %v", got)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// sources keeps the text which the templates are parsed from, e.g. "{" followed by "{{-" can not be printed back
+// from the parsed nodes.
+var sources sync.Map
+
+// OverrideTemplate returns the template in dir which has the same name as tmpl, it is parsed with the functions of tmpl.
+// tmpl itself is returned if dir is empty or there is no such template in it.
+func OverrideTemplate(tmpl *template.Template, dir string) (*template.Template, error) {
+	if dir == "" {
+		return tmpl, nil
+	}
+	path := filepath.Join(dir, tmpl.Name())
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return tmpl, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "read template %s failed", path)
+	}
+
+	override, err := tmpl.Clone()
+	if err != nil {
+		return nil, errors.Wrapf(err, "clone template %s failed", tmpl.Name())
+	}
+	if _, err := ParseTemplate(override, string(content)); err != nil {
+		return nil, errors.Wrapf(err, "parse template %s failed", path)
+	}
+	return override, nil
+}
+
+// ParseTemplate parses text into tmpl as tmpl.Parse does, and keeps text as the source of tmpl.
+func ParseTemplate(tmpl *template.Template, text string) (*template.Template, error) {
+	if _, err := tmpl.Parse(text); err != nil {
+		return nil, err
+	}
+	sources.Store(tmpl, text)
+	return tmpl, nil
+}
+
+// TemplateSource returns the source text of a template parsed by ParseTemplate, or the text printed back from the
+// parsed nodes of any other template.
+func TemplateSource(tmpl *template.Template) string {
+	if text, ok := sources.Load(tmpl); ok {
+		return text.(string)
+	}
+	if tmpl.Tree == nil || tmpl.Tree.Root == nil {
+		return ""
+	}
+	return tmpl.Tree.Root.String()
+}
@@ -0,0 +1,69 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestOverrideTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("config.toml").Funcs(template.FuncMap{"upper": strings.ToUpper}).Parse("built-in {{ .Name }}"))
+
+	dir := t.TempDir()
+	for _, d := range []string{"", dir} {
+		got, err := OverrideTemplate(tmpl, d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tmpl {
+			t.Errorf("expected the built-in template with dir %q", d)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte("override {{ upper .Name }}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := OverrideTemplate(tmpl, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := Render(got, Data{"Name": "kubekey"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "override KUBEKEY" {
+		t.Errorf("unexpected output %q", out)
+	}
+	if out, _ := Render(tmpl, Data{"Name": "kubekey"}); out != "built-in kubekey" {
+		t.Errorf("the built-in template is changed: %q", out)
+	}
+	if source := TemplateSource(got); source != "override {{ upper .Name }}" {
+		t.Errorf("unexpected source %q", source)
+	}
+}
+
+func TestTemplateSource(t *testing.T) {
+	text := "{ {{- .Name }} }"
+	tmpl := template.Must(ParseTemplate(template.New("config.json"), text))
+	if source := TemplateSource(tmpl); source != text {
+		t.Errorf("unexpected source %q", source)
+	}
+}
@@ -203,9 +203,10 @@ func GenerateAltName(k *common.KubeConf, runtime *connector.Runtime) *cert.AltNa
 
 	for _, host := range k.Cluster.Hosts {
 		dnsList = append(dnsList, host.Name)
-		internalAddress := netutils.ParseIPSloppy(host.InternalAddress)
-		if internalAddress != nil {
-			ipList = append(ipList, internalAddress)
+		for _, address := range host.InternalAddresses() {
+			if internalAddress := netutils.ParseIPSloppy(address); internalAddress != nil {
+				ipList = append(ipList, internalAddress)
+			}
 		}
 	}
 
@@ -22,6 +22,7 @@ import (
 	kubekeyapiv1alpha2 "github.com/kubesphere/kubekey/v3/cmd/kk/apis/kubekey/v1alpha2"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/action"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/task"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/etcd/templates"
@@ -36,6 +37,10 @@ func (p *PreCheckModule) IsSkip() bool {
 	return p.Skip
 }
 
+func (p *PreCheckModule) Repeatable() bool {
+	return true
+}
+
 func (p *PreCheckModule) Init() {
 	p.Name = "ETCDPreCheckModule"
 	p.Desc = "Get ETCD cluster status"
@@ -149,6 +154,10 @@ func (p *InstallETCDBinaryModule) IsSkip() bool {
 	return p.Skip
 }
 
+func (p *InstallETCDBinaryModule) Repeatable() bool {
+	return true
+}
+
 func (i *InstallETCDBinaryModule) Init() {
 	i.Name = "InstallETCDBinaryModule"
 	i.Desc = "Install ETCD cluster"
@@ -423,3 +432,124 @@ func (b *BackupModule) Init() {
 		enable,
 	}
 }
+
+// SnapshotModule saves a snapshot of the etcd data on the first etcd node, it is taken before the cluster is upgraded.
+type SnapshotModule struct {
+	common.KubeModule
+	Skip bool
+}
+
+func (s *SnapshotModule) IsSkip() bool {
+	return s.Skip
+}
+
+func (s *SnapshotModule) Init() {
+	s.Name = "ETCDSnapshotModule"
+	s.Desc = "Save a snapshot of the etcd data"
+
+	saveSnapshot := &task.RemoteTask{
+		Name:     "SaveETCDSnapshot",
+		Desc:     "Save etcd snapshot",
+		Hosts:    []connector.Host{s.Runtime.GetHostsByRole(common.ETCD)[0]},
+		Action:   new(SaveSnapshot),
+		Parallel: true,
+		Retry:    2,
+	}
+
+	s.Tasks = []task.Interface{
+		saveSnapshot,
+	}
+}
+
+type RestoreModule struct {
+	common.KubeModule
+	Skip bool
+}
+
+func (r *RestoreModule) IsSkip() bool {
+	return r.Skip
+}
+
+func (r *RestoreModule) Init() {
+	r.Name = "ETCDRestoreModule"
+	r.Desc = "Restore ETCD cluster data from a snapshot"
+
+	stopKubeAPIServer := &task.RemoteTask{
+		Name:     "StopKubeAPIServer",
+		Desc:     "Stop kube-apiserver",
+		Hosts:    r.Runtime.GetHostsByRole(common.Master),
+		Action:   new(StopKubeAPIServer),
+		Parallel: true,
+	}
+
+	stopETCD := &task.RemoteTask{
+		Name:     "StopETCD",
+		Desc:     "Stop etcd",
+		Hosts:    r.Runtime.GetHostsByRole(common.ETCD),
+		Action:   new(StopETCD),
+		Parallel: true,
+	}
+
+	syncSnapshot := &task.RemoteTask{
+		Name:     "SyncETCDSnapshot",
+		Desc:     "Synchronize etcd snapshot file",
+		Hosts:    r.Runtime.GetHostsByRole(common.ETCD),
+		Action:   new(SyncSnapshot),
+		Parallel: true,
+		Retry:    1,
+	}
+
+	restoreSnapshot := &task.RemoteTask{
+		Name:     "RestoreETCDSnapshot",
+		Desc:     "Restore etcd data from snapshot",
+		Hosts:    r.Runtime.GetHostsByRole(common.ETCD),
+		Action:   new(RestoreSnapshot),
+		Parallel: true,
+	}
+
+	restart := &task.RemoteTask{
+		Name:     "RestartETCD",
+		Desc:     "Restart etcd",
+		Hosts:    r.Runtime.GetHostsByRole(common.ETCD),
+		Action:   new(RestartETCD),
+		Parallel: true,
+	}
+
+	accessAddress := &task.RemoteTask{
+		Name:     "GenerateAccessAddress",
+		Desc:     "Generate access address",
+		Hosts:    r.Runtime.GetHostsByRole(common.ETCD),
+		Prepare:  new(FirstETCDNode),
+		Action:   new(GenerateAccessAddress),
+		Parallel: true,
+		Retry:    1,
+	}
+
+	allETCDNodeHealthCheck := &task.RemoteTask{
+		Name:     "AllETCDNodeHealthCheck",
+		Desc:     "Health check on all etcd",
+		Hosts:    r.Runtime.GetHostsByRole(common.ETCD),
+		Action:   new(HealthCheck),
+		Parallel: true,
+		Retry:    20,
+	}
+
+	startKubeAPIServer := &task.RemoteTask{
+		Name:     "StartKubeAPIServer",
+		Desc:     "Start kube-apiserver",
+		Hosts:    r.Runtime.GetHostsByRole(common.Master),
+		Action:   new(StartKubeAPIServer),
+		Parallel: true,
+	}
+
+	r.Tasks = []task.Interface{
+		stopKubeAPIServer,
+		stopETCD,
+		syncSnapshot,
+		restoreSnapshot,
+		restart,
+		accessAddress,
+		allETCDNodeHealthCheck,
+		startKubeAPIServer,
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/action"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/etcd/templates"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/files"
@@ -84,12 +86,12 @@ func (g *GetStatus) Execute(runtime connector.Runtime) error {
 
 		if v, ok := g.PipelineCache.Get(common.ETCDCluster); ok {
 			c := v.(*EtcdCluster)
-			c.peerAddresses = append(c.peerAddresses, fmt.Sprintf("%s=https://%s:2380", etcdName, host.GetInternalAddress()))
+			c.peerAddresses = append(c.peerAddresses, fmt.Sprintf("%s=https://%s:2380", etcdName, util.BracketIP(host.GetInternalAddress())))
 			c.clusterExist = true
 			// type: *EtcdCluster
 			g.PipelineCache.Set(common.ETCDCluster, c)
 		} else {
-			cluster.peerAddresses = append(cluster.peerAddresses, fmt.Sprintf("%s=https://%s:2380", etcdName, host.GetInternalAddress()))
+			cluster.peerAddresses = append(cluster.peerAddresses, fmt.Sprintf("%s=https://%s:2380", etcdName, util.BracketIP(host.GetInternalAddress())))
 			cluster.clusterExist = true
 			g.PipelineCache.Set(common.ETCDCluster, cluster)
 		}
@@ -169,7 +171,7 @@ type GenerateAccessAddress struct {
 func (g *GenerateAccessAddress) Execute(runtime connector.Runtime) error {
 	var addrList []string
 	for _, host := range runtime.GetHostsByRole(common.ETCD) {
-		addrList = append(addrList, fmt.Sprintf("https://%s:2379", host.GetInternalAddress()))
+		addrList = append(addrList, fmt.Sprintf("https://%s:2379", util.BracketIP(host.GetInternalAddress())))
 	}
 
 	accessAddresses := strings.Join(addrList, ",")
@@ -227,7 +229,7 @@ func (g *GenerateConfig) Execute(runtime connector.Runtime) error {
 	if v, ok := g.PipelineCache.Get(common.ETCDCluster); ok {
 		cluster := v.(*EtcdCluster)
 
-		cluster.peerAddresses = append(cluster.peerAddresses, fmt.Sprintf("%s=https://%s:2380", etcdName, host.GetInternalAddress()))
+		cluster.peerAddresses = append(cluster.peerAddresses, fmt.Sprintf("%s=https://%s:2380", etcdName, util.BracketIP(host.GetInternalAddress())))
 		g.PipelineCache.Set(common.ETCDCluster, cluster)
 
 		if !cluster.clusterExist {
@@ -295,7 +297,7 @@ func refreshConfig(KubeConf *common.KubeConf, runtime connector.Runtime, endpoin
 		Data: util.Data{
 			"Tag":                 kubekeyapiv1alpha2.DefaultEtcdVersion,
 			"Name":                etcdName,
-			"Ip":                  host.GetInternalAddress(),
+			"Ip":                  util.BracketIP(host.GetInternalAddress()),
 			"Hostname":            host.GetName(),
 			"State":               state,
 			"PeerAddresses":       strings.Join(endpoints, ","),
@@ -341,7 +343,7 @@ func (j *JoinMember) Execute(runtime connector.Runtime) error {
 			"export ETCDCTL_CA_FILE='/etc/ssl/etcd/ssl/ca.pem';"+
 			"%s/etcdctl --endpoints=%s member add %s %s",
 			host.GetName(), host.GetName(), common.BinDir, cluster.accessAddresses, etcdName,
-			fmt.Sprintf("https://%s:2380", host.GetInternalAddress()))
+			fmt.Sprintf("https://%s:2380", util.BracketIP(host.GetInternalAddress())))
 
 		if _, err := runtime.GetRunner().SudoCmd(joinMemberCmd, true); err != nil {
 			return errors.Wrap(errors.WithStack(err), "add etcd member failed")
@@ -375,7 +377,7 @@ func (c *CheckMember) Execute(runtime connector.Runtime) error {
 		if err != nil {
 			return errors.Wrap(errors.WithStack(err), "list etcd member failed")
 		}
-		if !strings.Contains(memberList, fmt.Sprintf("https://%s:2379", host.GetInternalAddress())) {
+		if !strings.Contains(memberList, fmt.Sprintf("https://%s:2379", util.BracketIP(host.GetInternalAddress()))) {
 			return errors.Wrap(errors.WithStack(err), "add etcd member failed")
 		}
 	} else {
@@ -405,7 +407,7 @@ func (b *BackupETCD) Execute(runtime connector.Runtime) error {
 		Dst:      filepath.Join(b.KubeConf.Cluster.Etcd.BackupScriptDir, "etcd-backup.sh"),
 		Data: util.Data{
 			"Hostname":            runtime.RemoteHost().GetName(),
-			"Etcdendpoint":        fmt.Sprintf("https://%s:2379", runtime.RemoteHost().GetInternalAddress()),
+			"Etcdendpoint":        fmt.Sprintf("https://%s:2379", util.BracketIP(runtime.RemoteHost().GetInternalAddress())),
 			"DataDir":             b.KubeConf.Cluster.Etcd.DataDir,
 			"Backupdir":           b.KubeConf.Cluster.Etcd.BackupDir,
 			"KeepbackupNumber":    b.KubeConf.Cluster.Etcd.KeepBackupNumber + 1,
@@ -435,3 +437,146 @@ func (e *EnableBackupETCDService) Execute(runtime connector.Runtime) error {
 	}
 	return nil
 }
+
+type SaveSnapshot struct {
+	common.KubeAction
+}
+
+func (s *SaveSnapshot) Execute(runtime connector.Runtime) error {
+	host := runtime.RemoteHost()
+	snapshot := filepath.Join(common.KubeUpgradeBackupDir, fmt.Sprintf("etcd-snapshot-%s.db", time.Now().Format("20060102150405")))
+	saveCmd := fmt.Sprintf("mkdir -p %s && export ETCDCTL_API=3;"+
+		"%s/etcdctl --endpoints=https://%s:2379 "+
+		"--cacert=/etc/ssl/etcd/ssl/ca.pem "+
+		"--cert=/etc/ssl/etcd/ssl/admin-%s.pem "+
+		"--key=/etc/ssl/etcd/ssl/admin-%s-key.pem "+
+		"snapshot save %s",
+		common.KubeUpgradeBackupDir, common.BinDir, util.BracketIP(host.GetInternalAddress()),
+		host.GetName(), host.GetName(), snapshot)
+	if _, err := runtime.GetRunner().SudoCmd(saveCmd, false); err != nil {
+		return errors.Wrap(errors.WithStack(err), "save etcd snapshot failed")
+	}
+
+	logger.Log.Messagef(host.GetName(), "etcd snapshot is saved to %s", snapshot)
+	return nil
+}
+
+// kubeAPIServerManifestBackup is where the kube-apiserver static pod manifest is kept while etcd is being restored.
+var kubeAPIServerManifestBackup = filepath.Join(common.KubeConfigDir, "kube-apiserver.yaml.restore")
+
+type StopKubeAPIServer struct {
+	common.KubeAction
+}
+
+func (s *StopKubeAPIServer) Execute(runtime connector.Runtime) error {
+	manifest := filepath.Join(common.KubeManifestDir, "kube-apiserver.yaml")
+	exist, err := runtime.GetRunner().FileExist(manifest)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return nil
+	}
+
+	// kubelet stops the static pod once its manifest is moved out of the manifests dir.
+	stopCmd := fmt.Sprintf("mv -f %s %s && "+
+		"timeout 120 /bin/sh -c 'while pgrep -x kube-apiserver > /dev/null; do sleep 2; done'",
+		manifest, kubeAPIServerManifestBackup)
+	if _, err := runtime.GetRunner().SudoCmd(stopCmd, false); err != nil {
+		return errors.Wrap(errors.WithStack(err), "stop kube-apiserver failed")
+	}
+	return nil
+}
+
+type StartKubeAPIServer struct {
+	common.KubeAction
+}
+
+func (s *StartKubeAPIServer) Execute(runtime connector.Runtime) error {
+	exist, err := runtime.GetRunner().FileExist(kubeAPIServerManifestBackup)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return nil
+	}
+
+	startCmd := fmt.Sprintf("mv -f %s %s", kubeAPIServerManifestBackup, filepath.Join(common.KubeManifestDir, "kube-apiserver.yaml"))
+	if _, err := runtime.GetRunner().SudoCmd(startCmd, false); err != nil {
+		return errors.Wrap(errors.WithStack(err), "start kube-apiserver failed")
+	}
+	return nil
+}
+
+type StopETCD struct {
+	common.KubeAction
+}
+
+func (s *StopETCD) Execute(runtime connector.Runtime) error {
+	if _, err := runtime.GetRunner().SudoCmd("systemctl stop etcd", false); err != nil {
+		return errors.Wrap(errors.WithStack(err), "stop etcd failed")
+	}
+	return nil
+}
+
+type SyncSnapshot struct {
+	common.KubeAction
+}
+
+func (s *SyncSnapshot) Execute(runtime connector.Runtime) error {
+	if err := utils.ResetTmpDir(runtime); err != nil {
+		return err
+	}
+
+	dst := filepath.Join(common.TmpDir, "etcd-snapshot.db")
+	if err := runtime.GetRunner().Scp(s.KubeConf.Arg.EtcdSnapshot, dst); err != nil {
+		return errors.Wrap(errors.WithStack(err), "sync etcd snapshot file failed")
+	}
+	return nil
+}
+
+type RestoreSnapshot struct {
+	common.KubeAction
+}
+
+func (r *RestoreSnapshot) Execute(runtime connector.Runtime) error {
+	host := runtime.RemoteHost()
+	if exist, _ := host.GetCache().GetMustBool(common.ETCDExist); !exist {
+		return errors.Errorf("etcd is not installed by kubekey on %s", host.GetName())
+	}
+	etcdName, ok := host.GetCache().GetMustString(common.ETCDName)
+	if !ok {
+		return errors.New("get etcd node status by host label failed")
+	}
+
+	v, ok := r.PipelineCache.Get(common.ETCDCluster)
+	if !ok {
+		return errors.New("get etcd cluster status by pipeline cache failed")
+	}
+	cluster := v.(*EtcdCluster)
+
+	dataDir := "/var/lib/etcd"
+	if r.KubeConf.Cluster.Etcd.DataDir != nil && *r.KubeConf.Cluster.Etcd.DataDir != "" {
+		dataDir = *r.KubeConf.Cluster.Etcd.DataDir
+	}
+
+	restoreCmd := fmt.Sprintf("%s/etcdutl", common.BinDir)
+	if exist, err := runtime.GetRunner().FileExist(restoreCmd); err != nil {
+		return err
+	} else if !exist {
+		// etcdutl is not released before etcd v3.5
+		restoreCmd = fmt.Sprintf("export ETCDCTL_API=3;%s/etcdctl", common.BinDir)
+	}
+
+	// the current data is kept aside instead of being removed, so that it can be recovered manually.
+	restoreCmd = fmt.Sprintf("if [ -d %s ]; then mv %s %s-%s; fi && "+
+		"%s snapshot restore %s --name=%s --data-dir=%s --initial-cluster=%s --initial-cluster-token=k8s_etcd --initial-advertise-peer-urls=https://%s:2380",
+		dataDir, dataDir, dataDir, time.Now().Format("20060102150405"),
+		restoreCmd, filepath.Join(common.TmpDir, "etcd-snapshot.db"), etcdName, dataDir,
+		strings.Join(cluster.peerAddresses, ","), util.BracketIP(host.GetInternalAddress()))
+	if _, err := runtime.GetRunner().SudoCmd(restoreCmd, true); err != nil {
+		return errors.Wrap(errors.WithStack(err), "restore etcd snapshot failed")
+	}
+
+	return refreshConfig(r.KubeConf, runtime, cluster.peerAddresses, ExistCluster, etcdName)
+}
@@ -20,10 +20,12 @@ import (
 	"text/template"
 
 	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 // EtcdBackupScriptTmpl defines the template of etcd backup script.
-var EtcdBackupScript = template.Must(template.New("etcd-backup.sh").Parse(
+var EtcdBackupScript = template.Must(util.ParseTemplate(template.New("etcd-backup.sh"),
 	dedent.Dedent(`#!/bin/bash
 
 set -o errexit
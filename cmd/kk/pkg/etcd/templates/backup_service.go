@@ -22,11 +22,13 @@ import (
 	"text/template"
 
 	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 var (
 	// BackupETCDService defines the template of backup-etcd service for systemd.
-	BackupETCDService = template.Must(template.New("backup-etcd.service").Parse(
+	BackupETCDService = template.Must(util.ParseTemplate(template.New("backup-etcd.service"),
 		dedent.Dedent(`[Unit]
 Description=Backup ETCD
 [Service]
@@ -35,7 +37,7 @@ ExecStart={{ .ScriptPath }}
     `)))
 
 	// BackupETCDTimer defines the template of backup-etcd timer for systemd.
-	BackupETCDTimer = template.Must(template.New("backup-etcd.timer").Parse(
+	BackupETCDTimer = template.Must(util.ParseTemplate(template.New("backup-etcd.timer"),
 		dedent.Dedent(`[Unit]
 Description=Timer to backup ETCD
 [Timer]
@@ -19,10 +19,12 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 // EtcdEnv defines the template of etcd's env.
-var EtcdEnv = template.Must(template.New("etcd.env").Parse(
+var EtcdEnv = template.Must(util.ParseTemplate(template.New("etcd.env"),
 	dedent.Dedent(`# Environment file for etcd {{ .Tag }}
 {{- if .DataDir }}
 ETCD_DATA_DIR={{ .DataDir }}
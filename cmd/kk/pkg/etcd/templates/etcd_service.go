@@ -19,11 +19,13 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 var (
 	// ETCDService defines the template of etcd's service for systemd.
-	ETCDService = template.Must(template.New("etcd.service").Parse(
+	ETCDService = template.Must(util.ParseTemplate(template.New("etcd.service"),
 		dedent.Dedent(`[Unit]
 Description=etcd
 After=network.target
@@ -0,0 +1,376 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package files
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/homedir"
+)
+
+const (
+	// DefaultDownloadParallel is the number of binaries downloaded at the same time by the built-in downloader.
+	DefaultDownloadParallel = 4
+	// DefaultDownloadRetry is the number of attempts to download a file.
+	DefaultDownloadRetry = 5
+
+	partSuffix = ".part"
+)
+
+var (
+	// DefaultCacheDir is the local content-addressed cache of the downloaded files, keyed by their sha256.
+	DefaultCacheDir = filepath.Join(homedir.HomeDir(), ".kube", "kk_cache")
+
+	// DefaultDownloader is the built-in downloader used when no download command is defined.
+	DefaultDownloader = NewDownloader(DefaultCacheDir)
+)
+
+// Downloader downloads files over HTTP. It honors the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables,
+// resumes interrupted downloads with range requests and keeps the verified files in a content-addressed cache.
+type Downloader struct {
+	Client   *http.Client
+	CacheDir string
+	Retry    int
+	Backoff  time.Duration
+
+	// Progress receives the progress of the downloads, it is reported at most once per ProgressInterval for each file.
+	Progress         io.Writer
+	ProgressInterval time.Duration
+}
+
+func NewDownloader(cacheDir string) *Downloader {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	return &Downloader{
+		Client:           &http.Client{Transport: transport},
+		CacheDir:         cacheDir,
+		Retry:            DefaultDownloadRetry,
+		Backoff:          2 * time.Second,
+		Progress:         os.Stdout,
+		ProgressInterval: 2 * time.Second,
+	}
+}
+
+// Download downloads the url to the dest. When the checksum is not empty, the file is verified against it,
+// and it is served from or stored into the cache.
+func (d *Downloader) Download(ctx context.Context, url, dest, checksum string) error {
+	if checksum != "" && d.fromCache(checksum, dest) {
+		d.progressf("%s found in the cache\n", filepath.Base(dest))
+		return nil
+	}
+
+	part := dest + partSuffix
+	retry := d.Retry
+	if retry <= 0 {
+		retry = 1
+	}
+
+	var err error
+	for i := 0; i < retry; i++ {
+		if i > 0 {
+			d.progressf("download %s failed: %v, retrying ...\n", filepath.Base(dest), err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(d.Backoff):
+			}
+		}
+
+		if err = d.fetch(ctx, url, part); err != nil {
+			continue
+		}
+		if checksum != "" {
+			sum, sumErr := sha256sum(part)
+			if sumErr != nil {
+				return errors.Wrapf(sumErr, "Failed to check SHA256 of %s", part)
+			}
+			if sum != checksum {
+				_ = os.Remove(part)
+				err = errors.Errorf("SHA256 no match. %s not equal %s", checksum, sum)
+				continue
+			}
+		}
+		break
+	}
+	if err != nil {
+		return errors.Wrapf(err, "download %s failed", url)
+	}
+
+	if err := os.Rename(part, dest); err != nil {
+		return errors.Wrapf(err, "rename %s to %s failed", part, dest)
+	}
+	if checksum != "" {
+		if err := d.store(checksum, dest); err != nil {
+			d.progressf("store %s into the cache failed: %v\n", filepath.Base(dest), err)
+		}
+	}
+	return nil
+}
+
+// fetch downloads the url into the part file, it continues from the end of the part file if the server supports range requests.
+func (d *Downloader) fetch(ctx context.Context, url, part string) error {
+	var offset int64
+	if fi, err := os.Stat(part); err == nil {
+		offset = fi.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	flag := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		flag |= os.O_APPEND
+	case http.StatusOK:
+		flag |= os.O_TRUNC
+		offset = 0
+	case http.StatusRequestedRangeNotSatisfiable:
+		// the part file does not belong to the current file, download it from scratch.
+		_ = os.Remove(part)
+		return errors.Errorf("GET %s: %s", url, resp.Status)
+	default:
+		return errors.Errorf("GET %s: %s", url, resp.Status)
+	}
+
+	f, err := os.OpenFile(part, flag, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+	p := &progress{
+		name:     filepath.Base(part[:len(part)-len(partSuffix)]),
+		current:  offset,
+		total:    total,
+		out:      d.Progress,
+		interval: d.ProgressInterval,
+	}
+	if _, err := io.Copy(io.MultiWriter(f, p), resp.Body); err != nil {
+		return err
+	}
+	p.done()
+	return nil
+}
+
+func (d *Downloader) cachePath(checksum string) string {
+	return filepath.Join(d.CacheDir, "sha256", checksum)
+}
+
+// fromCache copies the cached file of the checksum to the dest, it reports whether the cache is hit.
+func (d *Downloader) fromCache(checksum, dest string) bool {
+	if d.CacheDir == "" {
+		return false
+	}
+	blob := d.cachePath(checksum)
+	if sum, err := sha256sum(blob); err != nil || sum != checksum {
+		_ = os.Remove(blob)
+		return false
+	}
+	return copyFile(blob, dest) == nil
+}
+
+func (d *Downloader) store(checksum, src string) error {
+	if d.CacheDir == "" {
+		return nil
+	}
+	blob := d.cachePath(checksum)
+	if err := os.MkdirAll(filepath.Dir(blob), 0755); err != nil {
+		return err
+	}
+	tmp := blob + partSuffix
+	if err := copyFile(src, tmp); err != nil {
+		return err
+	}
+	return os.Rename(tmp, blob)
+}
+
+func (d *Downloader) progressf(format string, a ...interface{}) {
+	if d.Progress != nil {
+		_, _ = fmt.Fprintf(d.Progress, format, a...)
+	}
+}
+
+// DownloadBinaries downloads the binaries. The binaries using the built-in downloader are downloaded in parallel,
+// the ones using a user defined download command are downloaded one by one.
+func DownloadBinaries(binaries []*KubeBinary, parallel int) error {
+	if parallel <= 0 {
+		parallel = DefaultDownloadParallel
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, parallel)
+	)
+	for _, binary := range binaries {
+		if binary.getCmd != nil {
+			if err := binary.Download(); err != nil {
+				return fmt.Errorf("Failed to download %s binary: %s error: %w ", binary.ID, binary.GetCmd(), err)
+			}
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(b *KubeBinary) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := b.Download(); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("Failed to download %s binary: %s error: %w ", b.ID, b.GetCmd(), err))
+				mu.Unlock()
+			}
+		}(binary)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+type progress struct {
+	name     string
+	current  int64
+	total    int64
+	out      io.Writer
+	interval time.Duration
+	last     time.Time
+}
+
+func (p *progress) Write(b []byte) (int, error) {
+	p.current += int64(len(b))
+	if p.out != nil && time.Since(p.last) >= p.interval {
+		p.last = time.Now()
+		p.report()
+	}
+	return len(b), nil
+}
+
+func (p *progress) done() {
+	if p.out != nil {
+		p.report()
+	}
+}
+
+func (p *progress) report() {
+	if p.total > 0 {
+		_, _ = fmt.Fprintf(p.out, "%s: %s / %s (%d%%)\n", p.name, formatBytes(p.current), formatBytes(p.total), p.current*100/p.total)
+		return
+	}
+	_, _ = fmt.Fprintf(p.out, "%s: %s\n", p.name, formatBytes(p.current))
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// extractFile extracts the named file of the gzip compressed tarball to the dest.
+func extractFile(tarball, name, dest string) error {
+	f, err := os.Open(tarball)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return errors.Wrapf(err, "read %s failed", tarball)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return errors.Errorf("%s not found in %s", name, tarball)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "read %s failed", tarball)
+		}
+		if filepath.Clean(hdr.Name) != filepath.Clean(name) {
+			continue
+		}
+
+		out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, tr); err != nil {
+			_ = out.Close()
+			return err
+		}
+		return out.Close()
+	}
+}
@@ -0,0 +1,173 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package files
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testServer struct {
+	*httptest.Server
+	content  []byte
+	requests int32
+	ranges   []string
+	mu       sync.Mutex
+}
+
+func newTestServer(t *testing.T, content []byte) *testServer {
+	s := &testServer{content: content}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.requests, 1)
+		s.mu.Lock()
+		s.ranges = append(s.ranges, r.Header.Get("Range"))
+		s.mu.Unlock()
+		http.ServeContent(w, r, filepath.Base(r.URL.Path), time.Time{}, bytes.NewReader(s.content))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestDownloader(t *testing.T) *Downloader {
+	d := NewDownloader(filepath.Join(t.TempDir(), "cache"))
+	d.Retry = 2
+	d.Backoff = 0
+	d.Progress = nil
+	return d
+}
+
+func checksum(content []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(content))
+}
+
+func TestDownloader_Download(t *testing.T) {
+	content := bytes.Repeat([]byte("kubekey"), 4096)
+	server := newTestServer(t, content)
+	d := newTestDownloader(t)
+
+	dest := filepath.Join(t.TempDir(), "kubeadm")
+	if err := d.Download(context.Background(), server.URL+"/kubeadm", dest, checksum(content)); err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("read downloaded file failed: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("Download() got %d bytes, want %d bytes", len(got), len(content))
+	}
+
+	// the second download is served from the cache.
+	other := filepath.Join(t.TempDir(), "kubeadm")
+	if err := d.Download(context.Background(), server.URL+"/kubeadm", other, checksum(content)); err != nil {
+		t.Fatalf("Download() from cache error = %v", err)
+	}
+	if n := atomic.LoadInt32(&server.requests); n != 1 {
+		t.Errorf("server got %d requests, want 1", n)
+	}
+}
+
+func TestDownloader_Resume(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	server := newTestServer(t, content)
+	d := newTestDownloader(t)
+
+	dest := filepath.Join(t.TempDir(), "etcd.tar.gz")
+	if err := os.WriteFile(dest+partSuffix, content[:4000], 0644); err != nil {
+		t.Fatalf("write part file failed: %v", err)
+	}
+	if err := d.Download(context.Background(), server.URL+"/etcd.tar.gz", dest, checksum(content)); err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if got := server.ranges; len(got) != 1 || got[0] != "bytes=4000-" {
+		t.Errorf("server got ranges %v, want [bytes=4000-]", got)
+	}
+	got, _ := os.ReadFile(dest)
+	if !bytes.Equal(got, content) {
+		t.Errorf("resumed file does not match the content")
+	}
+	if _, err := os.Stat(dest + partSuffix); !os.IsNotExist(err) {
+		t.Errorf("part file is not removed")
+	}
+}
+
+func TestDownloader_ChecksumMismatch(t *testing.T) {
+	server := newTestServer(t, []byte("corrupted"))
+	d := newTestDownloader(t)
+
+	dest := filepath.Join(t.TempDir(), "kubelet")
+	err := d.Download(context.Background(), server.URL+"/kubelet", dest, checksum([]byte("kubelet")))
+	if err == nil || !strings.Contains(err.Error(), "SHA256 no match") {
+		t.Fatalf("Download() error = %v, want SHA256 no match", err)
+	}
+	if n := atomic.LoadInt32(&server.requests); n != int32(d.Retry) {
+		t.Errorf("server got %d requests, want %d", n, d.Retry)
+	}
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		t.Errorf("dest file exists after a failed download")
+	}
+}
+
+func TestDownloadBinaries(t *testing.T) {
+	contents := map[string][]byte{
+		kubeadm: []byte("kubeadm binary"),
+		kubelet: []byte("kubelet binary"),
+		kubectl: []byte("kubectl binary"),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(contents[filepath.Base(r.URL.Path)])
+	}))
+	defer server.Close()
+
+	origin, originSha256 := DefaultDownloader, FileSha256
+	defer func() {
+		DefaultDownloader, FileSha256 = origin, originSha256
+	}()
+	DefaultDownloader = newTestDownloader(t)
+	FileSha256 = map[string]map[string]map[string]string{}
+
+	var binaries []*KubeBinary
+	for id, content := range contents {
+		FileSha256[id] = map[string]map[string]string{amd64: {"v1.23.10": checksum(content)}}
+		b := NewKubeBinary(id, amd64, "v1.23.10", t.TempDir(), nil)
+		b.Url = server.URL + "/" + id
+		if err := b.CreateBaseDir(); err != nil {
+			t.Fatalf("CreateBaseDir() error = %v", err)
+		}
+		binaries = append(binaries, b)
+	}
+
+	if err := DownloadBinaries(binaries, 2); err != nil {
+		t.Fatalf("DownloadBinaries() error = %v", err)
+	}
+	for _, b := range binaries {
+		if err := b.SHA256Check(); err != nil {
+			t.Errorf("%s SHA256Check() error = %v", b.ID, err)
+		}
+	}
+}
@@ -17,6 +17,7 @@
 package files
 
 import (
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"io"
@@ -50,6 +51,8 @@ const (
 	compose    = "compose"
 	containerd = "containerd"
 	runc       = "runc"
+	crio       = "crio"
+	criDockerd = "cri-dockerd"
 	calicoctl  = "calicoctl"
 )
 
@@ -64,6 +67,8 @@ const (
 	REGISTRY   = "registry"
 	CONTAINERD = "containerd"
 	RUNC       = "runc"
+	CRIO       = "crio"
+	CRIDOCKERD = "cri-dockerd"
 )
 
 var (
@@ -209,6 +214,20 @@ func NewKubeBinary(name, arch, version, prePath string, getCmd func(path, url st
 		if component.Zone == "cn" {
 			component.Url = fmt.Sprintf("https://kubernetes-release.pek3b.qingstor.com/opencontainers/runc/releases/download/%s/runc.%s", version, arch)
 		}
+	case crio:
+		component.Type = CRIO
+		component.FileName = fmt.Sprintf("cri-o.%s.%s.tar.gz", arch, version)
+		component.Url = fmt.Sprintf("https://storage.googleapis.com/cri-o/artifacts/cri-o.%s.%s.tar.gz", arch, version)
+		if component.Zone == "cn" {
+			component.Url = fmt.Sprintf("https://kubernetes-release.pek3b.qingstor.com/cri-o/artifacts/cri-o.%s.%s.tar.gz", arch, version)
+		}
+	case criDockerd:
+		component.Type = CRIDOCKERD
+		component.FileName = fmt.Sprintf("cri-dockerd-%s.%s.tgz", version, arch)
+		component.Url = fmt.Sprintf("https://github.com/Mirantis/cri-dockerd/releases/download/v%s/cri-dockerd-%s.%s.tgz", version, version, arch)
+		if component.Zone == "cn" {
+			component.Url = fmt.Sprintf("https://kubernetes-release.pek3b.qingstor.com/Mirantis/cri-dockerd/releases/download/v%s/cri-dockerd-%s.%s.tgz", version, version, arch)
+		}
 	case calicoctl:
 		component.Type = CNI
 		component.FileName = calicoctl
@@ -227,6 +246,26 @@ func NewKubeBinary(name, arch, version, prePath string, getCmd func(path, url st
 	return component
 }
 
+// binaryIDs is all the ids which NewKubeBinary knows.
+var binaryIDs = []string{etcd, kubeadm, kubelet, kubectl, kubecni, helm, docker, crictl, k3s, k8e, registry, harbor, compose, containerd, runc, crio, criDockerd, calicoctl}
+
+// LookupKubeBinary returns the binary whose path relative to the download directory is the given slash separated path,
+// or nil if no binary is stored at the path.
+func LookupKubeBinary(path string) *KubeBinary {
+	parts := strings.Split(path, "/")
+	if len(parts) < 4 {
+		return nil
+	}
+	version, arch := parts[len(parts)-3], parts[len(parts)-2]
+	for _, id := range binaryIDs {
+		b := NewKubeBinary(id, arch, version, "", nil)
+		if filepath.ToSlash(b.Path()) == path {
+			return b
+		}
+	}
+	return nil
+}
+
 func (b *KubeBinary) CreateBaseDir() error {
 	if err := util.CreateDir(b.BaseDir); err != nil {
 		return err
@@ -238,7 +277,12 @@ func (b *KubeBinary) Path() string {
 	return filepath.Join(b.BaseDir, b.FileName)
 }
 
+// GetCmd returns the user defined download command, or the URL if the built-in downloader is used.
 func (b *KubeBinary) GetCmd() string {
+	if b.getCmd == nil {
+		return b.Url
+	}
+
 	cmd := b.getCmd(b.Path(), b.Url)
 
 	if b.ID == helm && b.Zone != "cn" {
@@ -254,7 +298,12 @@ func (b *KubeBinary) GetSha256() string {
 	return s
 }
 
+// Download downloads the binary with the user defined download command, or with the DefaultDownloader if there is none.
 func (b *KubeBinary) Download() error {
+	if b.getCmd == nil {
+		return b.download(context.Background(), DefaultDownloader)
+	}
+
 	for i := 5; i > 0; i-- {
 		cmd := exec.Command("/bin/sh", "-c", b.GetCmd())
 		stdout, err := cmd.StdoutPipe()
@@ -297,6 +346,36 @@ func (b *KubeBinary) Download() error {
 	return nil
 }
 
+func (b *KubeBinary) download(ctx context.Context, d *Downloader) error {
+	if b.ID != helm || b.Zone == "cn" {
+		if err := d.Download(ctx, b.Url, b.Path(), b.GetSha256()); err != nil {
+			return err
+		}
+		return b.SHA256Check()
+	}
+
+	// the helm binary is released in a tarball, its checksum is the one of the binary inside.
+	if sum := b.GetSha256(); sum != "" && d.fromCache(sum, b.Path()) {
+		return nil
+	}
+	tarball := filepath.Join(b.BaseDir, fmt.Sprintf("helm-%s-linux-%s.tar.gz", b.Version, b.Arch))
+	if err := d.Download(ctx, b.Url, tarball, ""); err != nil {
+		return err
+	}
+	defer os.Remove(tarball)
+
+	if err := extractFile(tarball, fmt.Sprintf("linux-%s/helm", b.Arch), b.Path()); err != nil {
+		return err
+	}
+	if err := b.SHA256Check(); err != nil {
+		return err
+	}
+	if err := d.store(b.GetSha256(), b.Path()); err != nil {
+		d.progressf("store %s into the cache failed: %v\n", b.FileName, err)
+	}
+	return nil
+}
+
 // SHA256Check is used to hash checks on downloaded binary. (sha256)
 func (b *KubeBinary) SHA256Check() error {
 	output, err := sha256sum(b.Path())
@@ -159,6 +159,10 @@ func (s *SaveImages) Execute(runtime connector.Runtime) error {
 	if err := coreutil.Mkdir(dirName); err != nil {
 		return errors.Wrapf(errors.WithStack(err), "mkdir %s failed", dirName)
 	}
+	var base *kubekeyv1alpha2.ManifestSpec
+	if v, ok := s.PipelineCache.Get(common.ArtifactBaseSpec); ok {
+		base = v.(*kubekeyv1alpha2.ManifestSpec)
+	}
 	for _, image := range s.Manifest.Spec.Images {
 		if err := validateImageName(image); err != nil {
 			return err
@@ -172,6 +176,10 @@ func (s *SaveImages) Execute(runtime connector.Runtime) error {
 
 		srcName := fmt.Sprintf("docker://%s", image)
 		for _, platform := range s.Manifest.Spec.Arches {
+			if base != nil && baseHasImage(base, image, platform) {
+				logger.Log.Infof("%s (%s) is in the base artifact, skip it", image, platform)
+				continue
+			}
 			arch, variant := ParseArchVariant(platform)
 			// placeholder
 			if variant != "" {
@@ -369,3 +377,20 @@ func (p *PushManifest) Execute(_ connector.Runtime) error {
 
 	return nil
 }
+
+func baseHasImage(base *kubekeyv1alpha2.ManifestSpec, image, platform string) bool {
+	var hasImage, hasArch bool
+	for _, i := range base.Images {
+		if i == image {
+			hasImage = true
+			break
+		}
+	}
+	for _, a := range base.Arches {
+		if a == platform {
+			hasArch = true
+			break
+		}
+	}
+	return hasImage && hasArch
+}
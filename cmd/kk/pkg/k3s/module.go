@@ -17,10 +17,13 @@
 package k3s
 
 import (
+	"fmt"
 	"path/filepath"
 
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/bootstrap/precheck"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/action"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/prepare"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/task"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/k3s/templates"
@@ -30,6 +33,10 @@ type StatusModule struct {
 	common.KubeModule
 }
 
+func (s *StatusModule) Repeatable() bool {
+	return true
+}
+
 func (s *StatusModule) Init() {
 	s.Name = "StatusModule"
 	s.Desc = "Get cluster status"
@@ -325,6 +332,11 @@ func (d *DeleteClusterModule) Init() {
 
 type SaveKubeConfigModule struct {
 	common.KubeModule
+	Skip bool
+}
+
+func (s *SaveKubeConfigModule) IsSkip() bool {
+	return s.Skip
 }
 
 func (s *SaveKubeConfigModule) Init() {
@@ -341,3 +353,100 @@ func (s *SaveKubeConfigModule) Init() {
 		save,
 	}
 }
+
+type UpgradePreCheckModule struct {
+	common.KubeModule
+}
+
+func (u *UpgradePreCheckModule) Init() {
+	u.Name = "K3sUpgradePreCheckModule"
+	u.Desc = "Check the k3s upgrade plan"
+
+	checkVersion := &task.LocalTask{
+		Name:   "CheckUpgradeVersion",
+		Desc:   "Check the current and desired k3s versions",
+		Action: new(CheckUpgradeVersion),
+	}
+
+	ksVersionCheck := &task.RemoteTask{
+		Name:     "KsVersionCheck",
+		Desc:     "Check KubeSphere version",
+		Hosts:    u.Runtime.GetHostsByRole(common.Master),
+		Prepare:  new(common.OnlyFirstMaster),
+		Action:   new(precheck.KsVersionCheck),
+		Parallel: true,
+	}
+
+	nodesStatus := &task.RemoteTask{
+		Name:     "GetKubernetesNodesStatus",
+		Desc:     "Get kubernetes nodes status",
+		Hosts:    u.Runtime.GetHostsByRole(common.Master),
+		Prepare:  new(common.OnlyFirstMaster),
+		Action:   new(precheck.GetKubernetesNodesStatus),
+		Parallel: true,
+	}
+
+	u.Tasks = []task.Interface{
+		checkVersion,
+		ksVersionCheck,
+		nodesStatus,
+	}
+}
+
+// UpgradeModule upgrades the servers one at a time, then drains, upgrades and uncordons the agents one at a time.
+type UpgradeModule struct {
+	common.KubeModule
+}
+
+func (u *UpgradeModule) Init() {
+	u.Name = "K3sUpgradeModule"
+	u.Desc = "Upgrade k3s cluster"
+
+	masters := u.Runtime.GetHostsByRole(common.Master)
+	for _, server := range masters {
+		u.Tasks = append(u.Tasks, &task.RemoteTask{
+			Name:    "UpgradeK3sServer",
+			Desc:    fmt.Sprintf("Upgrade k3s server %s", server.GetName()),
+			Hosts:   []connector.Host{server},
+			Prepare: &KubeletVersionMismatch{Node: server.GetName()},
+			Action:  new(UpgradeK3sNode),
+		})
+	}
+
+	for _, agent := range u.Runtime.GetHostsByRole(common.Worker) {
+		if agent.IsRole(common.Master) {
+			continue
+		}
+		node := agent.GetName()
+		u.Tasks = append(u.Tasks,
+			&task.RemoteTask{
+				Name:  "DrainK3sAgent",
+				Desc:  fmt.Sprintf("Drain k3s agent %s", node),
+				Hosts: masters,
+				Prepare: &prepare.PrepareCollection{
+					new(common.OnlyFirstMaster),
+					&KubeletVersionMismatch{Node: node},
+				},
+				Action: &DrainAgent{Node: node},
+				Retry:  2,
+			},
+			&task.RemoteTask{
+				Name:    "UpgradeK3sAgent",
+				Desc:    fmt.Sprintf("Upgrade k3s agent %s", node),
+				Hosts:   []connector.Host{agent},
+				Prepare: &NodeDrained{Node: node},
+				Action:  new(UpgradeK3sNode),
+			},
+			&task.RemoteTask{
+				Name:  "UncordonK3sAgent",
+				Desc:  fmt.Sprintf("Uncordon k3s agent %s", node),
+				Hosts: masters,
+				Prepare: &prepare.PrepareCollection{
+					new(common.OnlyFirstMaster),
+					&NodeDrained{Node: node},
+				},
+				Action: &UncordonAgent{Node: node},
+				Retry:  3,
+			})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -211,7 +212,7 @@ func (g *GenerateK3sService) Execute(runtime connector.Runtime) error {
 			"IsMaster":                 host.IsRole(common.Master),
 			"IsDockerRuntime":          g.KubeConf.Cluster.Kubernetes.ContainerManager == common.Docker,
 			"ContainerRuntimeEndpoint": g.KubeConf.Cluster.Kubernetes.ContainerRuntimeEndpoint,
-			"NodeIP":                   host.GetInternalAddress(),
+			"NodeIP":                   strings.Join(host.GetInternalAddresses(), ","),
 			"HostName":                 host.GetName(),
 			"PodSubnet":                g.KubeConf.Cluster.Network.KubePodsCIDR,
 			"ServiceSubnet":            g.KubeConf.Cluster.Network.KubeServiceCIDR,
@@ -261,7 +262,7 @@ func (g *GenerateK3sServiceEnv) Execute(runtime connector.Runtime) error {
 		}
 	default:
 		for _, node := range runtime.GetHostsByRole(common.ETCD) {
-			endpoint := fmt.Sprintf("https://%s:%s", node.GetInternalAddress(), kubekeyapiv1alpha2.DefaultEtcdPort)
+			endpoint := fmt.Sprintf("https://%s:%s", util.BracketIP(node.GetInternalAddress()), kubekeyapiv1alpha2.DefaultEtcdPort)
 			endpointsList = append(endpointsList, endpoint)
 		}
 		externalEtcd.Endpoints = endpointsList
@@ -529,3 +530,152 @@ func (g *GenerateK3sRegistryConfig) Execute(runtime connector.Runtime) error {
 	}
 	return nil
 }
+
+// VersionMatches tells whether a k3s version such as v1.21.4+k3s1 is the desired Kubernetes version.
+func VersionMatches(current, desired string) bool {
+	c, err := versionutil.ParseGeneric(strings.TrimSpace(current))
+	if err != nil {
+		return false
+	}
+	d, err := versionutil.ParseGeneric(desired)
+	if err != nil {
+		return false
+	}
+	return c.Major() == d.Major() && c.Minor() == d.Minor() && c.Patch() == d.Patch()
+}
+
+func drainedKey(node string) string {
+	return fmt.Sprintf("k3sUpgradeDrained-%s", node)
+}
+
+// waitForCmd runs cmd until it succeeds or the timeout expires.
+func waitForCmd(runtime connector.Runtime, cmd string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		_, err := runtime.GetRunner().SudoCmd(cmd, false)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Wrapf(err, "%s did not succeed within %s", cmd, timeout)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
+
+func checkKubeletVersion(runtime connector.Runtime, node, desired string) error {
+	version, err := runtime.GetRunner().SudoCmd(fmt.Sprintf(
+		"/usr/local/bin/kubectl get node %s -o jsonpath='{.status.nodeInfo.kubeletVersion}'", node), false)
+	if err != nil {
+		return errors.Wrapf(errors.WithStack(err), "get kubelet version of node %s failed", node)
+	}
+	if !VersionMatches(version, desired) {
+		return errors.Errorf("node %s runs %s after the upgrade, expected %s", node, version, desired)
+	}
+	return nil
+}
+
+type CheckUpgradeVersion struct {
+	common.KubeAction
+}
+
+func (c *CheckUpgradeVersion) Execute(_ connector.Runtime) error {
+	if exist, _ := c.PipelineCache.GetMustBool(common.ClusterExist); !exist {
+		return errors.New("no k3s cluster was found on the master nodes")
+	}
+	v, ok := c.PipelineCache.Get(common.ClusterStatus)
+	if !ok {
+		return errors.New("get k3s cluster status by pipeline cache failed")
+	}
+	current := strings.TrimSpace(v.(*K3sStatus).Version)
+
+	currentVersion, err := versionutil.ParseGeneric(current)
+	if err != nil {
+		return errors.Wrapf(err, "parse current k3s version %s", current)
+	}
+	desiredVersion, err := versionutil.ParseGeneric(c.KubeConf.Cluster.Kubernetes.Version)
+	if err != nil {
+		return errors.Wrapf(err, "parse desired k3s version %s", c.KubeConf.Cluster.Kubernetes.Version)
+	}
+	if desiredVersion.LessThan(currentVersion) && !VersionMatches(current, c.KubeConf.Cluster.Kubernetes.Version) {
+		return errors.Errorf("downgrading k3s from %s to %s is not supported", current, c.KubeConf.Cluster.Kubernetes.Version)
+	}
+	if desiredVersion.Major() != currentVersion.Major() || desiredVersion.Minor() > currentVersion.Minor()+1 {
+		return errors.Errorf("k3s can only be upgraded one minor version at a time, %s to %s is not supported",
+			current, c.KubeConf.Cluster.Kubernetes.Version)
+	}
+
+	c.PipelineCache.Set(common.K8sVersion, current)
+	return nil
+}
+
+type UpgradeK3sNode struct {
+	common.KubeAction
+}
+
+func (u *UpgradeK3sNode) Execute(runtime connector.Runtime) error {
+	host := runtime.RemoteHost()
+
+	binariesMapObj, ok := u.PipelineCache.Get(common.KubeBinaries + "-" + host.GetArch())
+	if !ok {
+		return errors.New("get KubeBinary by pipeline cache failed")
+	}
+	binariesMap := binariesMapObj.(map[string]*files.KubeBinary)
+	if err := SyncKubeBinaries(&SyncKubeBinary{KubeAction: u.KubeAction}, runtime, binariesMap); err != nil {
+		return err
+	}
+
+	if _, err := runtime.GetRunner().SudoCmd("systemctl daemon-reload && systemctl restart k3s", false); err != nil {
+		return errors.Wrap(errors.WithStack(err), "restart k3s failed")
+	}
+
+	if !host.IsRole(common.Master) {
+		return waitForCmd(runtime, "systemctl is-active k3s", 2*time.Minute)
+	}
+
+	if err := waitForCmd(runtime, "/usr/local/bin/kubectl get --raw=/readyz", 5*time.Minute); err != nil {
+		return errors.Wrap(err, "k3s server is not healthy after the upgrade")
+	}
+	if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf(
+		"/usr/local/bin/kubectl wait --for=condition=Ready node/%s --timeout=300s", host.GetName()), true); err != nil {
+		return errors.Wrapf(errors.WithStack(err), "node %s is not ready after the upgrade", host.GetName())
+	}
+	return checkKubeletVersion(runtime, host.GetName(), u.KubeConf.Cluster.Kubernetes.Version)
+}
+
+type DrainAgent struct {
+	common.KubeAction
+	Node string
+}
+
+func (d *DrainAgent) Execute(runtime connector.Runtime) error {
+	if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf(
+		"/usr/local/bin/kubectl drain %s --delete-emptydir-data --ignore-daemonsets --timeout=5m --force", d.Node),
+		true); err != nil {
+		return errors.Wrapf(errors.WithStack(err), "drain node %s failed", d.Node)
+	}
+	d.PipelineCache.Set(drainedKey(d.Node), true)
+	return nil
+}
+
+type UncordonAgent struct {
+	common.KubeAction
+	Node string
+}
+
+func (u *UncordonAgent) Execute(runtime connector.Runtime) error {
+	if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf(
+		"/usr/local/bin/kubectl wait --for=condition=Ready node/%s --timeout=300s", u.Node), true); err != nil {
+		return errors.Wrapf(errors.WithStack(err), "node %s is not ready after the upgrade", u.Node)
+	}
+
+	if err := checkKubeletVersion(runtime, u.Node, u.KubeConf.Cluster.Kubernetes.Version); err != nil {
+		return err
+	}
+
+	if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf("/usr/local/bin/kubectl uncordon %s", u.Node), true); err != nil {
+		return errors.Wrapf(errors.WithStack(err), "uncordon node %s failed", u.Node)
+	}
+	u.PipelineCache.Delete(drainedKey(u.Node))
+	return nil
+}
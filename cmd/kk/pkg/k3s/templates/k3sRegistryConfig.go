@@ -21,12 +21,13 @@ import (
 
 	"github.com/lithammer/dedent"
 
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/utils"
 )
 
 var (
 	funcMap = template.FuncMap{"toYaml": utils.ToYAML, "indent": utils.Indent}
 	// k3sRegistryConfigTempl defines the template of k3s' registry.
-	K3sRegistryConfigTempl = template.Must(template.New("registries.yaml").Funcs(funcMap).Parse(
+	K3sRegistryConfigTempl = template.Must(util.ParseTemplate(template.New("registries.yaml").Funcs(funcMap),
 		dedent.Dedent(`{{ toYaml .Registries }}`)))
 )
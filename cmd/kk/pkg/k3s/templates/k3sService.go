@@ -20,11 +20,13 @@ import (
 	"text/template"
 
 	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 var (
 	// K3sService defines the template of kubelet service for systemd.
-	K3sService = template.Must(template.New("k3s.service").Parse(
+	K3sService = template.Must(util.ParseTemplate(template.New("k3s.service"),
 		dedent.Dedent(`[Unit]
 Description=Lightweight Kubernetes
 Documentation=https://k3s.io
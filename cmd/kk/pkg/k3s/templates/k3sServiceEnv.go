@@ -19,10 +19,12 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 // K3sServiceEnv defines the template of kubelet's Env for the kubelet's systemd service.
-var K3sServiceEnv = template.Must(template.New("k3s.service.env").Parse(
+var K3sServiceEnv = template.Must(util.ParseTemplate(template.New("k3s.service.env"),
 	dedent.Dedent(`# Note: This dropin only works with k3s
 {{ if .IsMaster }}
 K3S_DATASTORE_ENDPOINT={{ .DataStoreEndPoint }}
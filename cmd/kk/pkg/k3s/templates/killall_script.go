@@ -20,10 +20,12 @@ import (
 	"text/template"
 
 	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 // K3sKillallScript defines the template of k3s-killall script.
-var K3sKillallScript = template.Must(template.New("k3s-killall.sh").Parse(
+var K3sKillallScript = template.Must(util.ParseTemplate(template.New("k3s-killall.sh"),
 	dedent.Dedent(`#!/bin/sh
 [ $(id -u) -eq 0 ] || exec sudo $0 $@
 
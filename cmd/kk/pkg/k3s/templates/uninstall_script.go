@@ -19,10 +19,12 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 // K3sUninstallScript defines the template of k3s-killall script.
-var K3sUninstallScript = template.Must(template.New("k3s-uninstall.sh").Parse(
+var K3sUninstallScript = template.Must(util.ParseTemplate(template.New("k3s-uninstall.sh"),
 	dedent.Dedent(`#!/bin/sh
 set -x
 [ $(id -u) -eq 0 ] || exec sudo $0 $@
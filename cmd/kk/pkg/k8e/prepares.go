@@ -17,6 +17,8 @@
 package k8e
 
 import (
+	"fmt"
+
 	"github.com/pkg/errors"
 
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
@@ -60,3 +62,30 @@ func (c *ClusterIsExist) PreCheck(_ connector.Runtime) (bool, error) {
 		return false, errors.New("get k8e cluster status by pipeline cache failed")
 	}
 }
+
+// KubeletVersionMismatch checks whether the kubelet of the node does not run the desired version yet.
+// It runs kubectl, so the task has to run on a server.
+type KubeletVersionMismatch struct {
+	common.KubePrepare
+	Node string
+}
+
+func (k *KubeletVersionMismatch) PreCheck(runtime connector.Runtime) (bool, error) {
+	output, err := runtime.GetRunner().SudoCmd(fmt.Sprintf(
+		"/usr/local/bin/kubectl get node %s -o jsonpath='{.status.nodeInfo.kubeletVersion}'", k.Node), false)
+	if err != nil {
+		return false, errors.Wrapf(errors.WithStack(err), "get kubelet version of node %s failed", k.Node)
+	}
+	return !VersionMatches(output, k.KubeConf.Cluster.Kubernetes.Version), nil
+}
+
+// NodeDrained checks whether the node has been drained for the upgrade.
+type NodeDrained struct {
+	common.KubePrepare
+	Node string
+}
+
+func (n *NodeDrained) PreCheck(_ connector.Runtime) (bool, error) {
+	drained, _ := n.PipelineCache.GetMustBool(drainedKey(n.Node))
+	return drained, nil
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -203,7 +204,7 @@ func (g *GenerateK8eService) Execute(runtime connector.Runtime) error {
 		Data: util.Data{
 			"Server":            server,
 			"IsMaster":          host.IsRole(common.Master),
-			"NodeIP":            host.GetInternalAddress(),
+			"NodeIP":            strings.Join(host.GetInternalAddresses(), ","),
 			"HostName":          host.GetName(),
 			"PodSubnet":         g.KubeConf.Cluster.Network.KubePodsCIDR,
 			"ServiceSubnet":     g.KubeConf.Cluster.Network.KubeServiceCIDR,
@@ -253,7 +254,7 @@ func (g *GenerateK8eServiceEnv) Execute(runtime connector.Runtime) error {
 		}
 	default:
 		for _, node := range runtime.GetHostsByRole(common.ETCD) {
-			endpoint := fmt.Sprintf("https://%s:%s", node.GetInternalAddress(), kubekeyapiv1alpha2.DefaultEtcdPort)
+			endpoint := fmt.Sprintf("https://%s:%s", util.BracketIP(node.GetInternalAddress()), kubekeyapiv1alpha2.DefaultEtcdPort)
 			endpointsList = append(endpointsList, endpoint)
 		}
 		externalEtcd.Endpoints = endpointsList
@@ -456,3 +457,152 @@ func (s *SaveKubeConfig) Execute(_ connector.Runtime) error {
 	}
 	return nil
 }
+
+// VersionMatches tells whether a k8e version such as v1.21.4+k8e1 is the desired Kubernetes version.
+func VersionMatches(current, desired string) bool {
+	c, err := versionutil.ParseGeneric(strings.TrimSpace(current))
+	if err != nil {
+		return false
+	}
+	d, err := versionutil.ParseGeneric(desired)
+	if err != nil {
+		return false
+	}
+	return c.Major() == d.Major() && c.Minor() == d.Minor() && c.Patch() == d.Patch()
+}
+
+func drainedKey(node string) string {
+	return fmt.Sprintf("k8eUpgradeDrained-%s", node)
+}
+
+// waitForCmd runs cmd until it succeeds or the timeout expires.
+func waitForCmd(runtime connector.Runtime, cmd string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		_, err := runtime.GetRunner().SudoCmd(cmd, false)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Wrapf(err, "%s did not succeed within %s", cmd, timeout)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
+
+func checkKubeletVersion(runtime connector.Runtime, node, desired string) error {
+	version, err := runtime.GetRunner().SudoCmd(fmt.Sprintf(
+		"/usr/local/bin/kubectl get node %s -o jsonpath='{.status.nodeInfo.kubeletVersion}'", node), false)
+	if err != nil {
+		return errors.Wrapf(errors.WithStack(err), "get kubelet version of node %s failed", node)
+	}
+	if !VersionMatches(version, desired) {
+		return errors.Errorf("node %s runs %s after the upgrade, expected %s", node, version, desired)
+	}
+	return nil
+}
+
+type CheckUpgradeVersion struct {
+	common.KubeAction
+}
+
+func (c *CheckUpgradeVersion) Execute(_ connector.Runtime) error {
+	if exist, _ := c.PipelineCache.GetMustBool(common.ClusterExist); !exist {
+		return errors.New("no k8e cluster was found on the master nodes")
+	}
+	v, ok := c.PipelineCache.Get(common.ClusterStatus)
+	if !ok {
+		return errors.New("get k8e cluster status by pipeline cache failed")
+	}
+	current := strings.TrimSpace(v.(*K8eStatus).Version)
+
+	currentVersion, err := versionutil.ParseGeneric(current)
+	if err != nil {
+		return errors.Wrapf(err, "parse current k8e version %s", current)
+	}
+	desiredVersion, err := versionutil.ParseGeneric(c.KubeConf.Cluster.Kubernetes.Version)
+	if err != nil {
+		return errors.Wrapf(err, "parse desired k8e version %s", c.KubeConf.Cluster.Kubernetes.Version)
+	}
+	if desiredVersion.LessThan(currentVersion) && !VersionMatches(current, c.KubeConf.Cluster.Kubernetes.Version) {
+		return errors.Errorf("downgrading k8e from %s to %s is not supported", current, c.KubeConf.Cluster.Kubernetes.Version)
+	}
+	if desiredVersion.Major() != currentVersion.Major() || desiredVersion.Minor() > currentVersion.Minor()+1 {
+		return errors.Errorf("k8e can only be upgraded one minor version at a time, %s to %s is not supported",
+			current, c.KubeConf.Cluster.Kubernetes.Version)
+	}
+
+	c.PipelineCache.Set(common.K8sVersion, current)
+	return nil
+}
+
+type UpgradeK8eNode struct {
+	common.KubeAction
+}
+
+func (u *UpgradeK8eNode) Execute(runtime connector.Runtime) error {
+	host := runtime.RemoteHost()
+
+	binariesMapObj, ok := u.PipelineCache.Get(common.KubeBinaries + "-" + host.GetArch())
+	if !ok {
+		return errors.New("get KubeBinary by pipeline cache failed")
+	}
+	binariesMap := binariesMapObj.(map[string]*files.KubeBinary)
+	if err := SyncKubeBinaries(runtime, binariesMap); err != nil {
+		return err
+	}
+
+	if _, err := runtime.GetRunner().SudoCmd("systemctl daemon-reload && systemctl restart k8e", false); err != nil {
+		return errors.Wrap(errors.WithStack(err), "restart k8e failed")
+	}
+
+	if !host.IsRole(common.Master) {
+		return waitForCmd(runtime, "systemctl is-active k8e", 2*time.Minute)
+	}
+
+	if err := waitForCmd(runtime, "/usr/local/bin/kubectl get --raw=/readyz", 5*time.Minute); err != nil {
+		return errors.Wrap(err, "k8e server is not healthy after the upgrade")
+	}
+	if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf(
+		"/usr/local/bin/kubectl wait --for=condition=Ready node/%s --timeout=300s", host.GetName()), true); err != nil {
+		return errors.Wrapf(errors.WithStack(err), "node %s is not ready after the upgrade", host.GetName())
+	}
+	return checkKubeletVersion(runtime, host.GetName(), u.KubeConf.Cluster.Kubernetes.Version)
+}
+
+type DrainAgent struct {
+	common.KubeAction
+	Node string
+}
+
+func (d *DrainAgent) Execute(runtime connector.Runtime) error {
+	if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf(
+		"/usr/local/bin/kubectl drain %s --delete-emptydir-data --ignore-daemonsets --timeout=5m --force", d.Node),
+		true); err != nil {
+		return errors.Wrapf(errors.WithStack(err), "drain node %s failed", d.Node)
+	}
+	d.PipelineCache.Set(drainedKey(d.Node), true)
+	return nil
+}
+
+type UncordonAgent struct {
+	common.KubeAction
+	Node string
+}
+
+func (u *UncordonAgent) Execute(runtime connector.Runtime) error {
+	if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf(
+		"/usr/local/bin/kubectl wait --for=condition=Ready node/%s --timeout=300s", u.Node), true); err != nil {
+		return errors.Wrapf(errors.WithStack(err), "node %s is not ready after the upgrade", u.Node)
+	}
+
+	if err := checkKubeletVersion(runtime, u.Node, u.KubeConf.Cluster.Kubernetes.Version); err != nil {
+		return err
+	}
+
+	if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf("/usr/local/bin/kubectl uncordon %s", u.Node), true); err != nil {
+		return errors.Wrapf(errors.WithStack(err), "uncordon node %s failed", u.Node)
+	}
+	u.PipelineCache.Delete(drainedKey(u.Node))
+	return nil
+}
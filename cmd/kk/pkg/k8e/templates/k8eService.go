@@ -20,11 +20,13 @@ import (
 	"text/template"
 
 	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 var (
 	// K8eService defines the template of kubelet service for systemd.
-	K8eService = template.Must(template.New("k8e.service").Parse(
+	K8eService = template.Must(util.ParseTemplate(template.New("k8e.service"),
 		dedent.Dedent(`[Unit]
 Description=Simple Kubernetes Distribution
 Documentation=https://getk8e.com
@@ -20,10 +20,12 @@ import (
 	"text/template"
 
 	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 // K8eServiceEnv defines the template of kubelet's Env for the kubelet's systemd service.
-var K8eServiceEnv = template.Must(template.New("k8e.service.env").Parse(
+var K8eServiceEnv = template.Must(util.ParseTemplate(template.New("k8e.service.env"),
 	dedent.Dedent(`# Note: This dropin only works with k3s
 {{ if .IsMaster }}
 K8E_DATASTORE_ENDPOINT={{ .DataStoreEndPoint }}
@@ -20,10 +20,12 @@ import (
 	"text/template"
 
 	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 // K8eKillallScript defines the template of k3s-killall script.
-var K8eKillallScript = template.Must(template.New("k8e-killall.sh").Parse(
+var K8eKillallScript = template.Must(util.ParseTemplate(template.New("k8e-killall.sh"),
 	dedent.Dedent(`#!/bin/sh
 [ $(id -u) -eq 0 ] || exec sudo $0 $@
 
@@ -20,10 +20,12 @@ import (
 	"text/template"
 
 	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 // K8eUninstallScript defines the template of k3s-killall script.
-var K8eUninstallScript = template.Must(template.New("k8e-uninstall.sh").Parse(
+var K8eUninstallScript = template.Must(util.ParseTemplate(template.New("k8e-uninstall.sh"),
 	dedent.Dedent(`#!/bin/sh
 set -x
 [ $(id -u) -eq 0 ] || exec sudo $0 $@
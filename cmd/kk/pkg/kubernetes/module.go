@@ -27,6 +27,7 @@ import (
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/binaries"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/action"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/prepare"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/task"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/images"
@@ -38,6 +39,10 @@ type StatusModule struct {
 	common.KubeModule
 }
 
+func (k *StatusModule) Repeatable() bool {
+	return true
+}
+
 func (k *StatusModule) Init() {
 	k.Name = "KubernetesStatusModule"
 	k.Desc = "Get kubernetes cluster status"
@@ -457,6 +462,91 @@ func (d *DeleteKubeNodeModule) Init() {
 	}
 }
 
+type RemoveNodesModule struct {
+	common.KubeModule
+	Skip  bool
+	Nodes []string
+	// Hosts are the removed nodes which can be reached, they are reset once deleted.
+	Hosts []connector.Host
+}
+
+func (r *RemoveNodesModule) IsSkip() bool {
+	return r.Skip
+}
+
+func (r *RemoveNodesModule) Init() {
+	r.Name = "RemoveNodesModule"
+	r.Desc = "Remove the nodes which are not in the configuration"
+
+	drain := &task.RemoteTask{
+		Name:    "DrainNodes",
+		Desc:    "Nodes safely evict all pods",
+		Hosts:   r.Runtime.GetHostsByRole(common.Master),
+		Prepare: new(common.OnlyFirstMaster),
+		Action:  &DrainNodes{Nodes: r.Nodes},
+		Retry:   2,
+	}
+
+	deleteNodes := &task.RemoteTask{
+		Name:    "DeleteNodes",
+		Desc:    "Delete the nodes using kubectl",
+		Hosts:   r.Runtime.GetHostsByRole(common.Master),
+		Prepare: new(common.OnlyFirstMaster),
+		Action:  &KubectlDeleteNodes{Nodes: r.Nodes},
+		Retry:   5,
+	}
+
+	kubeadmReset := &task.RemoteTask{
+		Name:     "KubeadmReset",
+		Desc:     "Reset the removed nodes using kubeadm",
+		Hosts:    r.Hosts,
+		Action:   new(KubeadmReset),
+		Parallel: true,
+	}
+
+	r.Tasks = []task.Interface{
+		drain,
+		deleteNodes,
+		kubeadmReset,
+	}
+}
+
+type ReconfigureKubeletModule struct {
+	common.KubeModule
+	Skip  bool
+	Hosts []connector.Host
+}
+
+func (r *ReconfigureKubeletModule) IsSkip() bool {
+	return r.Skip
+}
+
+func (r *ReconfigureKubeletModule) Init() {
+	r.Name = "ReconfigureKubeletModule"
+	r.Desc = "Reconfigure the kubelet args"
+
+	generateKubeletEnv := &task.RemoteTask{
+		Name:     "GenerateKubeletEnv",
+		Desc:     "Generate kubelet env",
+		Hosts:    r.Hosts,
+		Action:   new(GenerateKubeletEnv),
+		Parallel: true,
+	}
+
+	restart := &task.RemoteTask{
+		Name:   "RestartKubelet",
+		Desc:   "Restart kubelet service",
+		Hosts:  r.Hosts,
+		Action: new(RestartKubelet),
+		Retry:  5,
+	}
+
+	r.Tasks = []task.Interface{
+		generateKubeletEnv,
+		restart,
+	}
+}
+
 type SetUpgradePlanModule struct {
 	common.KubeModule
 	Step UpgradeStep
@@ -524,6 +614,15 @@ func (p *ProgressiveUpgradeModule) Init() {
 		Parallel: true,
 	}
 
+	backupControlPlane := &task.RemoteTask{
+		Name:     "BackupControlPlane",
+		Desc:     "Backup binaries, manifests, certs and kubelet config on master",
+		Hosts:    p.Runtime.GetHostsByRole(common.Master),
+		Prepare:  new(NotEqualPlanVersion),
+		Action:   new(BackupControlPlane),
+		Parallel: true,
+	}
+
 	syncBinary := &task.RemoteTask{
 		Name:     "SyncKubeBinary",
 		Desc:     "Synchronize kubernetes binaries",
@@ -631,6 +730,7 @@ func (p *ProgressiveUpgradeModule) Init() {
 		nextVersion,
 		download,
 		pull,
+		backupControlPlane,
 		syncBinary,
 		upgradeKubeMaster,
 		clusterStatus,
@@ -669,6 +769,11 @@ func (p *ProgressiveUpgradeModule) Until() (*bool, error) {
 
 type SaveKubeConfigModule struct {
 	common.KubeModule
+	Skip bool
+}
+
+func (s *SaveKubeConfigModule) IsSkip() bool {
+	return s.Skip
 }
 
 func (s *SaveKubeConfigModule) Init() {
@@ -0,0 +1,69 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/ending"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
+)
+
+// RestoreControlPlane restores the binaries, static pod manifests, certs and kubelet config saved by
+// BackupControlPlane on the master which kubeadm failed to upgrade.
+type RestoreControlPlane struct {
+	common.KubeRollback
+}
+
+func (r *RestoreControlPlane) Execute(runtime connector.Runtime, result *ending.ActionResult) error {
+	if result.Status != ending.FAILED {
+		return nil
+	}
+
+	host := runtime.RemoteHost()
+	exist, err := runtime.GetRunner().DirExist(controlPlaneBackupDir)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.Errorf("no control plane backup is found on %s", host.GetName())
+	}
+
+	logger.Log.Messagef(host.GetName(), "restore control plane from %s", controlPlaneBackupDir)
+	restoreCmd := fmt.Sprintf("systemctl stop kubelet && "+
+		"cp -af %[1]s/bin/. %[2]s/ && "+
+		"rm -rf %[3]s %[4]s && cp -af %[1]s/kubernetes/. %[5]s/ && "+
+		"cp -af %[1]s/kubelet/. /var/lib/kubelet/ && "+
+		"systemctl daemon-reload && systemctl restart kubelet",
+		controlPlaneBackupDir, common.BinDir, common.KubeManifestDir, common.KubeCertDir, common.KubeConfigDir)
+	if _, err := runtime.GetRunner().SudoCmd(restoreCmd, false); err != nil {
+		return errors.Wrap(errors.WithStack(err), "restore control plane failed")
+	}
+
+	// kubelet brings the static pods of the previous version back from the restored manifests.
+	waitCmd := fmt.Sprintf("timeout 300 /bin/sh -c "+
+		"'until %s/kubectl --kubeconfig %s/admin.conf get --raw=/readyz > /dev/null 2>&1; do sleep 5; done'",
+		common.BinDir, common.KubeConfigDir)
+	if _, err := runtime.GetRunner().SudoCmd(waitCmd, false); err != nil {
+		return errors.Wrap(errors.WithStack(err), "kube-apiserver is not healthy after the rollback")
+	}
+	return nil
+}
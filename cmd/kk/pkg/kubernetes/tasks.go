@@ -187,7 +187,7 @@ func (g *GenerateKubeletEnv) Execute(runtime connector.Runtime) error {
 		Template: templates.KubeletEnv,
 		Dst:      filepath.Join("/etc/systemd/system/kubelet.service.d", templates.KubeletEnv.Name()),
 		Data: util.Data{
-			"NodeIP":           host.GetInternalAddress(),
+			"NodeIP":           strings.Join(host.GetInternalAddresses(), ","),
 			"Hostname":         host.GetName(),
 			"ContainerRuntime": "",
 			"KubeletArgs":      g.KubeConf.Cluster.Kubernetes.KubeletArgs,
@@ -224,7 +224,7 @@ func (g *GenerateKubeadmConfig) Execute(runtime connector.Runtime) error {
 		switch g.KubeConf.Cluster.Etcd.Type {
 		case kubekeyv1alpha2.KubeKey:
 			for _, host := range runtime.GetHostsByRole(common.ETCD) {
-				endpoint := fmt.Sprintf("https://%s:%s", host.GetInternalAddress(), kubekeyv1alpha2.DefaultEtcdPort)
+				endpoint := fmt.Sprintf("https://%s:%s", util.BracketIP(host.GetInternalAddress()), kubekeyv1alpha2.DefaultEtcdPort)
 				endpointsList = append(endpointsList, endpoint)
 			}
 			externalEtcd.Endpoints = endpointsList
@@ -244,13 +244,18 @@ func (g *GenerateKubeadmConfig) Execute(runtime connector.Runtime) error {
 			altNames := etcd.GenerateAltName(g.KubeConf, &runtime)
 			etcdCertSANs = append(etcdCertSANs, altNames.DNSNames...)
 			for _, ip := range altNames.IPs {
-				etcdCertSANs = append(etcdCertSANs, string(ip))
+				etcdCertSANs = append(etcdCertSANs, ip.String())
 			}
 		}
 
-		_, ApiServerArgs := util.GetArgs(templates.GetApiServerArgs(g.WithSecurityEnhancement, g.KubeConf.Cluster.Kubernetes.EnableAudit()), g.KubeConf.Cluster.Kubernetes.ApiServerArgs)
-		_, ControllerManagerArgs := util.GetArgs(templates.GetControllermanagerArgs(g.KubeConf.Cluster.Kubernetes.Version, g.WithSecurityEnhancement), g.KubeConf.Cluster.Kubernetes.ControllerManagerArgs)
-		_, SchedulerArgs := util.GetArgs(templates.GetSchedulerArgs(g.WithSecurityEnhancement), g.KubeConf.Cluster.Kubernetes.SchedulerArgs)
+		defaultControllerManagerArgs := templates.WithBindAddressFamily(templates.GetControllermanagerArgs(g.KubeConf.Cluster.Kubernetes.Version, g.WithSecurityEnhancement), host.GetInternalAddress())
+		for k, v := range templates.GetNodeCidrMaskSizeArgs(g.KubeConf) {
+			defaultControllerManagerArgs[k] = v
+		}
+
+		_, ApiServerArgs := util.GetArgs(templates.WithBindAddressFamily(templates.GetApiServerArgs(g.WithSecurityEnhancement, g.KubeConf.Cluster.Kubernetes.EnableAudit()), host.GetInternalAddress()), g.KubeConf.Cluster.Kubernetes.ApiServerArgs)
+		_, ControllerManagerArgs := util.GetArgs(defaultControllerManagerArgs, g.KubeConf.Cluster.Kubernetes.ControllerManagerArgs)
+		_, SchedulerArgs := util.GetArgs(templates.WithBindAddressFamily(templates.GetSchedulerArgs(g.WithSecurityEnhancement), host.GetInternalAddress()), g.KubeConf.Cluster.Kubernetes.SchedulerArgs)
 
 		checkCgroupDriver, err := templates.GetKubeletCgroupDriver(runtime, g.KubeConf)
 		if err != nil {
@@ -560,6 +565,10 @@ func (d *DrainNode) Execute(runtime connector.Runtime) error {
 	if !ok {
 		return errors.New("get dstNode failed by pipeline cache")
 	}
+	return drainNode(runtime, nodeName.(string))
+}
+
+func drainNode(runtime connector.Runtime, nodeName string) error {
 	if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf(
 		"/usr/local/bin/kubectl drain %s --delete-emptydir-data --ignore-daemonsets --timeout=2m --force", nodeName),
 		true); err != nil {
@@ -577,6 +586,10 @@ func (k *KubectlDeleteNode) Execute(runtime connector.Runtime) error {
 	if !ok {
 		return errors.New("get dstNode failed by pipeline cache")
 	}
+	return deleteNode(runtime, nodeName.(string))
+}
+
+func deleteNode(runtime connector.Runtime, nodeName string) error {
 	if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf(
 		"/usr/local/bin/kubectl delete node %s", nodeName),
 		true); err != nil {
@@ -585,6 +598,34 @@ func (k *KubectlDeleteNode) Execute(runtime connector.Runtime) error {
 	return nil
 }
 
+type DrainNodes struct {
+	common.KubeAction
+	Nodes []string
+}
+
+func (d *DrainNodes) Execute(runtime connector.Runtime) error {
+	for _, node := range d.Nodes {
+		if err := drainNode(runtime, node); err != nil {
+			return errors.Wrapf(err, "node %s", node)
+		}
+	}
+	return nil
+}
+
+type KubectlDeleteNodes struct {
+	common.KubeAction
+	Nodes []string
+}
+
+func (k *KubectlDeleteNodes) Execute(runtime connector.Runtime) error {
+	for _, node := range k.Nodes {
+		if err := deleteNode(runtime, node); err != nil {
+			return errors.Wrapf(err, "node %s", node)
+		}
+	}
+	return nil
+}
+
 type SetUpgradePlan struct {
 	common.KubeAction
 	Step UpgradeStep
@@ -699,6 +740,26 @@ func (r *RestartKubelet) Execute(runtime connector.Runtime) error {
 	return nil
 }
 
+// controlPlaneBackupDir keeps the binaries, the /etc/kubernetes dir and the kubelet config of a master
+// from before its upgrade, RestoreControlPlane restores them if kubeadm fails to upgrade the master.
+var controlPlaneBackupDir = filepath.Join(common.KubeUpgradeBackupDir, "control-plane")
+
+type BackupControlPlane struct {
+	common.KubeAction
+}
+
+func (b *BackupControlPlane) Execute(runtime connector.Runtime) error {
+	backupCmd := fmt.Sprintf("rm -rf %[1]s && mkdir -p %[1]s/bin %[1]s/kubelet && "+
+		"cp -a %[2]s/kubeadm %[2]s/kubelet %[2]s/kubectl %[1]s/bin/ && "+
+		"cp -a %[3]s %[1]s/kubernetes && "+
+		"for f in /var/lib/kubelet/config.yaml /var/lib/kubelet/kubeadm-flags.env; do if [ -f $f ]; then cp -a $f %[1]s/kubelet/; fi; done",
+		controlPlaneBackupDir, common.BinDir, common.KubeConfigDir)
+	if _, err := runtime.GetRunner().SudoCmd(backupCmd, false); err != nil {
+		return errors.Wrap(errors.WithStack(err), "backup control plane failed")
+	}
+	return nil
+}
+
 type UpgradeKubeMaster struct {
 	common.KubeAction
 	ModuleName string
@@ -770,6 +831,7 @@ func KubeadmUpgradeTasks(runtime connector.Runtime, u *UpgradeKubeMaster) error
 		Hosts:    []connector.Host{host},
 		Prepare:  new(NotEqualDesiredVersion),
 		Action:   new(KubeadmUpgrade),
+		Rollback: new(RestoreControlPlane),
 		Parallel: false,
 		Retry:    3,
 	}
@@ -793,6 +855,7 @@ func KubeadmUpgradeTasks(runtime connector.Runtime, u *UpgradeKubeMaster) error
 		t := tasks[i]
 		t.Init(runtime, u.ModuleCache, u.PipelineCache)
 		if res := t.Execute(); res.IsFailed() {
+			t.ExecuteRollback()
 			return res.CombineErr()
 		}
 	}
@@ -19,10 +19,12 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 // AuditPolicy defines the template of kube-apiserver audit-policy.
-var AuditPolicy = template.Must(template.New("audit-policy.yaml").Parse(
+var AuditPolicy = template.Must(util.ParseTemplate(template.New("audit-policy.yaml"),
 	dedent.Dedent(`apiVersion: audit.k8s.io/v1
 kind: Policy
 rules:
@@ -149,7 +151,7 @@ rules:
     `)))
 
 // AuditWebhook defines the template of kube-apiserver audit-webhook.
-var AuditWebhook = template.Must(template.New("audit-webhook.yaml").Parse(
+var AuditWebhook = template.Must(util.ParseTemplate(template.New("audit-webhook.yaml"),
 	dedent.Dedent(`apiVersion: v1
 kind: Config
 clusters:
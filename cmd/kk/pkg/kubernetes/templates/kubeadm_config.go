@@ -29,12 +29,13 @@ import (
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/utils"
 )
 
 var (
 	// KubeadmConfig defines the template of kubeadm configuration file.
-	KubeadmConfig = template.Must(template.New("kubeadm-config.yaml").Funcs(utils.FuncMap).Parse(
+	KubeadmConfig = template.Must(util.ParseTemplate(template.New("kubeadm-config.yaml").Funcs(utils.FuncMap),
 		dedent.Dedent(`
 {{- if .IsInitCluster -}}
 ---
@@ -94,7 +95,6 @@ apiServer:
 {{- end }}
 controllerManager:
   extraArgs:
-    node-cidr-mask-size: "{{ .NodeCidrMaskSize }}"
 {{ toYaml .ControllerManagerArgs | indent 4 }}
   extraVolumes:
   - name: host-time
@@ -249,6 +249,40 @@ func GetSchedulerArgs(securityEnhancement bool) map[string]string {
 	return SchedulerArgs
 }
 
+// GetNodeCidrMaskSizeArgs returns the controller-manager node CIDR mask size flags matching the pod CIDR families.
+func GetNodeCidrMaskSizeArgs(kubeConf *common.KubeConf) map[string]string {
+	network := kubeConf.Cluster.Network
+	ipv4Size := fmt.Sprintf("%d", kubeConf.Cluster.Kubernetes.NodeCidrMaskSize)
+	ipv6Size := fmt.Sprintf("%d", kubeConf.Cluster.Kubernetes.NodeCidrMaskSizeIPv6)
+
+	switch {
+	case network.KubePodsIPv4CIDR() != "" && network.KubePodsIPv6CIDR() != "":
+		return map[string]string{
+			"node-cidr-mask-size-ipv4": ipv4Size,
+			"node-cidr-mask-size-ipv6": ipv6Size,
+		}
+	case network.KubePodsIPv6CIDR() != "":
+		return map[string]string{"node-cidr-mask-size": ipv6Size}
+	default:
+		return map[string]string{"node-cidr-mask-size": ipv4Size}
+	}
+}
+
+// WithBindAddressFamily rewrites the default wildcard and loopback bind addresses to IPv6 when the node's primary address is IPv6.
+func WithBindAddressFamily(args map[string]string, address string) map[string]string {
+	cp := copyStringMap(args)
+	if !util.IsIPv6(address) {
+		return cp
+	}
+	switch cp["bind-address"] {
+	case "0.0.0.0":
+		cp["bind-address"] = "::"
+	case "127.0.0.1":
+		cp["bind-address"] = "::1"
+	}
+	return cp
+}
+
 func UpdateFeatureGatesConfiguration(args map[string]string, kubeConf *common.KubeConf) map[string]string {
 	var featureGates []string
 
@@ -19,10 +19,12 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 // KubeletEnv defines the template of kubelet's Env for the kubelet's systemd service.
-var KubeletEnv = template.Must(template.New("10-kubeadm.conf").Parse(
+var KubeletEnv = template.Must(util.ParseTemplate(template.New("10-kubeadm.conf"),
 	dedent.Dedent(`# Note: This dropin only works with kubeadm and kubelet v1.11+
 [Service]
 Environment="KUBELET_KUBECONFIG_ARGS=--bootstrap-kubeconfig=/etc/kubernetes/bootstrap-kubelet.conf --kubeconfig=/etc/kubernetes/kubelet.conf"
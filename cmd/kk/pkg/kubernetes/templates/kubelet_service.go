@@ -19,10 +19,12 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 // KubeletService defines the template of kubelete service for systemd.
-var KubeletService = template.Must(template.New("kubelet.service").Parse(
+var KubeletService = template.Must(util.ParseTemplate(template.New("kubelet.service"),
 	dedent.Dedent(`[Unit]
 Description=kubelet: The Kubernetes Node Agent
 Documentation=http://kubernetes.io/docs/
@@ -25,9 +25,10 @@ import (
 	kubekeyapiv1alpha2 "github.com/kubesphere/kubekey/v3/cmd/kk/apis/kubekey/v1alpha2"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var HaproxyConfig = template.Must(template.New("haproxy.cfg").Parse(
+var HaproxyConfig = template.Must(util.ParseTemplate(template.New("haproxy.cfg"),
 	dedent.Dedent(`
 global
     maxconn                 4000
@@ -19,9 +19,11 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var HaproxyManifest = template.Must(template.New("haproxy.yaml").Parse(
+var HaproxyManifest = template.Must(util.ParseTemplate(template.New("haproxy.yaml"),
 	dedent.Dedent(`
 apiVersion: v1
 kind: Pod
@@ -20,9 +20,11 @@ import (
 	"text/template"
 
 	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var K3sKubevipManifest = template.Must(template.New("kube-vip-rbac.yaml").Parse(
+var K3sKubevipManifest = template.Must(util.ParseTemplate(template.New("kube-vip-rbac.yaml"),
 	dedent.Dedent(`{{ if .BGPMode }}
 apiVersion: v1
 kind: ServiceAccount
@@ -20,9 +20,11 @@ import (
 	"text/template"
 
 	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var KubevipManifest = template.Must(template.New("kube-vip.yaml").Parse(
+var KubevipManifest = template.Must(util.ParseTemplate(template.New("kube-vip.yaml"),
 	dedent.Dedent(`{{ if .BGPMode }}
 apiVersion: v1
 kind: Pod
@@ -47,11 +47,13 @@ func NewUpgradeBinaryPipeline(runtime *common.KubeRuntime) error {
 }
 
 func UpgradeBinary(args common.Argument, downloadCmd string) error {
-	args.DownloadCommand = func(path, url string) string {
-		// this is an extension point for downloading tools, for example users can set the timeout, proxy or retry under
-		// some poor network environment. Or users even can choose another cli, it might be wget.
-		// perhaps we should have a build-in download function instead of totally rely on the external one
-		return fmt.Sprintf(downloadCmd, path, url)
+	// the built-in downloader is used unless a download command is defined.
+	if downloadCmd != "" {
+		args.DownloadCommand = func(path, url string) string {
+			// this is an extension point for downloading tools, for example users can set the timeout, proxy or retry under
+			// some poor network environment. Or users even can choose another cli, it might be wget.
+			return fmt.Sprintf(downloadCmd, path, url)
+		}
 	}
 	var loaderType string
 
@@ -48,7 +48,7 @@ func NewAddNodesPipeline(runtime *common.KubeRuntime) error {
 		&precheck.GreetingsModule{},
 		&customscripts.CustomScriptsModule{Phase: "PreInstall", Scripts: runtime.Cluster.System.PreInstall},
 		&precheck.NodePreCheckModule{},
-		&confirm.InstallConfirmModule{},
+		&confirm.InstallConfirmModule{Skip: runtime.Arg.DryRun},
 		&artifact.UnArchiveModule{Skip: noArtifact},
 		&os.RepositoryModule{Skip: noArtifact || !runtime.Arg.InstallPackages},
 		&binaries.NodeBinariesModule{},
@@ -75,9 +75,11 @@ func NewAddNodesPipeline(runtime *common.KubeRuntime) error {
 	}
 
 	p := pipeline.Pipeline{
-		Name:    "AddNodesPipeline",
-		Modules: m,
-		Runtime: runtime,
+		Name:       "AddNodesPipeline",
+		Modules:    m,
+		Runtime:    runtime,
+		Resume:     runtime.Arg.Resume,
+		ConfigHash: runtime.ConfigHash,
 	}
 	if err := p.Start(); err != nil {
 		return err
@@ -112,9 +114,11 @@ func NewK3sAddNodesPipeline(runtime *common.KubeRuntime) error {
 	}
 
 	p := pipeline.Pipeline{
-		Name:    "AddNodesPipeline",
-		Modules: m,
-		Runtime: runtime,
+		Name:       "AddNodesPipeline",
+		Modules:    m,
+		Runtime:    runtime,
+		Resume:     runtime.Arg.Resume,
+		ConfigHash: runtime.ConfigHash,
 	}
 	if err := p.Start(); err != nil {
 		return err
@@ -148,9 +152,11 @@ func NewK8eAddNodesPipeline(runtime *common.KubeRuntime) error {
 	}
 
 	p := pipeline.Pipeline{
-		Name:    "AddNodesPipeline",
-		Modules: m,
-		Runtime: runtime,
+		Name:       "AddNodesPipeline",
+		Modules:    m,
+		Runtime:    runtime,
+		Resume:     runtime.Arg.Resume,
+		ConfigHash: runtime.ConfigHash,
 	}
 	if err := p.Start(); err != nil {
 		return err
@@ -160,15 +166,19 @@ func NewK8eAddNodesPipeline(runtime *common.KubeRuntime) error {
 }
 
 func AddNodes(args common.Argument, downloadCmd string) error {
-	args.DownloadCommand = func(path, url string) string {
-		// this is an extension point for downloading tools, for example users can set the timeout, proxy or retry under
-		// some poor network environment. Or users even can choose another cli, it might be wget.
-		// perhaps we should have a build-in download function instead of totally rely on the external one
-		return fmt.Sprintf(downloadCmd, path, url)
+	// the built-in downloader is used unless a download command is defined.
+	if downloadCmd != "" {
+		args.DownloadCommand = func(path, url string) string {
+			// this is an extension point for downloading tools, for example users can set the timeout, proxy or retry under
+			// some poor network environment. Or users even can choose another cli, it might be wget.
+			return fmt.Sprintf(downloadCmd, path, url)
+		}
 	}
 
 	var loaderType string
-	if args.FilePath != "" {
+	if args.FromCluster {
+		loaderType = common.Operator
+	} else if args.FilePath != "" {
 		loaderType = common.File
 	} else {
 		loaderType = common.AllInOne
@@ -195,5 +205,5 @@ func AddNodes(args common.Argument, downloadCmd string) error {
 			return err
 		}
 	}
-	return nil
+	return writeDryRunReport(runtime)
 }
@@ -0,0 +1,164 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipelines
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	kubekeyapiv1alpha2 "github.com/kubesphere/kubekey/v3/cmd/kk/apis/kubekey/v1alpha2"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/addons"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/bootstrap/confirm"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/bootstrap/os"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/bootstrap/precheck"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/module"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/pipeline"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/kubernetes"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/plan"
+)
+
+// ApplyPlanPipeline computes the plan against the live cluster and asks for the confirmation.
+func ApplyPlanPipeline(runtime *common.KubeRuntime, p *plan.Plan) error {
+	m := []module.Module{
+		&precheck.GreetingsModule{},
+		&kubernetes.StatusModule{},
+		&plan.LiveStateModule{},
+		&plan.PlanModule{Plan: p},
+		&confirm.ApplyConfirmModule{Skip: runtime.Arg.SkipConfirmCheck, Plan: p},
+	}
+
+	pl := pipeline.Pipeline{
+		Name:    "ApplyPlanPipeline",
+		Modules: m,
+		Runtime: runtime,
+	}
+	if err := pl.Start(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ApplyPipeline removes and resets the nodes, reconfigures kubelet and installs the addons according to the plan.
+func ApplyPipeline(runtime *common.KubeRuntime, p *plan.Plan) error {
+	var removeNodes []string
+	var removeHosts []connector.Host
+	for _, n := range p.RemoveNodes {
+		removeNodes = append(removeNodes, n.Name)
+		// only the nodes still listed in the hosts can be reached to be reset.
+		host, err := runtime.Host(n.Name)
+		if err != nil {
+			return err
+		}
+		if host == nil {
+			logger.Log.Warnf("node %s is not in the hosts of the configuration, it is deleted from the cluster but not reset", n.Name)
+			continue
+		}
+		removeHosts = append(removeHosts, host)
+	}
+
+	var kubeletHosts []connector.Host
+	for _, d := range p.KubeletArgs {
+		for _, host := range runtime.GetHostsByRole(common.K8s) {
+			if host.GetName() == d.Node {
+				kubeletHosts = append(kubeletHosts, host)
+			}
+		}
+	}
+
+	pending := make(map[string]bool, len(p.Addons))
+	for _, addon := range p.Addons {
+		pending[addon] = true
+	}
+	var addonList []kubekeyapiv1alpha2.Addon
+	for _, addon := range runtime.Cluster.Addons {
+		if pending[plan.AddonKey(addon.Namespace, addon.Name)] {
+			addonList = append(addonList, addon)
+		}
+	}
+	runtime.Cluster.Addons = addonList
+
+	m := []module.Module{
+		&kubernetes.RemoveNodesModule{Skip: len(removeNodes) == 0, Nodes: removeNodes, Hosts: removeHosts},
+		&os.ClearNodeOSModule{Skip: len(removeHosts) == 0, Hosts: removeHosts},
+		&kubernetes.ReconfigureKubeletModule{Skip: len(kubeletHosts) == 0, Hosts: kubeletHosts},
+		&addons.AddonsModule{Skip: len(addonList) == 0},
+	}
+
+	pl := pipeline.Pipeline{
+		Name:    "ApplyPipeline",
+		Modules: m,
+		Runtime: runtime,
+	}
+	if err := pl.Start(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Apply(args common.Argument, downloadCmd string) error {
+	// the built-in downloader is used unless a download command is defined.
+	if downloadCmd != "" {
+		args.DownloadCommand = func(path, url string) string {
+			return fmt.Sprintf(downloadCmd, path, url)
+		}
+	}
+
+	var loaderType string
+	if args.FromCluster {
+		loaderType = common.Operator
+	} else if args.FilePath != "" {
+		loaderType = common.File
+	} else {
+		loaderType = common.AllInOne
+	}
+
+	runtime, err := common.NewKubeRuntime(loaderType, args)
+	if err != nil {
+		return err
+	}
+
+	if t := runtime.Cluster.Kubernetes.Type; t == common.K3s || t == common.K8e {
+		return errors.Errorf("applying the configuration to a cluster of type %s is not supported", t)
+	}
+
+	p := &plan.Plan{}
+	if err := ApplyPlanPipeline(runtime, p); err != nil {
+		return err
+	}
+	if !p.HasChanges() {
+		return nil
+	}
+
+	// the plan has been confirmed, do not ask again in the following pipelines.
+	runtime.Arg.SkipConfirmCheck = true
+
+	if len(p.AddNodes) != 0 {
+		if err := NewAddNodesPipeline(runtime); err != nil {
+			return err
+		}
+	}
+	if len(p.RemoveNodes) != 0 || len(p.KubeletArgs) != 0 || len(p.Addons) != 0 {
+		if err := ApplyPipeline(runtime, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -34,6 +34,7 @@ import (
 func NewArtifactExportPipeline(runtime *common.ArtifactRuntime) error {
 	m := []module.Module{
 		&confirm.CheckFileExistModule{FileName: runtime.Arg.Output},
+		&artifact.DeltaModule{},
 		&images.CopyImagesToLocalModule{},
 		&binaries.ArtifactBinariesModule{},
 		&artifact.RepositoryModule{},
@@ -58,6 +59,7 @@ func NewArtifactExportPipeline(runtime *common.ArtifactRuntime) error {
 func NewK3sArtifactExportPipeline(runtime *common.ArtifactRuntime) error {
 	m := []module.Module{
 		&confirm.CheckFileExistModule{FileName: runtime.Arg.Output},
+		&artifact.DeltaModule{},
 		&images.CopyImagesToLocalModule{},
 		&binaries.K3sArtifactBinariesModule{},
 		&artifact.RepositoryModule{},
@@ -82,6 +84,7 @@ func NewK3sArtifactExportPipeline(runtime *common.ArtifactRuntime) error {
 func NewK8eArtifactExportPipeline(runtime *common.ArtifactRuntime) error {
 	m := []module.Module{
 		&confirm.CheckFileExistModule{FileName: runtime.Arg.Output},
+		&artifact.DeltaModule{},
 		&images.CopyImagesToLocalModule{},
 		&binaries.K8eArtifactBinariesModule{},
 		&artifact.RepositoryModule{},
@@ -104,11 +107,13 @@ func NewK8eArtifactExportPipeline(runtime *common.ArtifactRuntime) error {
 }
 
 func ArtifactExport(args common.ArtifactArgument, downloadCmd string) error {
-	args.DownloadCommand = func(path, url string) string {
-		// this is an extension point for downloading tools, for example users can set the timeout, proxy or retry under
-		// some poor network environment. Or users even can choose another cli, it might be wget.
-		// perhaps we should have a build-in download function instead of totally rely on the external one
-		return fmt.Sprintf(downloadCmd, path, url)
+	// the built-in downloader is used unless a download command is defined.
+	if downloadCmd != "" {
+		args.DownloadCommand = func(path, url string) string {
+			// this is an extension point for downloading tools, for example users can set the timeout, proxy or retry under
+			// some poor network environment. Or users even can choose another cli, it might be wget.
+			return fmt.Sprintf(downloadCmd, path, url)
+		}
 	}
 
 	runtime, err := common.NewArtifactRuntime(args)
@@ -60,14 +60,14 @@ func NewCreateClusterPipeline(runtime *common.KubeRuntime) error {
 		&precheck.GreetingsModule{},
 		&customscripts.CustomScriptsModule{Phase: "PreInstall", Scripts: runtime.Cluster.System.PreInstall},
 		&precheck.NodePreCheckModule{},
-		&confirm.InstallConfirmModule{},
+		&confirm.InstallConfirmModule{Skip: runtime.Arg.DryRun},
 		&artifact.UnArchiveModule{Skip: noArtifact},
 		&os.RepositoryModule{Skip: noArtifact || !runtime.Arg.InstallPackages},
 		&binaries.NodeBinariesModule{},
 		&os.ConfigureOSModule{Skip: runtime.Cluster.System.SkipConfigureOS},
 		&kubernetes.StatusModule{},
 		&container.InstallContainerModule{},
-		&images.CopyImagesToRegistryModule{Skip: skipPushImages},
+		&images.CopyImagesToRegistryModule{Skip: skipPushImages || runtime.Arg.DryRun},
 		&images.PullModule{Skip: runtime.Arg.SkipPullImages},
 		&etcd.PreCheckModule{Skip: runtime.Cluster.Etcd.Type != kubekeyapiv1alpha2.KubeKey},
 		&etcd.CertsModule{},
@@ -89,9 +89,9 @@ func NewCreateClusterPipeline(runtime *common.KubeRuntime) error {
 		&filesystem.ChownModule{},
 		&certs.AutoRenewCertsModule{Skip: !runtime.Cluster.Kubernetes.EnableAutoRenewCerts()},
 		&kubernetes.SecurityEnhancementModule{Skip: !runtime.Arg.SecurityEnhancement},
-		&kubernetes.SaveKubeConfigModule{},
+		&kubernetes.SaveKubeConfigModule{Skip: runtime.Arg.DryRun},
 		&plugins.DeployPluginsModule{},
-		&addons.AddonsModule{},
+		&addons.AddonsModule{Skip: runtime.Arg.DryRun},
 		&storage.DeployLocalVolumeModule{Skip: skipLocalStorage},
 		&kubesphere.DeployModule{Skip: !runtime.Cluster.KubeSphere.Enabled},
 		&kubesphere.CheckResultModule{Skip: !runtime.Cluster.KubeSphere.Enabled},
@@ -99,14 +99,20 @@ func NewCreateClusterPipeline(runtime *common.KubeRuntime) error {
 	}
 
 	p := pipeline.Pipeline{
-		Name:    "CreateClusterPipeline",
-		Modules: m,
-		Runtime: runtime,
+		Name:       "CreateClusterPipeline",
+		Modules:    m,
+		Runtime:    runtime,
+		Resume:     runtime.Arg.Resume,
+		ConfigHash: runtime.ConfigHash,
 	}
 	if err := p.Start(); err != nil {
 		return err
 	}
 
+	if runtime.Arg.DryRun {
+		return nil
+	}
+
 	if runtime.Cluster.KubeSphere.Enabled {
 
 		fmt.Print(`Installation is complete.
@@ -158,14 +164,14 @@ func NewK3sCreateClusterPipeline(runtime *common.KubeRuntime) error {
 		&k3s.InitClusterModule{},
 		&k3s.StatusModule{},
 		&k3s.JoinNodesModule{},
-		&images.CopyImagesToRegistryModule{Skip: skipPushImages},
+		&images.CopyImagesToRegistryModule{Skip: skipPushImages || runtime.Arg.DryRun},
 		&loadbalancer.K3sHaproxyModule{Skip: !runtime.Cluster.ControlPlaneEndpoint.IsInternalLBEnabled()},
 		&network.DeployNetworkPluginModule{},
 		&kubernetes.ConfigureKubernetesModule{},
 		&filesystem.ChownModule{},
 		&certs.AutoRenewCertsModule{Skip: !runtime.Cluster.Kubernetes.EnableAutoRenewCerts()},
-		&k3s.SaveKubeConfigModule{},
-		&addons.AddonsModule{},
+		&k3s.SaveKubeConfigModule{Skip: runtime.Arg.DryRun},
+		&addons.AddonsModule{Skip: runtime.Arg.DryRun},
 		&storage.DeployLocalVolumeModule{Skip: skipLocalStorage},
 		&kubesphere.DeployModule{Skip: !runtime.Cluster.KubeSphere.Enabled},
 		&kubesphere.CheckResultModule{Skip: !runtime.Cluster.KubeSphere.Enabled},
@@ -173,14 +179,20 @@ func NewK3sCreateClusterPipeline(runtime *common.KubeRuntime) error {
 	}
 
 	p := pipeline.Pipeline{
-		Name:    "K3sCreateClusterPipeline",
-		Modules: m,
-		Runtime: runtime,
+		Name:       "K3sCreateClusterPipeline",
+		Modules:    m,
+		Runtime:    runtime,
+		Resume:     runtime.Arg.Resume,
+		ConfigHash: runtime.ConfigHash,
 	}
 	if err := p.Start(); err != nil {
 		return err
 	}
 
+	if runtime.Arg.DryRun {
+		return nil
+	}
+
 	if runtime.Cluster.KubeSphere.Enabled {
 
 		fmt.Print(`Installation is complete.
@@ -232,14 +244,14 @@ func NewK8eCreateClusterPipeline(runtime *common.KubeRuntime) error {
 		&k8e.InitClusterModule{},
 		&k8e.StatusModule{},
 		&k8e.JoinNodesModule{},
-		&images.CopyImagesToRegistryModule{Skip: skipPushImages},
+		&images.CopyImagesToRegistryModule{Skip: skipPushImages || runtime.Arg.DryRun},
 		&loadbalancer.K3sHaproxyModule{Skip: !runtime.Cluster.ControlPlaneEndpoint.IsInternalLBEnabled()},
 		&network.DeployNetworkPluginModule{},
 		&kubernetes.ConfigureKubernetesModule{},
 		&filesystem.ChownModule{},
 		&certs.AutoRenewCertsModule{Skip: !runtime.Cluster.Kubernetes.EnableAutoRenewCerts()},
-		&k8e.SaveKubeConfigModule{},
-		&addons.AddonsModule{},
+		&k8e.SaveKubeConfigModule{Skip: runtime.Arg.DryRun},
+		&addons.AddonsModule{Skip: runtime.Arg.DryRun},
 		&storage.DeployLocalVolumeModule{Skip: skipLocalStorage},
 		&kubesphere.DeployModule{Skip: !runtime.Cluster.KubeSphere.Enabled},
 		&kubesphere.CheckResultModule{Skip: !runtime.Cluster.KubeSphere.Enabled},
@@ -247,14 +259,20 @@ func NewK8eCreateClusterPipeline(runtime *common.KubeRuntime) error {
 	}
 
 	p := pipeline.Pipeline{
-		Name:    "K8eCreateClusterPipeline",
-		Modules: m,
-		Runtime: runtime,
+		Name:       "K8eCreateClusterPipeline",
+		Modules:    m,
+		Runtime:    runtime,
+		Resume:     runtime.Arg.Resume,
+		ConfigHash: runtime.ConfigHash,
 	}
 	if err := p.Start(); err != nil {
 		return err
 	}
 
+	if runtime.Arg.DryRun {
+		return nil
+	}
+
 	if runtime.Cluster.KubeSphere.Enabled {
 
 		fmt.Print(`Installation is complete.
@@ -279,11 +297,13 @@ Please check the result using the command:
 }
 
 func CreateCluster(args common.Argument, downloadCmd string) error {
-	args.DownloadCommand = func(path, url string) string {
-		// this is an extension point for downloading tools, for example users can set the timeout, proxy or retry under
-		// some poor network environment. Or users even can choose another cli, it might be wget.
-		// perhaps we should have a build-in download function instead of totally rely on the external one
-		return fmt.Sprintf(downloadCmd, path, url)
+	// the built-in downloader is used unless a download command is defined.
+	if downloadCmd != "" {
+		args.DownloadCommand = func(path, url string) string {
+			// this is an extension point for downloading tools, for example users can set the timeout, proxy or retry under
+			// some poor network environment. Or users even can choose another cli, it might be wget.
+			return fmt.Sprintf(downloadCmd, path, url)
+		}
 	}
 
 	var loaderType string
@@ -314,5 +334,5 @@ func CreateCluster(args common.Argument, downloadCmd string) error {
 			return err
 		}
 	}
-	return nil
+	return writeDryRunReport(runtime)
 }
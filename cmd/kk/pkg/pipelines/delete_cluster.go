@@ -123,5 +123,5 @@ func DeleteCluster(args common.Argument) error {
 			return err
 		}
 	}
-	return nil
+	return writeDryRunReport(runtime)
 }
@@ -64,5 +64,5 @@ func DeleteNode(args common.Argument) error {
 	if err := DeleteNodePipeline(runtime); err != nil {
 		return err
 	}
-	return nil
+	return writeDryRunReport(runtime)
 }
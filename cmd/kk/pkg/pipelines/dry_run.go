@@ -0,0 +1,67 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipelines
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
+)
+
+// writeDryRunReport writes the remote operations recorded in dry-run mode into the dry-run dir of the work dir,
+// either as a shell script per host or as a JSON report.
+func writeDryRunReport(runtime *common.KubeRuntime) error {
+	recorder, ok := runtime.GetConnector().(*connector.Recorder)
+	if !ok {
+		return nil
+	}
+
+	dir := filepath.Join(runtime.GetWorkDir(), "dry-run")
+	if err := os.RemoveAll(dir); err != nil {
+		return errors.Wrapf(err, "clean dir %s failed", dir)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "create dir %s failed", dir)
+	}
+
+	if runtime.Arg.DryRunFormat == common.DryRunJSON {
+		path := filepath.Join(dir, "report.json")
+		f, err := os.Create(path)
+		if err != nil {
+			return errors.Wrapf(err, "create file %s failed", path)
+		}
+		defer f.Close()
+		if err := recorder.WriteJSON(f); err != nil {
+			return errors.Wrapf(err, "write file %s failed", path)
+		}
+		logger.Log.Infof("Dry run finished, the report is written to %s", path)
+		return nil
+	}
+
+	paths, err := recorder.WriteScripts(dir)
+	if err != nil {
+		return err
+	}
+	logger.Log.Infof("Dry run finished, the scripts are written to:\n%s", strings.Join(paths, "\n"))
+	return nil
+}
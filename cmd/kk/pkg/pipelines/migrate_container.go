@@ -48,8 +48,11 @@ func MigrateCriPipeline(runtime *common.KubeRuntime) error {
 }
 
 func MigrateCri(args common.Argument, downloadCmd string) error {
-	args.DownloadCommand = func(path, url string) string {
-		return fmt.Sprintf(downloadCmd, path, url)
+	// the built-in downloader is used unless a download command is defined.
+	if downloadCmd != "" {
+		args.DownloadCommand = func(path, url string) string {
+			return fmt.Sprintf(downloadCmd, path, url)
+		}
 	}
 	var loaderType string
 	if args.FilePath != "" {
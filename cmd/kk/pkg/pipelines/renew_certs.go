@@ -45,7 +45,9 @@ func RenewCertsPipeline(runtime *common.KubeRuntime) error {
 
 func RenewCerts(args common.Argument) error {
 	var loaderType string
-	if args.FilePath != "" {
+	if args.FromCluster {
+		loaderType = common.Operator
+	} else if args.FilePath != "" {
 		loaderType = common.File
 	} else {
 		loaderType = common.AllInOne
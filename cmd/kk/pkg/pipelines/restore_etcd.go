@@ -0,0 +1,74 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package pipelines
+
+import (
+	"github.com/pkg/errors"
+
+	kubekeyapiv1alpha2 "github.com/kubesphere/kubekey/v3/cmd/kk/apis/kubekey/v1alpha2"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/bootstrap/confirm"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/bootstrap/precheck"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/module"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/pipeline"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/etcd"
+)
+
+func RestoreETCDPipeline(runtime *common.KubeRuntime) error {
+	m := []module.Module{
+		&precheck.GreetingsModule{},
+		&confirm.RestoreETCDConfirmModule{Skip: runtime.Arg.SkipConfirmCheck},
+		&etcd.PreCheckModule{},
+		&etcd.RestoreModule{},
+	}
+
+	p := pipeline.Pipeline{
+		Name:    "RestoreETCDPipeline",
+		Modules: m,
+		Runtime: runtime,
+	}
+	if err := p.Start(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func RestoreETCD(args common.Argument) error {
+	var loaderType string
+	if args.FromCluster {
+		loaderType = common.Operator
+	} else if args.FilePath != "" {
+		loaderType = common.File
+	} else {
+		loaderType = common.AllInOne
+	}
+
+	runtime, err := common.NewKubeRuntime(loaderType, args)
+	if err != nil {
+		return err
+	}
+
+	if runtime.Cluster.Etcd.Type != kubekeyapiv1alpha2.KubeKey {
+		return errors.Errorf("restoring etcd of type %s is not supported, only the etcd of type %s can be restored",
+			runtime.Cluster.Etcd.Type, kubekeyapiv1alpha2.KubeKey)
+	}
+
+	if err := RestoreETCDPipeline(runtime); err != nil {
+		return err
+	}
+	return nil
+}
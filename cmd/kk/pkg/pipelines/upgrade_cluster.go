@@ -21,14 +21,19 @@ import (
 
 	"github.com/pkg/errors"
 
+	kubekeyapiv1alpha2 "github.com/kubesphere/kubekey/v3/cmd/kk/apis/kubekey/v1alpha2"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/artifact"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/binaries"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/bootstrap/confirm"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/bootstrap/precheck"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/certs"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/module"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/pipeline"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/etcd"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/filesystem"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/k3s"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/k8e"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/kubernetes"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/kubesphere"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/loadbalancer"
@@ -43,6 +48,7 @@ func NewUpgradeClusterPipeline(runtime *common.KubeRuntime) error {
 		&precheck.ClusterPreCheckModule{},
 		&confirm.UpgradeConfirmModule{Skip: runtime.Arg.SkipConfirmCheck},
 		&artifact.UnArchiveModule{Skip: noArtifact},
+		&etcd.SnapshotModule{Skip: runtime.Cluster.Etcd.Type != kubekeyapiv1alpha2.KubeKey},
 		&kubernetes.SetUpgradePlanModule{Step: kubernetes.ToV121},
 		&kubernetes.ProgressiveUpgradeModule{Step: kubernetes.ToV121},
 		&loadbalancer.HaproxyModule{Skip: !runtime.Cluster.ControlPlaneEndpoint.IsInternalLBEnabled()},
@@ -67,16 +73,72 @@ func NewUpgradeClusterPipeline(runtime *common.KubeRuntime) error {
 	return nil
 }
 
+func NewK3sUpgradeClusterPipeline(runtime *common.KubeRuntime) error {
+	noArtifact := runtime.Arg.Artifact == ""
+
+	m := []module.Module{
+		&precheck.GreetingsModule{},
+		&precheck.NodePreCheckModule{},
+		&k3s.StatusModule{},
+		&k3s.UpgradePreCheckModule{},
+		&confirm.UpgradeConfirmModule{Skip: runtime.Arg.SkipConfirmCheck},
+		&artifact.UnArchiveModule{Skip: noArtifact},
+		&binaries.K3sNodeBinariesModule{},
+		&k3s.UpgradeModule{},
+		&filesystem.ChownModule{},
+	}
+
+	p := pipeline.Pipeline{
+		Name:    "K3sUpgradeClusterPipeline",
+		Modules: m,
+		Runtime: runtime,
+	}
+	if err := p.Start(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func NewK8eUpgradeClusterPipeline(runtime *common.KubeRuntime) error {
+	noArtifact := runtime.Arg.Artifact == ""
+
+	m := []module.Module{
+		&precheck.GreetingsModule{},
+		&precheck.NodePreCheckModule{},
+		&k8e.StatusModule{},
+		&k8e.UpgradePreCheckModule{},
+		&confirm.UpgradeConfirmModule{Skip: runtime.Arg.SkipConfirmCheck},
+		&artifact.UnArchiveModule{Skip: noArtifact},
+		&binaries.K8eNodeBinariesModule{},
+		&k8e.UpgradeModule{},
+		&filesystem.ChownModule{},
+	}
+
+	p := pipeline.Pipeline{
+		Name:    "K8eUpgradeClusterPipeline",
+		Modules: m,
+		Runtime: runtime,
+	}
+	if err := p.Start(); err != nil {
+		return err
+	}
+	return nil
+}
+
 func UpgradeCluster(args common.Argument, downloadCmd string) error {
-	args.DownloadCommand = func(path, url string) string {
-		// this is an extension point for downloading tools, for example users can set the timeout, proxy or retry under
-		// some poor network environment. Or users even can choose another cli, it might be wget.
-		// perhaps we should have a build-in download function instead of totally rely on the external one
-		return fmt.Sprintf(downloadCmd, path, url)
+	// the built-in downloader is used unless a download command is defined.
+	if downloadCmd != "" {
+		args.DownloadCommand = func(path, url string) string {
+			// this is an extension point for downloading tools, for example users can set the timeout, proxy or retry under
+			// some poor network environment. Or users even can choose another cli, it might be wget.
+			return fmt.Sprintf(downloadCmd, path, url)
+		}
 	}
 
 	var loaderType string
-	if args.FilePath != "" {
+	if args.FromCluster {
+		loaderType = common.Operator
+	} else if args.FilePath != "" {
 		loaderType = common.File
 	} else {
 		loaderType = common.AllInOne
@@ -92,9 +154,17 @@ func UpgradeCluster(args common.Argument, downloadCmd string) error {
 		if err := NewUpgradeClusterPipeline(runtime); err != nil {
 			return err
 		}
+	case common.K3s:
+		if err := NewK3sUpgradeClusterPipeline(runtime); err != nil {
+			return err
+		}
+	case common.K8e:
+		if err := NewK8eUpgradeClusterPipeline(runtime); err != nil {
+			return err
+		}
 	default:
 		return errors.New("unsupported cluster kubernetes type")
 	}
 
-	return nil
+	return writeDryRunReport(runtime)
 }
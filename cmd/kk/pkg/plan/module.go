@@ -0,0 +1,81 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package plan
+
+import (
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/task"
+)
+
+type LiveStateModule struct {
+	common.KubeModule
+}
+
+func (l *LiveStateModule) Init() {
+	l.Name = "LiveStateModule"
+	l.Desc = "Get the live state of the cluster"
+
+	getNodes := &task.RemoteTask{
+		Name:    "GetNodes",
+		Desc:    "Get the nodes of the cluster",
+		Hosts:   l.Runtime.GetHostsByRole(common.Master),
+		Prepare: new(common.OnlyFirstMaster),
+		Action:  new(GetNodes),
+	}
+
+	getAddons := &task.RemoteTask{
+		Name:    "GetAddons",
+		Desc:    "Get the helm releases of the cluster",
+		Hosts:   l.Runtime.GetHostsByRole(common.Master),
+		Prepare: new(common.OnlyFirstMaster),
+		Action:  new(GetAddons),
+	}
+
+	getNodeConfig := &task.RemoteTask{
+		Name:     "GetNodeConfig",
+		Desc:     "Get the kubelet args and registry mirrors of the nodes",
+		Hosts:    l.Runtime.GetHostsByRole(common.K8s),
+		Action:   new(GetNodeConfig),
+		Parallel: true,
+	}
+
+	l.Tasks = []task.Interface{
+		getNodes,
+		getAddons,
+		getNodeConfig,
+	}
+}
+
+type PlanModule struct {
+	common.KubeModule
+	Plan *Plan
+}
+
+func (p *PlanModule) Init() {
+	p.Name = "PlanModule"
+	p.Desc = "Compute the plan against the live cluster"
+
+	compute := &task.LocalTask{
+		Name:   "ComputePlan",
+		Desc:   "Compute and display the plan",
+		Action: &ComputePlan{Plan: p.Plan},
+	}
+
+	p.Tasks = []task.Interface{
+		compute,
+	}
+}
@@ -0,0 +1,339 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+)
+
+// Node is a kubernetes node and its roles, the roles are master and worker.
+type Node struct {
+	Name  string
+	Roles []string
+}
+
+// State describes a cluster, it is either the desired state from the configuration file or the live state of the cluster.
+type State struct {
+	Nodes []Node
+	// KubeletArgs holds the extra args of kubelet by the node name, a missing node means the args are unknown.
+	KubeletArgs map[string][]string
+	// RegistryMirrors holds the registry mirrors of the container runtime by the node name, a missing node means the mirrors are unknown.
+	RegistryMirrors map[string][]string
+	// Addons holds the "namespace/name" of the chart addons, nil means the addons are unknown.
+	Addons map[string]bool
+}
+
+// Drift is a setting of a node that differs from the configuration file.
+type Drift struct {
+	Node   string
+	Before []string
+	After  []string
+}
+
+// Plan is the set of changes to bring the live cluster to the desired state.
+type Plan struct {
+	AddNodes        []Node
+	RemoveNodes     []Node
+	RoleChanges     []Drift
+	KubeletArgs     []Drift
+	RegistryMirrors []Drift
+	// Addons holds the "namespace/name" of the chart addons to be installed or upgraded.
+	Addons []string
+}
+
+// Compute computes the plan to bring the live state to the desired state.
+func Compute(desired, live *State) *Plan {
+	p := &Plan{}
+
+	liveNodes := make(map[string]Node, len(live.Nodes))
+	for _, n := range live.Nodes {
+		liveNodes[n.Name] = n
+	}
+	desiredNodes := make(map[string]Node, len(desired.Nodes))
+	for _, n := range desired.Nodes {
+		desiredNodes[n.Name] = n
+	}
+
+	for _, n := range desired.Nodes {
+		l, ok := liveNodes[n.Name]
+		if !ok {
+			p.AddNodes = append(p.AddNodes, n)
+			continue
+		}
+		if !equal(sortedCopy(n.Roles), sortedCopy(l.Roles)) {
+			p.RoleChanges = append(p.RoleChanges, Drift{Node: n.Name, Before: sortedCopy(l.Roles), After: sortedCopy(n.Roles)})
+		}
+		if args, ok := live.KubeletArgs[n.Name]; ok && !equal(sortedCopy(args), sortedCopy(desired.KubeletArgs[n.Name])) {
+			p.KubeletArgs = append(p.KubeletArgs, Drift{Node: n.Name, Before: args, After: desired.KubeletArgs[n.Name]})
+		}
+		if mirrors, ok := live.RegistryMirrors[n.Name]; ok && !equal(mirrors, desired.RegistryMirrors[n.Name]) {
+			p.RegistryMirrors = append(p.RegistryMirrors, Drift{Node: n.Name, Before: mirrors, After: desired.RegistryMirrors[n.Name]})
+		}
+	}
+	for _, n := range live.Nodes {
+		if _, ok := desiredNodes[n.Name]; !ok {
+			p.RemoveNodes = append(p.RemoveNodes, n)
+		}
+	}
+
+	for addon := range desired.Addons {
+		if !live.Addons[addon] {
+			p.Addons = append(p.Addons, addon)
+		}
+	}
+	sort.Strings(p.Addons)
+	return p
+}
+
+// Empty reports whether the plan has no changes, including the ones which are not reconciled automatically.
+func (p *Plan) Empty() bool {
+	return len(p.AddNodes) == 0 && len(p.RemoveNodes) == 0 && len(p.RoleChanges) == 0 &&
+		len(p.KubeletArgs) == 0 && len(p.RegistryMirrors) == 0 && len(p.Addons) == 0
+}
+
+// HasChanges reports whether the plan has changes which are reconciled by kk apply.
+func (p *Plan) HasChanges() bool {
+	return len(p.AddNodes) != 0 || len(p.RemoveNodes) != 0 || len(p.KubeletArgs) != 0 || len(p.Addons) != 0
+}
+
+// Validate returns an error if the plan contains changes which can not be applied.
+func (p *Plan) Validate() error {
+	for _, n := range p.RemoveNodes {
+		if hasRole(n.Roles, common.Master) {
+			return fmt.Errorf("removing the control plane node %s is not supported by kk apply", n.Name)
+		}
+	}
+	// the roles and the registry mirrors need the node or its container runtime to be reinstalled.
+	if len(p.RoleChanges) != 0 {
+		return fmt.Errorf("changing the roles of node %s is not supported by kk apply", p.RoleChanges[0].Node)
+	}
+	if len(p.RegistryMirrors) != 0 {
+		return fmt.Errorf("changing the registry mirrors of node %s is not supported by kk apply", p.RegistryMirrors[0].Node)
+	}
+	return nil
+}
+
+// Print prints the plan in the terraform style.
+func (p *Plan) Print(w io.Writer) {
+	if p.Empty() {
+		fmt.Fprintln(w, "No changes. The cluster matches the configuration.")
+		return
+	}
+
+	fmt.Fprintln(w, "KubeKey will perform the following actions:")
+	fmt.Fprintln(w)
+	for _, n := range p.AddNodes {
+		fmt.Fprintf(w, "  + node %s [%s]\n", n.Name, strings.Join(n.Roles, ", "))
+		fmt.Fprintln(w, "      will be joined to the cluster")
+	}
+	for _, n := range p.RemoveNodes {
+		fmt.Fprintf(w, "  - node %s [%s]\n", n.Name, strings.Join(n.Roles, ", "))
+		fmt.Fprintln(w, "      will be drained, deleted from the cluster and reset")
+	}
+	for _, d := range p.KubeletArgs {
+		fmt.Fprintf(w, "  ~ node %s kubelet args\n", d.Node)
+		for _, arg := range difference(d.Before, d.After) {
+			fmt.Fprintf(w, "      - %s\n", arg)
+		}
+		for _, arg := range difference(d.After, d.Before) {
+			fmt.Fprintf(w, "      + %s\n", arg)
+		}
+	}
+	for _, addon := range p.Addons {
+		fmt.Fprintf(w, "  ~ addon %s\n", addon)
+		fmt.Fprintln(w, "      will be installed or upgraded")
+	}
+
+	if len(p.RoleChanges) != 0 || len(p.RegistryMirrors) != 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "The following drift is not supported by kk apply and has to be fixed manually before applying:")
+		fmt.Fprintln(w)
+		for _, d := range p.RoleChanges {
+			fmt.Fprintf(w, "  ~ node %s roles: [%s] => [%s]\n", d.Node, strings.Join(d.Before, ", "), strings.Join(d.After, ", "))
+		}
+		for _, d := range p.RegistryMirrors {
+			fmt.Fprintf(w, "  ~ node %s registry mirrors: [%s] => [%s]\n", d.Node, strings.Join(d.Before, ", "), strings.Join(d.After, ", "))
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Plan: %d to add, %d to change, %d to remove.\n",
+		len(p.AddNodes), len(p.KubeletArgs)+len(p.Addons), len(p.RemoveNodes))
+}
+
+// NodeRoles returns the roles of a node from its labels, a node which is not a master is always a worker.
+func NodeRoles(labels map[string]string) []string {
+	_, master := labels["node-role.kubernetes.io/master"]
+	_, controlPlane := labels["node-role.kubernetes.io/control-plane"]
+	if !master && !controlPlane {
+		return []string{common.Worker}
+	}
+	roles := []string{common.Master}
+	if _, ok := labels["node-role.kubernetes.io/worker"]; ok {
+		roles = append(roles, common.Worker)
+	}
+	return roles
+}
+
+// ParseNodes parses the output of "kubectl get nodes -o json".
+func ParseNodes(output string) ([]Node, error) {
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name   string            `json:"name"`
+				Labels map[string]string `json:"labels"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(output), &list); err != nil {
+		return nil, err
+	}
+
+	nodes := make([]Node, 0, len(list.Items))
+	for _, item := range list.Items {
+		nodes = append(nodes, Node{Name: item.Metadata.Name, Roles: NodeRoles(item.Metadata.Labels)})
+	}
+	return nodes, nil
+}
+
+// ParseReleases parses the output of "helm list -A -o json" into a set of "namespace/name".
+func ParseReleases(output string) (map[string]bool, error) {
+	var releases []struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	}
+	if err := json.Unmarshal([]byte(output), &releases); err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]bool, len(releases))
+	for _, r := range releases {
+		res[AddonKey(r.Namespace, r.Name)] = true
+	}
+	return res, nil
+}
+
+// AddonKey returns the "namespace/name" of a chart addon.
+func AddonKey(namespace, name string) string {
+	if namespace == "" {
+		namespace = "default"
+	}
+	return namespace + "/" + name
+}
+
+var kubeletExtraArgsRegexp = regexp.MustCompile(`KUBELET_EXTRA_ARGS=([^"]*)"`)
+
+// ParseKubeletArgs parses the user defined kubelet args from the kubelet systemd dropin generated by KubeKey,
+// the args set by KubeKey itself are ignored.
+func ParseKubeletArgs(dropin string) []string {
+	match := kubeletExtraArgsRegexp.FindStringSubmatch(dropin)
+	if len(match) < 2 {
+		return nil
+	}
+
+	var args []string
+	for _, arg := range strings.Fields(match[1]) {
+		if strings.HasPrefix(arg, "--node-ip=") || strings.HasPrefix(arg, "--hostname-override=") ||
+			arg == "--network-plugin=cni" {
+			continue
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
+// ParseDockerMirrors parses the registry mirrors from the docker daemon.json.
+func ParseDockerMirrors(daemon string) ([]string, error) {
+	var cfg struct {
+		RegistryMirrors []string `json:"registry-mirrors"`
+	}
+	if err := json.Unmarshal([]byte(daemon), &cfg); err != nil {
+		return nil, err
+	}
+	return cfg.RegistryMirrors, nil
+}
+
+var (
+	containerdDockerIORegexp = regexp.MustCompile(`registry\.mirrors\."docker\.io"\]\s*endpoint\s*=\s*\[([^\]]*)\]`)
+	quotedRegexp             = regexp.MustCompile(`"([^"]*)"`)
+)
+
+// ParseContainerdMirrors parses the docker.io mirrors from the containerd config.toml, the default endpoint is ignored.
+func ParseContainerdMirrors(config string) []string {
+	match := containerdDockerIORegexp.FindStringSubmatch(config)
+	if len(match) < 2 {
+		return nil
+	}
+
+	var mirrors []string
+	for _, m := range quotedRegexp.FindAllStringSubmatch(match[1], -1) {
+		if m[1] == "https://registry-1.docker.io" {
+			continue
+		}
+		mirrors = append(mirrors, m[1])
+	}
+	return mirrors
+}
+
+func hasRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedCopy(s []string) []string {
+	res := append([]string(nil), s...)
+	sort.Strings(res)
+	return res
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// difference returns the elements of a which are not in b.
+func difference(a, b []string) []string {
+	set := make(map[string]bool, len(b))
+	for _, s := range b {
+		set[s] = true
+	}
+	var res []string
+	for _, s := range a {
+		if !set[s] {
+			res = append(res, s)
+		}
+	}
+	return res
+}
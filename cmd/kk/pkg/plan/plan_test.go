@@ -0,0 +1,192 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package plan
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompute(t *testing.T) {
+	desired := &State{
+		Nodes: []Node{
+			{Name: "node1", Roles: []string{"master", "worker"}},
+			{Name: "node2", Roles: []string{"master"}},
+			{Name: "node4", Roles: []string{"worker"}},
+		},
+		KubeletArgs: map[string][]string{
+			"node1": {"--max-pods=200"},
+			"node2": {"--max-pods=200"},
+			"node4": {"--max-pods=200"},
+		},
+		RegistryMirrors: map[string][]string{
+			"node1": {"https://mirror.example.com"},
+			"node2": {"https://mirror.example.com"},
+			"node4": {"https://mirror.example.com"},
+		},
+		Addons: map[string]bool{"kube-system/nfs-client": true, "default/redis": true},
+	}
+	live := &State{
+		Nodes: []Node{
+			{Name: "node1", Roles: []string{"worker", "master"}},
+			{Name: "node2", Roles: []string{"worker"}},
+			{Name: "node3", Roles: []string{"worker"}},
+		},
+		KubeletArgs: map[string][]string{
+			"node1": {"--max-pods=200"},
+			"node2": {"--max-pods=110"},
+		},
+		RegistryMirrors: map[string][]string{
+			"node1": nil,
+		},
+		Addons: map[string]bool{"default/redis": true},
+	}
+
+	p := Compute(desired, live)
+	want := &Plan{
+		AddNodes:        []Node{{Name: "node4", Roles: []string{"worker"}}},
+		RemoveNodes:     []Node{{Name: "node3", Roles: []string{"worker"}}},
+		RoleChanges:     []Drift{{Node: "node2", Before: []string{"worker"}, After: []string{"master"}}},
+		KubeletArgs:     []Drift{{Node: "node2", Before: []string{"--max-pods=110"}, After: []string{"--max-pods=200"}}},
+		RegistryMirrors: []Drift{{Node: "node1", After: []string{"https://mirror.example.com"}}},
+		Addons:          []string{"kube-system/nfs-client"},
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Fatalf("Compute() = %+v, want %+v", p, want)
+	}
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "roles of node node2") {
+		t.Errorf("Validate() error = %v, want the role change of node2 to be rejected", err)
+	}
+
+	var buf bytes.Buffer
+	p.Print(&buf)
+	for _, line := range []string{
+		"  + node node4 [worker]",
+		"  - node node3 [worker]",
+		"  ~ node node2 kubelet args",
+		"      - --max-pods=110",
+		"      + --max-pods=200",
+		"  ~ addon kube-system/nfs-client",
+		"  ~ node node2 roles: [worker] => [master]",
+		"  ~ node node1 registry mirrors: [] => [https://mirror.example.com]",
+		"Plan: 1 to add, 2 to change, 1 to remove.",
+	} {
+		if !strings.Contains(buf.String(), line+"\n") {
+			t.Errorf("Print() does not contain %q:\n%s", line, buf.String())
+		}
+	}
+}
+
+func TestComputeNoChanges(t *testing.T) {
+	state := &State{
+		Nodes:       []Node{{Name: "node1", Roles: []string{"master", "worker"}}},
+		KubeletArgs: map[string][]string{"node1": {"--max-pods=200", "--v=2"}},
+	}
+	live := &State{
+		Nodes:       []Node{{Name: "node1", Roles: []string{"master", "worker"}}},
+		KubeletArgs: map[string][]string{"node1": {"--v=2", "--max-pods=200"}},
+	}
+
+	p := Compute(state, live)
+	if !p.Empty() || p.HasChanges() {
+		t.Fatalf("Compute() = %+v, want an empty plan", p)
+	}
+	var buf bytes.Buffer
+	p.Print(&buf)
+	if !strings.HasPrefix(buf.String(), "No changes.") {
+		t.Errorf("Print() = %q, want no changes", buf.String())
+	}
+}
+
+func TestValidateRemoveMaster(t *testing.T) {
+	p := &Plan{RemoveNodes: []Node{{Name: "node1", Roles: []string{"master"}}}}
+	if err := p.Validate(); err == nil {
+		t.Errorf("Validate() removing a master should fail")
+	}
+}
+
+func TestValidateDrift(t *testing.T) {
+	p := &Plan{
+		RemoveNodes:     []Node{{Name: "node3", Roles: []string{"worker"}}},
+		KubeletArgs:     []Drift{{Node: "node2", After: []string{"--max-pods=200"}}},
+		RegistryMirrors: []Drift{{Node: "node1", After: []string{"https://mirror.example.com"}}},
+	}
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "registry mirrors of node node1") {
+		t.Errorf("Validate() error = %v, want the registry mirrors drift to be rejected", err)
+	}
+
+	p.RegistryMirrors = nil
+	if err := p.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestParseKubeletArgs(t *testing.T) {
+	dropin := `[Service]
+Environment="KUBELET_EXTRA_ARGS=--node-ip=192.168.0.2 --hostname-override=node1 --network-plugin=cni   --max-pods=200 --v=2"
+ExecStart=
+`
+	want := []string{"--max-pods=200", "--v=2"}
+	if got := ParseKubeletArgs(dropin); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseKubeletArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestParseMirrors(t *testing.T) {
+	containerd := `
+      [plugins."io.containerd.grpc.v1.cri".registry.mirrors]
+        [plugins."io.containerd.grpc.v1.cri".registry.mirrors."docker.io"]
+          endpoint = ["https://mirror.example.com", "https://registry-1.docker.io"]
+        [plugins."io.containerd.grpc.v1.cri".registry.mirrors."dockerhub.kubekey.local"]
+          endpoint = ["http://dockerhub.kubekey.local"]
+`
+	if got, want := ParseContainerdMirrors(containerd), []string{"https://mirror.example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseContainerdMirrors() = %v, want %v", got, want)
+	}
+
+	docker := `{
+  "log-opts": {"max-size": "5m"},
+  "registry-mirrors": ["https://mirror.example.com"],
+  "exec-opts": ["native.cgroupdriver=systemd"]
+}`
+	got, err := ParseDockerMirrors(docker)
+	if err != nil {
+		t.Fatalf("ParseDockerMirrors() error = %v", err)
+	}
+	if want := []string{"https://mirror.example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseDockerMirrors() = %v, want %v", got, want)
+	}
+}
+
+func TestNodeRoles(t *testing.T) {
+	tests := []struct {
+		labels map[string]string
+		want   []string
+	}{
+		{map[string]string{"node-role.kubernetes.io/control-plane": ""}, []string{"master"}},
+		{map[string]string{"node-role.kubernetes.io/master": "", "node-role.kubernetes.io/worker": ""}, []string{"master", "worker"}},
+		{map[string]string{"node-role.kubernetes.io/worker": ""}, []string{"worker"}},
+		{nil, []string{"worker"}},
+	}
+	for _, tt := range tests {
+		if got := NodeRoles(tt.labels); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("NodeRoles(%v) = %v, want %v", tt.labels, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package plan
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
+)
+
+const (
+	liveNodes       = "liveNodes"
+	liveAddons      = "liveAddons"
+	kubeletArgs     = "kubeletArgs"
+	registryMirrors = "registryMirrors"
+)
+
+type GetNodes struct {
+	common.KubeAction
+}
+
+func (g *GetNodes) Execute(runtime connector.Runtime) error {
+	if exist, ok := g.PipelineCache.GetMustBool(common.ClusterExist); !ok || !exist {
+		return errors.New("the cluster does not exist, please use 'kk create cluster' to create it")
+	}
+
+	output, err := runtime.GetRunner().SudoCmd("/usr/local/bin/kubectl get nodes -o json", false)
+	if err != nil {
+		return errors.Wrap(errors.WithStack(err), "get kubernetes nodes failed")
+	}
+	nodes, err := ParseNodes(output)
+	if err != nil {
+		return errors.Wrap(err, "parse kubernetes nodes failed")
+	}
+	g.PipelineCache.Set(liveNodes, nodes)
+	return nil
+}
+
+type GetAddons struct {
+	common.KubeAction
+}
+
+func (g *GetAddons) Execute(runtime connector.Runtime) error {
+	output, err := runtime.GetRunner().SudoCmd("/usr/local/bin/helm list -A -o json", false)
+	if err != nil {
+		logger.Log.Warnf("list helm releases failed, all the chart addons will be installed or upgraded: %v", err)
+		return nil
+	}
+	releases, err := ParseReleases(output)
+	if err != nil {
+		logger.Log.Warnf("parse helm releases failed, all the chart addons will be installed or upgraded: %v", err)
+		return nil
+	}
+	g.PipelineCache.Set(liveAddons, releases)
+	return nil
+}
+
+type GetNodeConfig struct {
+	common.KubeAction
+}
+
+func (g *GetNodeConfig) Execute(runtime connector.Runtime) error {
+	host := runtime.RemoteHost()
+
+	dropin := "/etc/systemd/system/kubelet.service.d/10-kubeadm.conf"
+	if exist, err := runtime.GetRunner().FileExist(dropin); err != nil {
+		return err
+	} else if exist {
+		output, err := runtime.GetRunner().SudoCmd("cat "+dropin, false)
+		if err != nil {
+			return errors.Wrapf(errors.WithStack(err), "read %s failed", dropin)
+		}
+		host.GetCache().Set(kubeletArgs, ParseKubeletArgs(output))
+	}
+
+	switch g.KubeConf.Cluster.Kubernetes.ContainerManager {
+	case common.Docker:
+		config := "/etc/docker/daemon.json"
+		if exist, err := runtime.GetRunner().FileExist(config); err != nil || !exist {
+			return err
+		}
+		output, err := runtime.GetRunner().SudoCmd("cat "+config, false)
+		if err != nil {
+			return errors.Wrapf(errors.WithStack(err), "read %s failed", config)
+		}
+		mirrors, err := ParseDockerMirrors(output)
+		if err != nil {
+			return errors.Wrapf(err, "parse %s failed", config)
+		}
+		host.GetCache().Set(registryMirrors, mirrors)
+	case common.Containerd:
+		config := "/etc/containerd/config.toml"
+		if exist, err := runtime.GetRunner().FileExist(config); err != nil || !exist {
+			return err
+		}
+		output, err := runtime.GetRunner().SudoCmd("cat "+config, false)
+		if err != nil {
+			return errors.Wrapf(errors.WithStack(err), "read %s failed", config)
+		}
+		host.GetCache().Set(registryMirrors, ParseContainerdMirrors(output))
+	}
+	return nil
+}
+
+type ComputePlan struct {
+	common.KubeAction
+	Plan *Plan
+}
+
+func (c *ComputePlan) Execute(runtime connector.Runtime) error {
+	desired := &State{
+		KubeletArgs:     make(map[string][]string),
+		RegistryMirrors: make(map[string][]string),
+		Addons:          make(map[string]bool),
+	}
+	live := &State{
+		KubeletArgs:     make(map[string][]string),
+		RegistryMirrors: make(map[string][]string),
+	}
+
+	for _, host := range runtime.GetHostsByRole(common.K8s) {
+		var roles []string
+		if host.IsRole(common.Master) {
+			roles = append(roles, common.Master)
+		}
+		if host.IsRole(common.Worker) {
+			roles = append(roles, common.Worker)
+		}
+		desired.Nodes = append(desired.Nodes, Node{Name: host.GetName(), Roles: roles})
+		desired.KubeletArgs[host.GetName()] = c.KubeConf.Cluster.Kubernetes.KubeletArgs
+		desired.RegistryMirrors[host.GetName()] = c.KubeConf.Cluster.Registry.RegistryMirrors
+
+		if v, ok := host.GetCache().Get(kubeletArgs); ok {
+			live.KubeletArgs[host.GetName()] = v.([]string)
+		}
+		if v, ok := host.GetCache().Get(registryMirrors); ok {
+			live.RegistryMirrors[host.GetName()] = v.([]string)
+		}
+	}
+	for _, addon := range c.KubeConf.Cluster.Addons {
+		if addon.Sources.Chart.Name != "" {
+			desired.Addons[AddonKey(addon.Namespace, addon.Name)] = true
+		}
+	}
+
+	v, ok := c.PipelineCache.Get(liveNodes)
+	if !ok {
+		return errors.New("get kubernetes nodes failed by pipeline cache")
+	}
+	live.Nodes = v.([]Node)
+	if v, ok := c.PipelineCache.Get(liveAddons); ok {
+		live.Addons = v.(map[string]bool)
+	}
+
+	*c.Plan = *Compute(desired, live)
+	c.Plan.Print(os.Stdout)
+	return c.Plan.Validate()
+}
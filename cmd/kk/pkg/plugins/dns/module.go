@@ -131,6 +131,7 @@ func (c *ClusterDNSModule) Init() {
 			Data: util.Data{
 				"NodelocaldnsImage": images.GetImage(c.Runtime, c.KubeConf, "k8s-dns-node-cache").ImageName(),
 				"DNSEtcHosts":       c.KubeConf.Cluster.DNS.DNSEtcHosts,
+				"LocalIP":           c.KubeConf.Cluster.NodelocaldnsIP(),
 			},
 		},
 		Parallel: true,
@@ -17,6 +17,7 @@
 package dns
 
 import (
+	"fmt"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/images"
 	"github.com/pkg/errors"
 	"path/filepath"
@@ -33,11 +34,17 @@ type GenerateCorednsmanifests struct {
 }
 
 func (g *GenerateCorednsmanifests) Execute(runtime connector.Runtime) error {
+	var clusterIPs []string
+	if g.KubeConf.Cluster.Network.IsDualStack() {
+		clusterIPs = g.KubeConf.Cluster.CorednsClusterIPs()
+	}
+
 	templateAction := action.Template{
 		Template: templates.Coredns,
 		Dst:      filepath.Join(common.KubeConfigDir, templates.Coredns.Name()),
 		Data: util.Data{
 			"ClusterIP":    g.KubeConf.Cluster.CorednsClusterIP(),
+			"ClusterIPs":   clusterIPs,
 			"CorednsImage": images.GetImage(runtime, g.KubeConf, "coredns").ImageName(),
 			"DNSEtcHosts":  g.KubeConf.Cluster.DNS.DNSEtcHosts,
 		},
@@ -111,6 +118,8 @@ func (g *GenerateNodeLocalDNSConfigMap) Execute(runtime connector.Runtime) error
 			"DNSDomain":     g.KubeConf.Cluster.Kubernetes.DNSDomain,
 			"ExternalZones": g.KubeConf.Cluster.DNS.NodeLocalDNS.ExternalZones,
 			"DNSEtcHosts":   g.KubeConf.Cluster.DNS.DNSEtcHosts,
+			"LocalIP":       g.KubeConf.Cluster.NodelocaldnsIP(),
+			"HealthAddress": fmt.Sprintf("%s:9254", util.BracketIP(g.KubeConf.Cluster.NodelocaldnsIP())),
 		},
 	}
 
@@ -19,10 +19,12 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 var (
-	Coredns = template.Must(template.New("coredns.yaml").Parse(
+	Coredns = template.Must(util.ParseTemplate(template.New("coredns.yaml"),
 		dedent.Dedent(`---
 apiVersion: rbac.authorization.k8s.io/v1
 kind: ClusterRole
@@ -75,6 +77,13 @@ spec:
   selector:
     k8s-app: kube-dns
   clusterIP: {{ .ClusterIP }}
+{{- if .ClusterIPs }}
+  ipFamilyPolicy: PreferDualStack
+  clusterIPs:
+{{- range .ClusterIPs }}
+  - {{ . }}
+{{- end }}
+{{- end }}
   ports:
     - name: dns
       port: 53
@@ -17,13 +17,14 @@
 package templates
 
 import (
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/utils"
 	"github.com/lithammer/dedent"
 	"text/template"
 )
 
 var (
-	CorednsConfigMap = template.Must(template.New("coredns-configmap.yaml").Funcs(utils.FuncMap).Parse(
+	CorednsConfigMap = template.Must(util.ParseTemplate(template.New("coredns-configmap.yaml").Funcs(utils.FuncMap),
 		dedent.Dedent(`---
 apiVersion: v1
 kind: ConfigMap
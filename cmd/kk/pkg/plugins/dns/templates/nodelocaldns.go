@@ -19,9 +19,11 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var NodeLocalDNSService = template.Must(template.New("nodelocaldns.yaml").Parse(
+var NodeLocalDNSService = template.Must(util.ParseTemplate(template.New("nodelocaldns.yaml"),
 	dedent.Dedent(`---
 apiVersion: v1
 kind: ServiceAccount
@@ -74,7 +76,7 @@ spec:
           requests:
             cpu: 100m
             memory: 70Mi
-        args: [ "-localip", "169.254.25.10", "-conf", "/etc/coredns/Corefile", "-upstreamsvc", "coredns" ]
+        args: [ "-localip", "{{ .LocalIP }}", "-conf", "/etc/coredns/Corefile", "-upstreamsvc", "coredns" ]
         securityContext:
           privileged: true
         ports:
@@ -89,7 +91,7 @@ spec:
           protocol: TCP
         livenessProbe:
           httpGet:
-            host: 169.254.25.10
+            host: {{ .LocalIP }}
             path: /health
             port: 9254
             scheme: HTTP
@@ -98,7 +100,7 @@ spec:
           failureThreshold: 10
         readinessProbe:
           httpGet:
-            host: 169.254.25.10
+            host: {{ .LocalIP }}
             path: /health
             port: 9254
             scheme: HTTP
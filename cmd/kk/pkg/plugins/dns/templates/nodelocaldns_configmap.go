@@ -17,12 +17,13 @@
 package templates
 
 import (
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/utils"
 	"github.com/lithammer/dedent"
 	"text/template"
 )
 
-var NodeLocalDNSConfigMap = template.Must(template.New("nodelocaldns-configmap.yaml").Funcs(utils.FuncMap).Parse(
+var NodeLocalDNSConfigMap = template.Must(util.ParseTemplate(template.New("nodelocaldns-configmap.yaml").Funcs(utils.FuncMap),
 	dedent.Dedent(`---
 apiVersion: v1
 kind: ConfigMap
@@ -46,7 +47,7 @@ data:
 {{- end }}
 {{- end }}
         loop
-        bind 169.254.25.10
+        bind {{ $.LocalIP }}
         forward . {{ range .Nameservers }} {{ . }}{{ end }}
         prometheus :9253
         log
@@ -66,19 +67,19 @@ data:
         }
         reload
         loop
-        bind 169.254.25.10
+        bind {{ $.LocalIP }}
         forward . {{ .ForwardTarget }} {
             force_tcp
         }
         prometheus :9253
-        health 169.254.25.10:9254
+        health {{ .HealthAddress }}
     }
     in-addr.arpa:53 {
         errors
         cache 30
         reload
         loop
-        bind 169.254.25.10
+        bind {{ $.LocalIP }}
         forward . {{ .ForwardTarget }} {
             force_tcp
         }
@@ -89,7 +90,7 @@ data:
         cache 30
         reload
         loop
-        bind 169.254.25.10
+        bind {{ $.LocalIP }}
         forward . {{ .ForwardTarget }} {
             force_tcp
         }
@@ -100,7 +101,7 @@ data:
         cache 30
         reload
         loop
-        bind 169.254.25.10
+        bind {{ $.LocalIP }}
         forward . /etc/resolv.conf
         prometheus :9253
 {{- if .DNSEtcHosts }}
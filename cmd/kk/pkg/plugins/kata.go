@@ -33,7 +33,7 @@ import (
 // technology as a second layer of defense.
 
 var (
-	KataDeploy = template.Must(template.New("kata-deploy.yaml").Parse(
+	KataDeploy = template.Must(util.ParseTemplate(template.New("kata-deploy.yaml"),
 		dedent.Dedent(`---
 apiVersion: v1
 kind: ServiceAccount
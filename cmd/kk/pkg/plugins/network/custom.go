@@ -0,0 +1,275 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	kubekeyapiv1alpha2 "github.com/kubesphere/kubekey/v3/cmd/kk/apis/kubekey/v1alpha2"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/action"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/connector"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/logger"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/task"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/utils"
+)
+
+// customPlugin deploys a network plugin defined in NetworkConfig.CustomPlugins.
+type customPlugin struct {
+	config *kubekeyapiv1alpha2.CustomNetworkPlugin
+}
+
+func (c *customPlugin) Tasks(d *DeployNetworkPluginModule) []task.Interface {
+	var tasks []task.Interface
+	if c.config.ManifestsDir != "" {
+		tasks = append(tasks,
+			&task.RemoteTask{
+				Name:     "GenerateCustomNetworkPlugin",
+				Desc:     fmt.Sprintf("Generate %s manifests", c.config.Name),
+				Hosts:    d.Runtime.GetHostsByRole(common.Master),
+				Prepare:  new(common.OnlyFirstMaster),
+				Action:   &GenerateCustomNetworkManifests{Plugin: c.config},
+				Parallel: true,
+			},
+			&task.RemoteTask{
+				Name:     "DeployCustomNetworkPlugin",
+				Desc:     fmt.Sprintf("Deploy %s manifests", c.config.Name),
+				Hosts:    d.Runtime.GetHostsByRole(common.Master),
+				Prepare:  new(common.OnlyFirstMaster),
+				Action:   &ApplyCustomNetworkManifests{Plugin: c.config},
+				Parallel: true,
+				Retry:    5,
+			})
+	}
+
+	if c.config.Chart != nil {
+		if c.config.Chart.Path != "" {
+			tasks = append(tasks, &task.RemoteTask{
+				Name:     "SyncCustomNetworkChart",
+				Desc:     fmt.Sprintf("Synchronize %s chart", c.config.Name),
+				Hosts:    d.Runtime.GetHostsByRole(common.Master),
+				Prepare:  new(common.OnlyFirstMaster),
+				Action:   &SyncCustomNetworkChart{Plugin: c.config},
+				Parallel: true,
+				Retry:    2,
+			})
+		}
+		tasks = append(tasks, &task.RemoteTask{
+			Name:     "DeployCustomNetworkChart",
+			Desc:     fmt.Sprintf("Deploy %s chart", c.config.Name),
+			Hosts:    d.Runtime.GetHostsByRole(common.Master),
+			Prepare:  new(common.OnlyFirstMaster),
+			Action:   &DeployCustomNetworkChart{Plugin: c.config},
+			Parallel: true,
+			Retry:    5,
+		})
+	}
+
+	if len(tasks) == 0 {
+		logger.Log.Warnf("custom network plugin %s has neither manifestsDir nor chart, nothing will be deployed", c.config.Name)
+	}
+	return tasks
+}
+
+// customPluginData is the data that custom manifests and chart values are rendered with.
+func customPluginData(kubeConf *common.KubeConf) util.Data {
+	cluster := kubeConf.Cluster
+	return util.Data{
+		"KubePodsCIDR":         cluster.Network.KubePodsCIDR,
+		"KubePodsIPv4CIDR":     cluster.Network.KubePodsIPv4CIDR(),
+		"KubePodsIPv6CIDR":     cluster.Network.KubePodsIPv6CIDR(),
+		"KubeServiceCIDR":      cluster.Network.KubeServiceCIDR,
+		"NodeCidrMaskSize":     cluster.Kubernetes.NodeCidrMaskSize,
+		"NodeCidrMaskSizeIPv6": cluster.Kubernetes.NodeCidrMaskSizeIPv6,
+		"ClusterDNS":           cluster.ClusterDNS(),
+		"DNSDomain":            cluster.Kubernetes.DNSDomain,
+		"ControlPlaneAddress":  cluster.ControlPlaneEndpoint.Address,
+		"ControlPlanePort":     cluster.ControlPlaneEndpoint.Port,
+		"DisableKubeProxy":     cluster.Kubernetes.DisableKubeProxy,
+		"PrivateRegistry":      cluster.Registry.PrivateRegistry,
+	}
+}
+
+func customManifestsDir(name string) string {
+	return filepath.Join(common.KubeConfigDir, "network-plugins", name)
+}
+
+func customChartPath(name string) string {
+	return filepath.Join(common.KubeConfigDir, fmt.Sprintf("%s-chart.tgz", name))
+}
+
+// CustomManifests lists the manifest files of dir in name order.
+func CustomManifests(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read network plugin manifests dir %s", dir)
+	}
+	var files []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		files = append(files, filepath.Join(dir, entry.Name()))
+	}
+	if len(files) == 0 {
+		return nil, errors.Errorf("no manifests found in %s", dir)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// RenderChartValues renders the value mapping of a chart into sorted helm --set flags.
+func RenderChartValues(values map[string]string, data util.Data) ([]string, error) {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	flags := make([]string, 0, len(keys))
+	for _, k := range keys {
+		tmpl, err := template.New(k).Funcs(utils.FuncMap).Parse(values[k])
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse chart value %s", k)
+		}
+		v, err := util.Render(tmpl, data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "render chart value %s", k)
+		}
+		// helm splits --set on commas, so a dual-stack CIDR has to be escaped.
+		flags = append(flags, fmt.Sprintf("--set '%s=%s'", k, strings.ReplaceAll(v, ",", `\,`)))
+	}
+	return flags, nil
+}
+
+type GenerateCustomNetworkManifests struct {
+	common.KubeAction
+	Plugin *kubekeyapiv1alpha2.CustomNetworkPlugin
+}
+
+func (g *GenerateCustomNetworkManifests) Execute(runtime connector.Runtime) error {
+	files, err := CustomManifests(g.Plugin.ManifestsDir)
+	if err != nil {
+		return err
+	}
+
+	dir := customManifestsDir(g.Plugin.Name)
+	if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf("rm -rf %s && mkdir -p %s", dir, dir), false); err != nil {
+		return errors.Wrapf(errors.WithStack(err), "create dir %s failed", dir)
+	}
+
+	data := customPluginData(g.KubeConf)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return errors.Wrapf(err, "read manifest %s", file)
+		}
+		name := fmt.Sprintf("%s-%s", g.Plugin.Name, filepath.Base(file))
+		tmpl, err := template.New(name).Funcs(utils.FuncMap).Parse(string(content))
+		if err != nil {
+			return errors.Wrapf(err, "parse manifest %s", file)
+		}
+
+		templateAction := action.Template{
+			Template: tmpl,
+			Dst:      filepath.Join(dir, filepath.Base(file)),
+			Data:     data,
+		}
+		templateAction.Init(nil, nil)
+		if err := templateAction.Execute(runtime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type ApplyCustomNetworkManifests struct {
+	common.KubeAction
+	Plugin *kubekeyapiv1alpha2.CustomNetworkPlugin
+}
+
+func (a *ApplyCustomNetworkManifests) Execute(runtime connector.Runtime) error {
+	if _, err := runtime.GetRunner().SudoCmd(
+		fmt.Sprintf("/usr/local/bin/kubectl apply -f %s --force", customManifestsDir(a.Plugin.Name)), true); err != nil {
+		return errors.Wrapf(errors.WithStack(err), "deploy network plugin %s failed", a.Plugin.Name)
+	}
+	return nil
+}
+
+type SyncCustomNetworkChart struct {
+	common.KubeAction
+	Plugin *kubekeyapiv1alpha2.CustomNetworkPlugin
+}
+
+func (s *SyncCustomNetworkChart) Execute(runtime connector.Runtime) error {
+	dst := customChartPath(s.Plugin.Name)
+	tmp := filepath.Join(common.TmpDir, filepath.Base(dst))
+	if err := runtime.GetRunner().Scp(s.Plugin.Chart.Path, tmp); err != nil {
+		return errors.Wrapf(errors.WithStack(err), "sync %s chart failed", s.Plugin.Name)
+	}
+	if _, err := runtime.GetRunner().SudoCmd(fmt.Sprintf("mv %s %s", tmp, dst), true); err != nil {
+		return errors.Wrapf(errors.WithStack(err), "sync %s chart failed", s.Plugin.Name)
+	}
+	return nil
+}
+
+type DeployCustomNetworkChart struct {
+	common.KubeAction
+	Plugin *kubekeyapiv1alpha2.CustomNetworkPlugin
+}
+
+func (d *DeployCustomNetworkChart) Execute(runtime connector.Runtime) error {
+	chart := d.Plugin.Chart
+	namespace := chart.Namespace
+	if namespace == "" {
+		namespace = "kube-system"
+	}
+
+	args := []string{"/usr/local/bin/helm upgrade --install", d.Plugin.Name}
+	if chart.Path != "" {
+		args = append(args, customChartPath(d.Plugin.Name))
+	} else {
+		args = append(args, chart.Name)
+		if chart.Repo != "" {
+			args = append(args, "--repo", chart.Repo)
+		}
+	}
+	if chart.Version != "" {
+		args = append(args, "--version", chart.Version)
+	}
+	args = append(args, "--namespace", namespace, "--create-namespace")
+
+	values, err := RenderChartValues(chart.Values, customPluginData(d.KubeConf))
+	if err != nil {
+		return err
+	}
+	args = append(args, values...)
+
+	if _, err := runtime.GetRunner().SudoCmd(strings.Join(args, " "), true); err != nil {
+		return errors.Wrapf(errors.WithStack(err), "deploy network plugin %s failed", d.Plugin.Name)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
+)
+
+func TestCustomManifests(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"02-agent.yaml", "01-crds.yml", "README.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("kind: List\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sub.yaml"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := CustomManifests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "01-crds.yml"), filepath.Join(dir, "02-agent.yaml")}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("CustomManifests() = %v, want %v", files, want)
+	}
+
+	if _, err := CustomManifests(t.TempDir()); err == nil {
+		t.Error("CustomManifests() on an empty dir should fail")
+	}
+}
+
+func TestRenderChartValues(t *testing.T) {
+	values := map[string]string{
+		"podCIDR":            "{{ .KubePodsCIDR }}",
+		"ipam.nodeMaskSize":  "{{ .NodeCidrMaskSize }}",
+		"kubeProxy.disabled": "{{ .DisableKubeProxy }}",
+	}
+	data := util.Data{
+		"KubePodsCIDR":     "10.233.64.0/18,fd00::/56",
+		"NodeCidrMaskSize": 24,
+		"DisableKubeProxy": false,
+	}
+
+	flags, err := RenderChartValues(values, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"--set 'ipam.nodeMaskSize=24'",
+		"--set 'kubeProxy.disabled=false'",
+		`--set 'podCIDR=10.233.64.0/18\,fd00::/56'`,
+	}
+	if !reflect.DeepEqual(flags, want) {
+		t.Errorf("RenderChartValues() = %v, want %v", flags, want)
+	}
+}
@@ -18,6 +18,7 @@ package network
 
 import (
 	"path/filepath"
+	"strings"
 
 	versionutil "k8s.io/apimachinery/pkg/util/version"
 
@@ -39,20 +40,16 @@ func (d *DeployNetworkPluginModule) Init() {
 	d.Name = "DeployNetworkPluginModule"
 	d.Desc = "Deploy cluster network plugin"
 
-	switch d.KubeConf.Cluster.Network.Plugin {
-	case common.Calico:
-		d.Tasks = deployCalico(d)
-	case common.Flannel:
-		d.Tasks = deployFlannel(d)
-	case common.Cilium:
-		d.Tasks = deployCilium(d)
-	case common.Kubeovn:
-		d.Tasks = deployKubeOVN(d)
-	case common.Hybridnet:
-		d.Tasks = deployHybridnet(d)
-	default:
+	name := d.KubeConf.Cluster.Network.Plugin
+	plugin, ok := Lookup(d.KubeConf, name)
+	if !ok {
+		if name != "" && name != "none" {
+			logger.Log.Warnf("network plugin %s is not registered nor defined in customPlugins, skip deploying it. Registered plugins: %s",
+				name, strings.Join(Registered(), ", "))
+		}
 		return
 	}
+	d.Tasks = plugin.Tasks(d)
 	if d.KubeConf.Cluster.Network.EnableMultusCNI() {
 		d.Tasks = append(d.Tasks, deployMultus(d)...)
 	}
@@ -131,7 +128,8 @@ func deployCalico(d *DeployNetworkPluginModule) []task.Interface {
 			Template: templates.CalicoNew,
 			Dst:      filepath.Join(common.KubeConfigDir, templates.CalicoNew.Name()),
 			Data: util.Data{
-				"KubePodsCIDR":            d.KubeConf.Cluster.Network.KubePodsCIDR,
+				"KubePodsCIDR":            d.KubeConf.Cluster.Network.KubePodsIPv4CIDR(),
+				"KubePodsIPv6CIDR":        d.KubeConf.Cluster.Network.KubePodsIPv6CIDR(),
 				"CalicoCniImage":          images.GetImage(d.Runtime, d.KubeConf, "calico-cni").ImageName(),
 				"CalicoNodeImage":         images.GetImage(d.Runtime, d.KubeConf, "calico-node").ImageName(),
 				"CalicoFlexvolImage":      images.GetImage(d.Runtime, d.KubeConf, "calico-flexvol").ImageName(),
@@ -0,0 +1,81 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package network
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/common"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/task"
+)
+
+// Plugin generates the tasks that deploy a CNI network plugin.
+type Plugin interface {
+	Tasks(d *DeployNetworkPluginModule) []task.Interface
+}
+
+// PluginFunc adapts an ordinary function to a Plugin.
+type PluginFunc func(d *DeployNetworkPluginModule) []task.Interface
+
+func (f PluginFunc) Tasks(d *DeployNetworkPluginModule) []task.Interface {
+	return f(d)
+}
+
+var (
+	pluginsMu sync.RWMutex
+	plugins   = make(map[string]Plugin)
+)
+
+func init() {
+	Register(common.Calico, PluginFunc(deployCalico))
+	Register(common.Flannel, PluginFunc(deployFlannel))
+	Register(common.Cilium, PluginFunc(deployCilium))
+	Register(common.Kubeovn, PluginFunc(deployKubeOVN))
+	Register(common.Hybridnet, PluginFunc(deployHybridnet))
+}
+
+// Register makes a network plugin available under name, replacing any plugin registered before.
+func Register(name string, p Plugin) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	plugins[name] = p
+}
+
+// Registered returns the names of the registered network plugins.
+func Registered() []string {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the network plugin named name. A custom plugin in the cluster configuration takes
+// precedence over a registered one with the same name.
+func Lookup(kubeConf *common.KubeConf, name string) (Plugin, bool) {
+	if custom, ok := kubeConf.Cluster.Network.CustomPlugin(name); ok {
+		return &customPlugin{config: custom}, true
+	}
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	p, ok := plugins[name]
+	return p, ok
+}
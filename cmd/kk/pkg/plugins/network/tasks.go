@@ -89,8 +89,17 @@ func (d *DeployCilium) Execute(runtime connector.Runtime) error {
 	cmd := fmt.Sprintf("/usr/local/bin/helm upgrade --install cilium /etc/kubernetes/cilium.tgz --namespace kube-system "+
 		"--set operator.image.override=%s "+
 		"--set operator.replicas=1 "+
-		"--set image.override=%s "+
-		"--set ipam.operator.clusterPoolIPv4PodCIDR=%s", ciliumOperatorImage, ciliumImage, d.KubeConf.Cluster.Network.KubePodsCIDR)
+		"--set image.override=%s", ciliumOperatorImage, ciliumImage)
+
+	network := d.KubeConf.Cluster.Network
+	if ipv4CIDR := network.KubePodsIPv4CIDR(); ipv4CIDR != "" {
+		cmd = fmt.Sprintf("%s --set ipam.operator.clusterPoolIPv4PodCIDR=%s", cmd, ipv4CIDR)
+	} else {
+		cmd = fmt.Sprintf("%s --set ipv4.enabled=false", cmd)
+	}
+	if ipv6CIDR := network.KubePodsIPv6CIDR(); ipv6CIDR != "" {
+		cmd = fmt.Sprintf("%s --set ipv6.enabled=true --set ipam.operator.clusterPoolIPv6PodCIDR=%s", cmd, ipv6CIDR)
+	}
 
 	if d.KubeConf.Cluster.Kubernetes.DisableKubeProxy {
 		cmd = fmt.Sprintf("%s --set kubeProxyReplacement=strict --set k8sServiceHost=%s --set k8sServicePort=%d", cmd, d.KubeConf.Cluster.ControlPlaneEndpoint.Address, d.KubeConf.Cluster.ControlPlaneEndpoint.Port)
@@ -19,9 +19,11 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var CalicoNew = template.Must(template.New("network-plugin.yaml").Parse(
+var CalicoNew = template.Must(util.ParseTemplate(template.New("network-plugin.yaml"),
 	dedent.Dedent(`
 ---
 # Source: calico/templates/calico-kube-controllers.yaml
@@ -114,7 +116,9 @@ data:
           "nodename": "__KUBERNETES_NODE_NAME__",
           "mtu": __CNI_MTU__,
           "ipam": {
-              "type": "calico-ipam"
+              "type": "calico-ipam"{{ if .KubePodsIPv6CIDR }},
+              "assign_ipv4": "{{ if .KubePodsCIDR }}true{{ else }}false{{ end }}",
+              "assign_ipv6": "true"{{ end }}
           },
           "policy": {
               "type": "k8s"
@@ -4846,10 +4850,21 @@ spec:
               valueFrom:
                 fieldRef:
                   fieldPath: status.hostIP
+{{- if .KubePodsIPv6CIDR }}
+            - name: IP_AUTODETECTION_METHOD
+              value: "kubernetes-internal-ip"
+            - name: IP
+              value: "{{ if .KubePodsCIDR }}autodetect{{ else }}none{{ end }}"
+            - name: IP6_AUTODETECTION_METHOD
+              value: "kubernetes-internal-ip"
+            - name: IP6
+              value: "autodetect"
+{{- else }}
             - name: IP_AUTODETECTION_METHOD
               value: "can-reach=$(NODEIP)"
             - name: IP
               value: "autodetect"
+{{- end }}
             # Enable IPIP
             - name: CALICO_IPV4POOL_IPIP
               value: "{{ .IPIPMode }}"
@@ -4888,10 +4903,18 @@ spec:
             # The default IPv4 pool to create on startup if none exists. Pod IPs will be
             # chosen from this range. Changing this value after installation will have
             # no effect.
+{{- if .KubePodsCIDR }}
             - name: CALICO_IPV4POOL_CIDR
               value: "{{ .KubePodsCIDR }}"
             - name: CALICO_IPV4POOL_BLOCK_SIZE
               value: "{{ .NodeCidrMaskSize }}"
+{{- end }}
+{{- if .KubePodsIPv6CIDR }}
+            - name: CALICO_IPV6POOL_CIDR
+              value: "{{ .KubePodsIPv6CIDR }}"
+            - name: CALICO_IPV6POOL_NAT_OUTGOING
+              value: "{{ if .IPV4POOLNATOUTGOING }}true{{ else }}false{{ end }}"
+{{- end }}
 {{- else }}
             - name: NO_DEFAULT_POOLS
               value: "true"
@@ -4905,9 +4928,9 @@ spec:
             # Set Felix endpoint to host default action to ACCEPT.
             - name: FELIX_DEFAULTENDPOINTTOHOSTACTION
               value: "ACCEPT"
-            # Disable IPv6 on Kubernetes.
+            # Enable IPv6 on Kubernetes only when an IPv6 pod CIDR is configured.
             - name: FELIX_IPV6SUPPORT
-              value: "false"
+              value: "{{ if .KubePodsIPv6CIDR }}true{{ else }}false{{ end }}"
             - name: FELIX_HEALTHENABLED
               value: "true"
             - name: FELIX_DEVICEROUTESOURCEADDRESS
@@ -19,9 +19,11 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var CalicoOld = template.Must(template.New("network-plugin.yaml").Parse(
+var CalicoOld = template.Must(util.ParseTemplate(template.New("network-plugin.yaml"),
 	dedent.Dedent(`---
 # Source: calico/templates/calico-config.yaml
 # This ConfigMap is used to configure a self-hosted Calico installation.
@@ -62,11 +62,11 @@ data:
 
   # Enable IPv4 addressing. If enabled, all endpoints are allocated an IPv4
   # address.
-  enable-ipv4: "true"
+  enable-ipv4: "{{ if .KubePodsIPv4CIDR }}true{{ else }}false{{ end }}"
 
   # Enable IPv6 addressing. If enabled, all endpoints are allocated an IPv6
   # address.
-  enable-ipv6: "false"
+  enable-ipv6: "{{ if .KubePodsIPv6CIDR }}true{{ else }}false{{ end }}"
   enable-bpf-clock-probe: "true"
 
   # If you want cilium monitor to aggregate tracing for packets, set this level
@@ -137,15 +137,20 @@ data:
   node-port-bind-protection: "true"
   enable-auto-protect-node-port-range: "true"
   enable-session-affinity: "true"
-  k8s-require-ipv4-pod-cidr: "true"
-  k8s-require-ipv6-pod-cidr: "false"
+  k8s-require-ipv4-pod-cidr: "{{ if .KubePodsIPv4CIDR }}true{{ else }}false{{ end }}"
+  k8s-require-ipv6-pod-cidr: "{{ if .KubePodsIPv6CIDR }}true{{ else }}false{{ end }}"
   enable-endpoint-health-checking: "true"
   enable-well-known-identities: "false"
   enable-remote-node-identity: "true"
   operator-api-serve-addr: "127.0.0.1:9234"
   ipam: "cluster-pool"
-  cluster-pool-ipv4-cidr: "{{ .KubePodsCIDR }}"
+{{- if .KubePodsIPv4CIDR }}
+  cluster-pool-ipv4-cidr: "{{ .KubePodsIPv4CIDR }}"
   cluster-pool-ipv4-mask-size: "{{ .NodeCidrMaskSize }}"
+{{- end }}
+{{- if .KubePodsIPv6CIDR }}
+  cluster-pool-ipv6-cidr: "{{ .KubePodsIPv6CIDR }}"
+{{- end }}
   disable-cnp-status-updates: "true"
 ---
 # Source: cilium/charts/agent/templates/clusterrole.yaml
@@ -19,9 +19,11 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var FlannelPSP = template.Must(template.New("network-plugin.yaml").Parse(
+var FlannelPSP = template.Must(util.ParseTemplate(template.New("network-plugin.yaml"),
 	dedent.Dedent(`---
 apiVersion: policy/v1beta1
 kind: PodSecurityPolicy
@@ -284,7 +286,7 @@ spec:
 
     `)))
 
-var FlannelPS = template.Must(template.New("network-plugin.yaml").Parse(
+var FlannelPS = template.Must(util.ParseTemplate(template.New("network-plugin.yaml"),
 	dedent.Dedent(`---
 apiVersion: v1
 kind: Namespace
@@ -17,12 +17,13 @@
 package templates
 
 import (
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/utils"
 	"github.com/lithammer/dedent"
 	"text/template"
 )
 
-var HybridnetNetworks = template.Must(template.New("hybridnet-networks.yaml").Funcs(utils.FuncMap).Parse(
+var HybridnetNetworks = template.Must(util.ParseTemplate(template.New("hybridnet-networks.yaml").Funcs(utils.FuncMap),
 	dedent.Dedent(`
 {{- range $index, $network := .Networks }}
 ---
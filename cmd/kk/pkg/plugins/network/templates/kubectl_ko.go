@@ -20,9 +20,11 @@ import (
 	"text/template"
 
 	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var KubectlKo = template.Must(template.New("kubectl-ko").Parse(
+var KubectlKo = template.Must(util.ParseTemplate(template.New("kubectl-ko"),
 	dedent.Dedent(`#!/bin/bash
 set -euo pipefail
 
@@ -20,10 +20,12 @@ import (
 	"text/template"
 
 	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 var (
-	KubeOvnCrd = template.Must(template.New("kube-ovn-crd.yaml").Parse(
+	KubeOvnCrd = template.Must(util.ParseTemplate(template.New("kube-ovn-crd.yaml"),
 		dedent.Dedent(`---
 apiVersion: apiextensions.k8s.io/v1
 kind: CustomResourceDefinition
@@ -1187,7 +1189,7 @@ spec:
       - htbqos
 `)))
 
-	OVN = template.Must(template.New("ovn.yaml").Parse(
+	OVN = template.Must(util.ParseTemplate(template.New("ovn.yaml"),
 		dedent.Dedent(`---
 {{ if .DpdkMode }}
 apiVersion: v1
@@ -2164,7 +2166,7 @@ spec:
             secretName: kube-ovn-tls
 {{ end }}`)))
 
-	KubeOvn = template.Must(template.New("kube-ovn.yaml").Parse(
+	KubeOvn = template.Must(util.ParseTemplate(template.New("kube-ovn.yaml"),
 		dedent.Dedent(`---
 kind: Deployment
 apiVersion: apps/v1
@@ -16,9 +16,11 @@ package templates
 import (
 	"github.com/lithammer/dedent"
 	"text/template"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
-var Multus = template.Must(template.New("multus-network-plugin.yaml").Parse(
+var Multus = template.Must(util.ParseTemplate(template.New("multus-network-plugin.yaml"),
 	dedent.Dedent(`
 ---
 apiVersion: apiextensions.k8s.io/v1
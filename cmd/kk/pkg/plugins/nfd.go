@@ -32,7 +32,7 @@ import (
 // features using node labels.
 
 var (
-	NodeFeatureDiscovery = template.Must(template.New("node-feature-discovery.yaml").Parse(
+	NodeFeatureDiscovery = template.Must(util.ParseTemplate(template.New("node-feature-discovery.yaml"),
 		dedent.Dedent(`---
 apiVersion: v1
 kind: Namespace
@@ -20,10 +20,12 @@ import (
 	"text/template"
 
 	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 // OpenEBS defines the template of openebs' manifests.
-var OpenEBS = template.Must(template.New("local-volume.yaml").Parse(
+var OpenEBS = template.Must(util.ParseTemplate(template.New("local-volume.yaml"),
 	dedent.Dedent(`---
 #Sample storage classes for OpenEBS Local PV
 apiVersion: storage.k8s.io/v1
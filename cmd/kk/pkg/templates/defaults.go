@@ -0,0 +1,130 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package templates lists the built-in templates which can be replaced by the templates in the --template-dir.
+package templates
+
+import (
+	"path/filepath"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	ostemplates "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/bootstrap/os/templates"
+	registrytemplates "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/bootstrap/registry/templates"
+	certstemplates "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/certs/templates"
+	containertemplates "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/container/templates"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
+	etcdtemplates "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/etcd/templates"
+	k3stemplates "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/k3s/templates"
+	k8etemplates "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/k8e/templates"
+	kubernetestemplates "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/kubernetes/templates"
+	lbtemplates "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/loadbalancer/templates"
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/plugins"
+	dnstemplates "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/plugins/dns/templates"
+	networktemplates "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/plugins/network/templates"
+	storagetemplates "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/plugins/storage/templates"
+	kstemplates "github.com/kubesphere/kubekey/v3/cmd/kk/pkg/version/kubesphere/templates"
+)
+
+// Default is a built-in template.
+type Default struct {
+	// Variant tells apart the templates which share the same name, only one of them is rendered for a cluster.
+	Variant  string
+	Template *template.Template
+}
+
+// Defaults returns all the built-in templates which are rendered by action.Template.
+func Defaults() []Default {
+	return []Default{
+		{Template: ostemplates.InitOsScriptTmpl},
+		{Template: registrytemplates.HarborConfigTempl},
+		{Template: registrytemplates.HarborServiceTempl},
+		{Template: registrytemplates.RegistryConfigTempl},
+		{Template: registrytemplates.RegistryServiceTempl},
+		{Template: certstemplates.K8sCertsRenewScript},
+		{Template: certstemplates.K8sCertsRenewService},
+		{Template: certstemplates.K8sCertsRenewTimer},
+		{Template: containertemplates.ContainerdConfig},
+		{Template: containertemplates.ContainerdService},
+		{Template: containertemplates.CriDockerdService},
+		{Template: containertemplates.CriDockerdSocket},
+		{Template: containertemplates.CrictlConfig},
+		{Template: containertemplates.CrioAuth},
+		{Template: containertemplates.CrioConfig},
+		{Template: containertemplates.CrioPolicy},
+		{Template: containertemplates.CrioRegistries},
+		{Template: containertemplates.CrioService},
+		{Template: containertemplates.DockerConfig},
+		{Template: containertemplates.DockerService},
+		{Template: etcdtemplates.EtcdBackupScript},
+		{Template: etcdtemplates.BackupETCDService},
+		{Template: etcdtemplates.BackupETCDTimer},
+		{Template: etcdtemplates.EtcdEnv},
+		{Template: etcdtemplates.ETCDService},
+		{Template: k3stemplates.K3sRegistryConfigTempl},
+		{Template: k3stemplates.K3sService},
+		{Template: k3stemplates.K3sServiceEnv},
+		{Template: k3stemplates.K3sKillallScript},
+		{Template: k3stemplates.K3sUninstallScript},
+		{Template: k8etemplates.K8eService},
+		{Template: k8etemplates.K8eServiceEnv},
+		{Template: k8etemplates.K8eKillallScript},
+		{Template: k8etemplates.K8eUninstallScript},
+		{Template: kubernetestemplates.AuditPolicy},
+		{Template: kubernetestemplates.AuditWebhook},
+		{Template: kubernetestemplates.KubeadmConfig},
+		{Template: kubernetestemplates.KubeletEnv},
+		{Template: kubernetestemplates.KubeletService},
+		{Template: lbtemplates.HaproxyConfig},
+		{Template: lbtemplates.HaproxyManifest},
+		{Template: lbtemplates.K3sKubevipManifest},
+		{Template: lbtemplates.KubevipManifest},
+		{Template: dnstemplates.Coredns},
+		{Template: dnstemplates.CorednsConfigMap},
+		{Template: dnstemplates.NodeLocalDNSService},
+		{Template: dnstemplates.NodeLocalDNSConfigMap},
+		{Template: plugins.KataDeploy},
+		{Template: plugins.NodeFeatureDiscovery},
+		{Variant: "calico", Template: networktemplates.CalicoNew},
+		{Variant: "calico-before-v1.16", Template: networktemplates.CalicoOld},
+		{Variant: "flannel", Template: networktemplates.FlannelPS},
+		{Variant: "flannel-psp", Template: networktemplates.FlannelPSP},
+		{Template: networktemplates.HybridnetNetworks},
+		{Template: networktemplates.KubectlKo},
+		{Template: networktemplates.KubeOvnCrd},
+		{Template: networktemplates.KubeOvn},
+		{Template: networktemplates.OVN},
+		{Template: networktemplates.Multus},
+		{Template: storagetemplates.OpenEBS},
+		{Template: kstemplates.KsInstaller},
+	}
+}
+
+// Dump writes the source of the built-in templates into dir. The templates which have a variant are written into
+// the sub directory named after the variant, move the one you need to dir to use it.
+func Dump(dir string, overwrite bool) error {
+	for _, d := range Defaults() {
+		path := filepath.Join(dir, d.Variant, d.Template.Name())
+		if !overwrite && util.IsExist(path) {
+			return errors.Errorf("%s already exists", path)
+		}
+		if err := util.WriteFile(path, []byte(util.TemplateSource(d.Template))); err != nil {
+			return errors.Wrapf(err, "write template %s failed", path)
+		}
+	}
+	return nil
+}
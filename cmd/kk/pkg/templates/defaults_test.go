@@ -0,0 +1,47 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package templates
+
+import (
+	"testing"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
+)
+
+func TestDefaultsRoundTrip(t *testing.T) {
+	seen := make(map[string]struct{})
+	for _, d := range Defaults() {
+		path := d.Variant + "/" + d.Template.Name()
+		if _, ok := seen[path]; ok {
+			t.Errorf("%s is dumped more than once", path)
+		}
+		seen[path] = struct{}{}
+
+		source := util.TemplateSource(d.Template)
+		reparsed, err := d.Template.Clone()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := util.ParseTemplate(reparsed, source); err != nil {
+			t.Errorf("parse the dumped %s failed: %v", path, err)
+			continue
+		}
+		if reparsed.Tree.Root.String() != d.Template.Tree.Root.String() {
+			t.Errorf("the dumped %s is not stable", path)
+		}
+	}
+}
@@ -20,10 +20,12 @@ import (
 	"text/template"
 
 	"github.com/lithammer/dedent"
+
+	"github.com/kubesphere/kubekey/v3/cmd/kk/pkg/core/util"
 )
 
 var (
-	KsInstaller = template.Must(template.New("kubesphere.yaml").Parse(
+	KsInstaller = template.Must(util.ParseTemplate(template.New("kubesphere.yaml"),
 		dedent.Dedent(`
 ---
 apiVersion: v1
@@ -30,6 +30,7 @@ import (
 	kkclustercontroller "github.com/kubesphere/kubekey/v3/controllers/kkcluster"
 	kkinstancecontroller "github.com/kubesphere/kubekey/v3/controllers/kkinstance"
 	kkmachinecontroller "github.com/kubesphere/kubekey/v3/controllers/kkmachine"
+	"github.com/kubesphere/kubekey/v3/pkg/clients/ssh"
 )
 
 // KKClusterReconciler reconciles a KKCluster object
@@ -80,6 +81,8 @@ type KKInstanceReconciler struct {
 	Scheme           *runtime.Scheme
 	Tracker          *remote.ClusterCacheTracker
 	Recorder         record.EventRecorder
+	SSHPool          *ssh.Pool
+	StrictCloudInit  bool
 	WatchFilterValue string
 	DataDir          string
 
@@ -94,6 +97,8 @@ func (r *KKInstanceReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		Recorder:               r.Recorder,
 		Tracker:                r.Tracker,
 		Scheme:                 r.Scheme,
+		SSHPool:                r.SSHPool,
+		StrictCloudInit:        r.StrictCloudInit,
 		WatchFilterValue:       r.WatchFilterValue,
 		DataDir:                r.DataDir,
 		WaitKKInstanceInterval: r.WaitKKInstanceInterval,
@@ -53,6 +53,8 @@ import (
 const (
 	// upgradeCheckFailedRequeueAfter is how long to wait before requeuing a cluster for which the upgrade check failed.
 	upgradeCheckFailedRequeueAfter = 30 * time.Second
+	// loadBalancerNotReadyRequeueAfter is how long to wait before checking the managed load balancer again.
+	loadBalancerNotReadyRequeueAfter = 30 * time.Second
 )
 
 // Reconciler reconciles a KKCluster object
@@ -214,10 +216,13 @@ func (r *Reconciler) reconcileNormal(ctx context.Context, clusterScope *scope.Cl
 		}
 	}
 
-	if _, err := net.LookupIP(kkCluster.Spec.ControlPlaneLoadBalancer.Host); err != nil {
-		conditions.MarkFalse(kkCluster, infrav1.ExternalLoadBalancerReadyCondition, infrav1.WaitForDNSNameResolveReason, clusterv1.ConditionSeverityInfo, "")
-		clusterScope.Info("Waiting on API server DNS name to resolve")
-		return reconcile.Result{RequeueAfter: 15 * time.Second}, nil //nolint:nilerr
+	// The host of the haproxy load balancer is resolved on the instances only.
+	if kkCluster.Spec.ControlPlaneLoadBalancer.Type != infrav1.HaproxyLoadBalancer {
+		if _, err := net.LookupIP(kkCluster.Spec.ControlPlaneLoadBalancer.Host); err != nil {
+			conditions.MarkFalse(kkCluster, infrav1.ExternalLoadBalancerReadyCondition, infrav1.WaitForDNSNameResolveReason, clusterv1.ConditionSeverityInfo, "")
+			clusterScope.Info("Waiting on API server DNS name to resolve")
+			return reconcile.Result{RequeueAfter: 15 * time.Second}, nil //nolint:nilerr
+		}
 	}
 	conditions.MarkTrue(kkCluster, infrav1.ExternalLoadBalancerReadyCondition)
 
@@ -228,6 +233,9 @@ func (r *Reconciler) reconcileNormal(ctx context.Context, clusterScope *scope.Cl
 
 	kkCluster.Status.Ready = true
 
+	// The managed load balancer is deployed by the KKInstances, so it only reports the state here.
+	res := r.reconcileLoadBalancer(clusterScope)
+
 	if res, err := r.reconcileInPlaceUpgrade(ctx, clusterScope); !res.IsZero() || err != nil {
 		return res, err
 	}
@@ -236,7 +244,55 @@ func (r *Reconciler) reconcileNormal(ctx context.Context, clusterScope *scope.Cl
 		return res, err
 	}
 
-	return ctrl.Result{}, nil
+	return res, nil
+}
+
+func (r *Reconciler) reconcileLoadBalancer(clusterScope *scope.ClusterScope) ctrl.Result {
+	kkCluster := clusterScope.KKCluster
+	if !kkCluster.Spec.ControlPlaneLoadBalancer.IsManaged() {
+		return ctrl.Result{}
+	}
+
+	var err error
+	switch kkCluster.Spec.ControlPlaneLoadBalancer.Type {
+	case infrav1.KubeVipLoadBalancer:
+		var conn net.Conn
+		conn, err = net.DialTimeout("tcp", net.JoinHostPort(kkCluster.Spec.ControlPlaneEndpoint.Host,
+			fmt.Sprint(kkCluster.Spec.ControlPlaneEndpoint.Port)), 5*time.Second)
+		if err == nil {
+			_ = conn.Close()
+		}
+	case infrav1.HaproxyLoadBalancer:
+		err = loadBalancerDeployed(clusterScope)
+	}
+	if err != nil {
+		conditions.MarkFalse(kkCluster, infrav1.ControlPlaneLoadBalancerReadyCondition, infrav1.WaitingForLoadBalancerReason,
+			clusterv1.ConditionSeverityInfo, err.Error())
+		clusterScope.Info("Waiting on the control plane load balancer", "reason", err.Error())
+		return ctrl.Result{RequeueAfter: loadBalancerNotReadyRequeueAfter}
+	}
+	conditions.MarkTrue(kkCluster, infrav1.ControlPlaneLoadBalancerReadyCondition)
+	return ctrl.Result{}
+}
+
+// loadBalancerDeployed returns an error if the load balancer is not deployed on any of the KKInstances.
+func loadBalancerDeployed(clusterScope *scope.ClusterScope) error {
+	instances, err := clusterScope.AllInstances()
+	if err != nil {
+		return err
+	}
+	var deployed int
+	for _, instance := range instances {
+		if conditions.IsTrue(instance, infrav1.KKInstanceLoadBalancerReadyCondition) {
+			deployed++
+		} else if conditions.IsFalse(instance, infrav1.KKInstanceLoadBalancerReadyCondition) {
+			return errors.Errorf("failed to deploy the load balancer on KKInstance %s", instance.Name)
+		}
+	}
+	if deployed == 0 {
+		return errors.New("the load balancer has not been deployed on any KKInstance")
+	}
+	return nil
 }
 
 func (r *Reconciler) reconcileInPlaceUpgrade(ctx context.Context, clusterScope *scope.ClusterScope) (ctrl.Result, error) {
@@ -54,6 +54,7 @@ import (
 	"github.com/kubesphere/kubekey/v3/pkg/service/binary"
 	"github.com/kubesphere/kubekey/v3/pkg/service/bootstrap"
 	"github.com/kubesphere/kubekey/v3/pkg/service/containermanager"
+	"github.com/kubesphere/kubekey/v3/pkg/service/loadbalancer"
 	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning"
 	"github.com/kubesphere/kubekey/v3/pkg/service/repository"
 	"github.com/kubesphere/kubekey/v3/util"
@@ -80,12 +81,15 @@ type Reconciler struct {
 	Tracker                 *remote.ClusterCacheTracker
 	Recorder                record.EventRecorder
 	Lock                    Locker
+	SSHPool                 *ssh.Pool
+	StrictCloudInit         bool
 	sshClientFactory        func(scope *scope.InstanceScope) ssh.Interface
 	bootstrapFactory        func(sshClient ssh.Interface, scope scope.LBScope, instanceScope *scope.InstanceScope) service.Bootstrap
 	repositoryFactory       func(sshClient ssh.Interface, scope scope.KKInstanceScope, instanceScope *scope.InstanceScope) service.Repository
 	binaryFactory           func(sshClient ssh.Interface, scope scope.KKInstanceScope, instanceScope *scope.InstanceScope, distribution string) service.BinaryService
 	containerManagerFactory func(sshClient ssh.Interface, scope scope.KKInstanceScope, instanceScope *scope.InstanceScope) service.ContainerManager
 	provisioningFactory     func(sshClient ssh.Interface, format bootstrapv1.Format) service.Provisioning
+	loadBalancerFactory     func(sshClient ssh.Interface, scope scope.LBScope, instanceScope *scope.InstanceScope) service.LoadBalancer
 	WatchFilterValue        string
 	DataDir                 string
 
@@ -110,7 +114,7 @@ func (r *Reconciler) getSSHClient(scope *scope.InstanceScope) ssh.Interface {
 			}
 		}
 	}
-	return ssh.NewClient(scope.KKInstance.Spec.Address, scope.KKInstance.Spec.Auth, &scope.Logger)
+	return ssh.NewPooledClient(scope.KKInstance.Spec.Address, scope.KKInstance.Spec.Auth, r.SSHPool, &scope.Logger)
 }
 
 func (r *Reconciler) getBootstrapService(sshClient ssh.Interface, scope scope.LBScope, instanceScope *scope.InstanceScope) service.Bootstrap {
@@ -145,7 +149,14 @@ func (r *Reconciler) getProvisioningService(sshClient ssh.Interface, format boot
 	if r.provisioningFactory != nil {
 		return r.provisioningFactory(sshClient, format)
 	}
-	return provisioning.NewService(sshClient, format)
+	return provisioning.NewService(sshClient, format, r.StrictCloudInit)
+}
+
+func (r *Reconciler) getLoadBalancerService(sshClient ssh.Interface, scope scope.LBScope, instanceScope *scope.InstanceScope) service.LoadBalancer {
+	if r.loadBalancerFactory != nil {
+		return r.loadBalancerFactory(sshClient, scope, instanceScope)
+	}
+	return loadbalancer.NewService(sshClient, scope, instanceScope)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -378,6 +389,10 @@ func (r *Reconciler) reconcileNormal(ctx context.Context, instanceScope *scope.I
 			}
 			return true, nil
 		})
+		if errors.Is(pollErr, loadbalancer.ErrControlPlaneNotInitialized) {
+			instanceScope.Info("Waiting for the control plane to be initialized")
+			return ctrl.Result{RequeueAfter: defaultRequeueWait}, nil
+		}
 		if pollErr != nil {
 			instanceScope.Error(pollErr, "failed to reconcile phase")
 			return ctrl.Result{RequeueAfter: defaultRequeueWait}, pollErr
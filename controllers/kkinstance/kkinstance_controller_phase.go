@@ -30,6 +30,7 @@ import (
 	"github.com/kubesphere/kubekey/v3/pkg/clients/ssh"
 	"github.com/kubesphere/kubekey/v3/pkg/scope"
 	"github.com/kubesphere/kubekey/v3/pkg/service"
+	"github.com/kubesphere/kubekey/v3/pkg/service/loadbalancer"
 )
 
 func (r *Reconciler) phaseFactory(kkInstanceScope scope.KKInstanceScope) []func(context.Context, ssh.Interface,
@@ -42,14 +43,18 @@ func (r *Reconciler) phaseFactory(kkInstanceScope scope.KKInstanceScope) []func(
 			r.reconcileRepository,
 			r.reconcileBinaryService,
 			r.reconcileContainerManager,
+			r.reconcileLoadBalancer,
 			r.reconcileProvisioning,
+			r.reconcileLoadBalancerDeploy,
 		)
 	case infrav1.K3S:
 		phases = append(phases,
 			r.reconcileBootstrap,
 			r.reconcileRepository,
 			r.reconcileBinaryService,
+			r.reconcileLoadBalancer,
 			r.reconcileProvisioning,
+			r.reconcileLoadBalancerDeploy,
 		)
 	}
 	return phases
@@ -308,3 +313,66 @@ func (r *Reconciler) reconcileProvisioning(ctx context.Context, sshClient ssh.In
 	}
 	return nil
 }
+
+func (r *Reconciler) reconcileLoadBalancer(ctx context.Context, sshClient ssh.Interface, instanceScope *scope.InstanceScope,
+	_ scope.KKInstanceScope, lbScope scope.LBScope) (err error) {
+	if !lbScope.ControlPlaneLoadBalancer().IsManaged() {
+		return nil
+	}
+	if conditions.IsTrue(instanceScope.KKInstance, infrav1.KKInstanceProvisionedCondition) {
+		return nil
+	}
+	defer func() {
+		// A worker waiting for the control plane to be initialized is requeued, it has not failed.
+		if err != nil && !errors.Is(err, loadbalancer.ErrControlPlaneNotInitialized) {
+			conditions.MarkFalse(
+				instanceScope.KKInstance,
+				infrav1.KKInstanceLoadBalancerReadyCondition,
+				infrav1.KKInstanceDeployLoadBalancerFailedReason,
+				clusterv1.ConditionSeverityWarning,
+				err.Error(),
+			)
+		}
+	}()
+
+	instanceScope.Info("Reconcile load balancer")
+
+	svc := r.getLoadBalancerService(sshClient, lbScope, instanceScope)
+	if err := svc.Prepare(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *Reconciler) reconcileLoadBalancerDeploy(ctx context.Context, sshClient ssh.Interface, instanceScope *scope.InstanceScope,
+	_ scope.KKInstanceScope, lbScope scope.LBScope) (err error) {
+	if !lbScope.ControlPlaneLoadBalancer().IsManaged() {
+		return nil
+	}
+	defer func() {
+		if err != nil {
+			conditions.MarkFalse(
+				instanceScope.KKInstance,
+				infrav1.KKInstanceLoadBalancerReadyCondition,
+				infrav1.KKInstanceDeployLoadBalancerFailedReason,
+				clusterv1.ConditionSeverityWarning,
+				err.Error(),
+			)
+		} else {
+			conditions.MarkTrue(instanceScope.KKInstance, infrav1.KKInstanceLoadBalancerReadyCondition)
+		}
+	}()
+
+	svc := r.getLoadBalancerService(sshClient, lbScope, instanceScope)
+	if conditions.IsTrue(instanceScope.KKInstance, infrav1.KKInstanceLoadBalancerReadyCondition) && svc.UpToDate() {
+		instanceScope.Info("Instance's load balancer is already ready")
+		return nil
+	}
+
+	instanceScope.Info("Reconcile load balancer deploy")
+
+	if err := svc.Deploy(ctx); err != nil {
+		return err
+	}
+	return nil
+}
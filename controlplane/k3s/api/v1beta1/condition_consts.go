@@ -133,3 +133,25 @@ const (
 	// generate a machine object.
 	MachineGenerationFailedReason = "MachineGenerationFailed"
 )
+
+const (
+	// EtcdSnapshotsAvailableCondition documents that the etcd snapshots of the K3sControlPlane could be listed.
+	// NOTE: This condition exists only if etcd snapshots are configured.
+	EtcdSnapshotsAvailableCondition clusterv1.ConditionType = "EtcdSnapshotsAvailable"
+
+	// EtcdSnapshotsListingFailedReason (Severity=Warning) documents a failure in listing the etcd snapshots,
+	// either from the S3 bucket or from the workload cluster.
+	EtcdSnapshotsListingFailedReason = "EtcdSnapshotsListingFailed"
+
+	// EtcdSnapshotRestoredCondition documents the last etcd snapshot restore of the K3sControlPlane.
+	// NOTE: This condition exists only once a restore has been requested.
+	EtcdSnapshotRestoredCondition clusterv1.ConditionType = "EtcdSnapshotRestored"
+
+	// EtcdSnapshotRestoringReason (Severity=Info) documents a K3sControlPlane resetting the first server
+	// with an etcd snapshot and rejoining the other servers.
+	EtcdSnapshotRestoringReason = "EtcdSnapshotRestoring"
+
+	// EtcdSnapshotRestoreFailedReason (Severity=Error) documents an etcd snapshot restore that could not be
+	// started or did not complete in time.
+	EtcdSnapshotRestoreFailedReason = "EtcdSnapshotRestoreFailed"
+)
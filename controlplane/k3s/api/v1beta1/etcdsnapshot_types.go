@@ -0,0 +1,171 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultEtcdSnapshotScheduleCron is the default schedule of the etcd snapshots, as in k3s.
+	DefaultEtcdSnapshotScheduleCron = "0 */12 * * *"
+
+	// DefaultEtcdSnapshotRetention is the default number of etcd snapshots to retain, as in k3s.
+	DefaultEtcdSnapshotRetention = 5
+
+	// DefaultEtcdSnapshotRestoreImage is the default image of the jobs restoring an etcd snapshot.
+	DefaultEtcdSnapshotRestoreImage = "busybox:1.35"
+
+	// EtcdSnapshotS3AccessKey is the key of the access key in the S3 credentials secret.
+	EtcdSnapshotS3AccessKey = "accessKey"
+
+	// EtcdSnapshotS3SecretKey is the key of the secret key in the S3 credentials secret.
+	EtcdSnapshotS3SecretKey = "secretKey"
+)
+
+// EtcdSnapshotSpec defines the scheduled snapshots of the embedded etcd datastore, taken by
+// `k3s etcd-snapshot` on every server.
+type EtcdSnapshotSpec struct {
+	// ScheduleCron is the snapshot interval time in cron spec.
+	// Defaults to every 12 hours.
+	// +optional
+	ScheduleCron string `json:"scheduleCron,omitempty"`
+
+	// Retention is the number of snapshots to retain.
+	// Defaults to 5.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Retention *int32 `json:"retention,omitempty"`
+
+	// S3 uploads the snapshots to an S3 compatible object storage.
+	// When not set, the snapshots are only kept on the servers.
+	// +optional
+	S3 *EtcdSnapshotS3Spec `json:"s3,omitempty"`
+
+	// RestoreImage is the image of the jobs restoring a snapshot on the servers.
+	// Defaults to busybox:1.35.
+	// +optional
+	RestoreImage string `json:"restoreImage,omitempty"`
+}
+
+// EtcdSnapshotS3Spec defines the S3 compatible object storage the etcd snapshots are uploaded to.
+type EtcdSnapshotS3Spec struct {
+	// Endpoint is the S3 endpoint, e.g. s3.amazonaws.com or minio.example.com:9000.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// EndpointCA is the PEM encoded CA certificate of the S3 endpoint.
+	// +optional
+	EndpointCA string `json:"endpointCA,omitempty"`
+
+	// SkipSSLVerify disables the verification of the S3 endpoint certificate.
+	// +optional
+	SkipSSLVerify bool `json:"skipSSLVerify,omitempty"`
+
+	// Insecure uses plain HTTP to connect to the S3 endpoint.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// Bucket is the S3 bucket name.
+	Bucket string `json:"bucket"`
+
+	// Region is the S3 region.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Folder is the folder of the bucket the snapshots are stored in.
+	// +optional
+	Folder string `json:"folder,omitempty"`
+
+	// CredentialsSecretRef references a secret in the K3sControlPlane namespace holding the
+	// accessKey and secretKey of the S3 endpoint. When not set, the servers use their IAM role.
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// EtcdSnapshot describes an etcd snapshot.
+type EtcdSnapshot struct {
+	// Name is the name of the snapshot, to be used in the restore annotation.
+	Name string `json:"name"`
+
+	// Location is the URI of the snapshot, a file:// URI on the server or a s3:// URI.
+	Location string `json:"location"`
+
+	// NodeName is the name of the node the snapshot was taken on.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// CreatedAt is the time the snapshot was taken.
+	// +optional
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+
+	// Size is the size of the snapshot in bytes.
+	// +optional
+	Size int64 `json:"size,omitempty"`
+}
+
+// EtcdSnapshotRestorePhase is the phase of an etcd snapshot restore.
+type EtcdSnapshotRestorePhase string
+
+const (
+	// EtcdSnapshotRestoreRunning means the restore is in progress.
+	EtcdSnapshotRestoreRunning EtcdSnapshotRestorePhase = "Running"
+
+	// EtcdSnapshotRestoreSucceeded means all the servers are back with the restored datastore.
+	EtcdSnapshotRestoreSucceeded EtcdSnapshotRestorePhase = "Succeeded"
+
+	// EtcdSnapshotRestoreFailed means the restore could not be started or did not complete in time.
+	EtcdSnapshotRestoreFailed EtcdSnapshotRestorePhase = "Failed"
+)
+
+// EtcdSnapshotRestoreStatus describes an etcd snapshot restore.
+type EtcdSnapshotRestoreStatus struct {
+	// ID identifies the restore, the servers are annotated with it once they are restored.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// SnapshotName is the name of the restored snapshot.
+	SnapshotName string `json:"snapshotName"`
+
+	// Phase is the phase of the restore.
+	Phase EtcdSnapshotRestorePhase `json:"phase"`
+
+	// InitNodeName is the name of the node reset with the snapshot, the other servers rejoin it.
+	// +optional
+	InitNodeName string `json:"initNodeName,omitempty"`
+
+	// NodeNames are the names of the server nodes taking part in the restore.
+	// +optional
+	NodeNames []string `json:"nodeNames,omitempty"`
+
+	// StartedAt is the time the restore was started.
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// JobsCreated is true once the restore jobs are created in the workload cluster.
+	// +optional
+	JobsCreated bool `json:"jobsCreated,omitempty"`
+
+	// CompletedAt is the time the restore succeeded or failed.
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+
+	// Message describes why the restore failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -49,6 +49,10 @@ const (
 	// K3sServerConfigurationAnnotation is a machine annotation that stores the json-marshalled string of K3SCP ClusterConfiguration.
 	// This annotation is used to detect any changes in ClusterConfiguration and trigger machine rollout in K3SCP.
 	K3sServerConfigurationAnnotation = "controlplane.cluster.x-k8s.io/k3s-server-configuration"
+
+	// RestoreEtcdSnapshotAnnotation is set by users on a K3sControlPlane to restore the etcd snapshot with the given name.
+	// The controller removes the annotation once the restore is started and reports its progress in the status.
+	RestoreEtcdSnapshotAnnotation = "controlplane.cluster.x-k8s.io/restore-etcd-snapshot"
 )
 
 // K3sControlPlaneSpec defines the desired state of K3sControlPlane
@@ -83,6 +87,10 @@ type K3sControlPlaneSpec struct {
 	// +optional
 	// +kubebuilder:default={type: "RollingUpdate", rollingUpdate: {maxSurge: 1}}
 	RolloutStrategy *RolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	// EtcdSnapshot configures the scheduled snapshots of the embedded etcd datastore.
+	// +optional
+	EtcdSnapshot *EtcdSnapshotSpec `json:"etcdSnapshot,omitempty"`
 }
 
 // K3sControlPlaneMachineTemplate defines the template for Machines
@@ -202,6 +210,14 @@ type K3sControlPlaneStatus struct {
 	// Conditions defines current service state of the K3sControlPlane.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// EtcdSnapshots lists the etcd snapshots available for a restore, newest first.
+	// +optional
+	EtcdSnapshots []EtcdSnapshot `json:"etcdSnapshots,omitempty"`
+
+	// LastEtcdSnapshotRestore reports the last etcd snapshot restore.
+	// +optional
+	LastEtcdSnapshotRestore *EtcdSnapshotRestoreStatus `json:"lastEtcdSnapshotRestore,omitempty"`
 }
 
 // +kubebuilder:object:root=true
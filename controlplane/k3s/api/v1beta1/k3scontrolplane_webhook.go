@@ -78,6 +78,8 @@ func defaultK3sControlPlaneSpec(s *K3sControlPlaneSpec, namespace string) {
 	infrabootstrapv1.DefaultK3sConfigSpec(&s.K3sConfigSpec)
 
 	s.RolloutStrategy = defaultRolloutStrategy(s.RolloutStrategy)
+
+	s.EtcdSnapshot = defaultEtcdSnapshot(s.EtcdSnapshot)
 }
 
 func defaultRolloutStrategy(rolloutStrategy *RolloutStrategy) *RolloutStrategy {
@@ -103,6 +105,24 @@ func defaultRolloutStrategy(rolloutStrategy *RolloutStrategy) *RolloutStrategy {
 	return rolloutStrategy
 }
 
+func defaultEtcdSnapshot(etcdSnapshot *EtcdSnapshotSpec) *EtcdSnapshotSpec {
+	if etcdSnapshot == nil {
+		return nil
+	}
+
+	if etcdSnapshot.ScheduleCron == "" {
+		etcdSnapshot.ScheduleCron = DefaultEtcdSnapshotScheduleCron
+	}
+	if etcdSnapshot.Retention == nil {
+		etcdSnapshot.Retention = pointer.Int32(DefaultEtcdSnapshotRetention)
+	}
+	if etcdSnapshot.RestoreImage == "" {
+		etcdSnapshot.RestoreImage = DefaultEtcdSnapshotRestoreImage
+	}
+
+	return etcdSnapshot
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (in *K3sControlPlane) ValidateCreate() error {
 	spec := in.Spec
@@ -142,6 +162,7 @@ func (in *K3sControlPlane) ValidateUpdate(old runtime.Object) error {
 		{spec, "version"},
 		{spec, "rolloutAfter"},
 		{spec, "rolloutStrategy", "*"},
+		{spec, "etcdSnapshot", "*"},
 	}
 
 	allErrs := validateK3sControlPlaneSpec(in.Spec, in.Namespace, field.NewPath("spec"))
@@ -266,6 +287,7 @@ func validateK3sControlPlaneSpec(s K3sControlPlaneSpec, namespace string, pathPr
 	}
 
 	allErrs = append(allErrs, validateRolloutStrategy(s.RolloutStrategy, s.Replicas, pathPrefix.Child("rolloutStrategy"))...)
+	allErrs = append(allErrs, validateEtcdSnapshot(s.EtcdSnapshot, s.K3sConfigSpec.ServerConfiguration, pathPrefix.Child("etcdSnapshot"))...)
 
 	return allErrs
 }
@@ -313,6 +335,69 @@ func validateRolloutStrategy(rolloutStrategy *RolloutStrategy, replicas *int32,
 	return allErrs
 }
 
+func validateEtcdSnapshot(etcdSnapshot *EtcdSnapshotSpec, serverConfiguration *infrabootstrapv1.ServerConfiguration, pathPrefix *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if etcdSnapshot == nil {
+		return allErrs
+	}
+
+	if serverConfiguration != nil && serverConfiguration.Database.DataStoreEndPoint != "" {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix,
+				"etcd snapshots require the embedded etcd datastore",
+			),
+		)
+	}
+
+	if cron := strings.Fields(etcdSnapshot.ScheduleCron); len(cron) != 5 && (len(cron) == 0 || !strings.HasPrefix(cron[0], "@")) {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("scheduleCron"),
+				etcdSnapshot.ScheduleCron,
+				"must be a cron spec with 5 fields or a descriptor such as @every 6h",
+			),
+		)
+	}
+
+	if etcdSnapshot.Retention != nil && *etcdSnapshot.Retention < 1 {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("retention"),
+				*etcdSnapshot.Retention,
+				"must be greater than 0",
+			),
+		)
+	}
+
+	if etcdSnapshot.S3 != nil {
+		if etcdSnapshot.S3.Bucket == "" {
+			allErrs = append(
+				allErrs,
+				field.Required(
+					pathPrefix.Child("s3", "bucket"),
+					"is required",
+				),
+			)
+		}
+		if etcdSnapshot.S3.CredentialsSecretRef != nil && etcdSnapshot.S3.CredentialsSecretRef.Name == "" {
+			allErrs = append(
+				allErrs,
+				field.Required(
+					pathPrefix.Child("s3", "credentialsSecretRef", "name"),
+					"is required",
+				),
+			)
+		}
+	}
+
+	return allErrs
+}
+
 func validateServerConfiguration(newServerConfiguration, oldServerConfiguration *infrabootstrapv1.ServerConfiguration, pathPrefix *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -59,6 +59,10 @@ type K3sControlPlaneTemplateResourceSpec struct {
 	// +optional
 	// +kubebuilder:default={type: "RollingUpdate", rollingUpdate: {maxSurge: 1}}
 	RolloutStrategy *RolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	// EtcdSnapshot configures the scheduled snapshots of the embedded etcd datastore.
+	// +optional
+	EtcdSnapshot *EtcdSnapshotSpec `json:"etcdSnapshot,omitempty"`
 }
 
 // K3sControlPlaneTemplateMachineTemplate defines the template for Machines
@@ -47,6 +47,8 @@ func (r *K3sControlPlaneTemplate) Default() {
 	infrabootstrapv1.DefaultK3sConfigSpec(&r.Spec.Template.Spec.K3sConfigSpec)
 
 	r.Spec.Template.Spec.RolloutStrategy = defaultRolloutStrategy(r.Spec.Template.Spec.RolloutStrategy)
+
+	r.Spec.Template.Spec.EtcdSnapshot = defaultEtcdSnapshot(r.Spec.Template.Spec.EtcdSnapshot)
 }
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-controlplane-cluster-x-k8s-io-v1beta1-k3scontrolplanetemplate,mutating=false,failurePolicy=fail,groups=controlplane.cluster.x-k8s.io,resources=k3scontrolplanetemplates,versions=v1beta1,name=validation.k3scontrolplanetemplate.controlplane.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
@@ -102,5 +104,7 @@ func (r *K3sControlPlaneTemplate) ValidateDelete() error {
 // validateK3sControlPlaneTemplateResourceSpec is a copy of validateK3sControlPlaneSpec which
 // only validates the fields in K3sControlPlaneTemplateResourceSpec we care about.
 func validateK3sControlPlaneTemplateResourceSpec(s K3sControlPlaneTemplateResourceSpec, pathPrefix *field.Path) field.ErrorList {
-	return validateRolloutStrategy(s.RolloutStrategy, nil, pathPrefix.Child("rolloutStrategy"))
+	allErrs := validateRolloutStrategy(s.RolloutStrategy, nil, pathPrefix.Child("rolloutStrategy"))
+	allErrs = append(allErrs, validateEtcdSnapshot(s.EtcdSnapshot, s.K3sConfigSpec.ServerConfiguration, pathPrefix.Child("etcdSnapshot"))...)
+	return allErrs
 }
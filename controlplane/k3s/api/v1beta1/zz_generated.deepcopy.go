@@ -22,12 +22,105 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshot) DeepCopyInto(out *EtcdSnapshot) {
+	*out = *in
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshot.
+func (in *EtcdSnapshot) DeepCopy() *EtcdSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotRestoreStatus) DeepCopyInto(out *EtcdSnapshotRestoreStatus) {
+	*out = *in
+	if in.NodeNames != nil {
+		in, out := &in.NodeNames, &out.NodeNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotRestoreStatus.
+func (in *EtcdSnapshotRestoreStatus) DeepCopy() *EtcdSnapshotRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotS3Spec) DeepCopyInto(out *EtcdSnapshotS3Spec) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotS3Spec.
+func (in *EtcdSnapshotS3Spec) DeepCopy() *EtcdSnapshotS3Spec {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotS3Spec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotSpec) DeepCopyInto(out *EtcdSnapshotSpec) {
+	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(int32)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(EtcdSnapshotS3Spec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotSpec.
+func (in *EtcdSnapshotSpec) DeepCopy() *EtcdSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *K3sControlPlane) DeepCopyInto(out *K3sControlPlane) {
 	*out = *in
@@ -133,6 +226,11 @@ func (in *K3sControlPlaneSpec) DeepCopyInto(out *K3sControlPlaneSpec) {
 		*out = new(RolloutStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EtcdSnapshot != nil {
+		in, out := &in.EtcdSnapshot, &out.EtcdSnapshot
+		*out = new(EtcdSnapshotSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K3sControlPlaneSpec.
@@ -165,6 +263,18 @@ func (in *K3sControlPlaneStatus) DeepCopyInto(out *K3sControlPlaneStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EtcdSnapshots != nil {
+		in, out := &in.EtcdSnapshots, &out.EtcdSnapshots
+		*out = make([]EtcdSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastEtcdSnapshotRestore != nil {
+		in, out := &in.LastEtcdSnapshotRestore, &out.LastEtcdSnapshotRestore
+		*out = new(EtcdSnapshotRestoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K3sControlPlaneStatus.
@@ -294,6 +404,11 @@ func (in *K3sControlPlaneTemplateResourceSpec) DeepCopyInto(out *K3sControlPlane
 		*out = new(RolloutStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EtcdSnapshot != nil {
+		in, out := &in.EtcdSnapshot, &out.EtcdSnapshot
+		*out = new(EtcdSnapshotSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K3sControlPlaneTemplateResourceSpec.
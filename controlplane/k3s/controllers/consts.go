@@ -32,4 +32,12 @@ const (
 	// dependentCertRequeueAfter is how long to wait before checking again to see if
 	// dependent certificates have been created.
 	dependentCertRequeueAfter = 30 * time.Second
+
+	// etcdSnapshotRestoreRequeueAfter is how long to wait before checking again to see if
+	// the servers are back after an etcd snapshot restore.
+	etcdSnapshotRestoreRequeueAfter = 30 * time.Second
+
+	// etcdSnapshotRestoreTimeout is how long to wait for the servers to be back after an etcd snapshot restore
+	// before reporting the restore as failed.
+	etcdSnapshotRestoreTimeout = 30 * time.Minute
 )
@@ -0,0 +1,346 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	infracontrolplanev1 "github.com/kubesphere/kubekey/v3/controlplane/k3s/api/v1beta1"
+	k3sCluster "github.com/kubesphere/kubekey/v3/controlplane/k3s/pkg/cluster"
+	"github.com/kubesphere/kubekey/v3/controlplane/k3s/pkg/etcdsnapshot"
+)
+
+// reconcileEtcdSnapshotCredentials generates the secret holding the S3 credentials drop-in file of the servers,
+// from the credentials secret referenced by the etcd snapshot spec.
+func (r *K3sControlPlaneReconciler) reconcileEtcdSnapshotCredentials(ctx context.Context, cluster *clusterv1.Cluster, kcp *infracontrolplanev1.K3sControlPlane) error {
+	if kcp.Spec.EtcdSnapshot == nil || kcp.Spec.EtcdSnapshot.S3 == nil || kcp.Spec.EtcdSnapshot.S3.CredentialsSecretRef == nil {
+		return nil
+	}
+
+	accessKey, secretKey, err := r.getEtcdSnapshotS3Credentials(ctx, kcp)
+	if err != nil {
+		return err
+	}
+	data, err := etcdsnapshot.CredentialsConfig(accessKey, secretKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate the etcd snapshot S3 credentials")
+	}
+
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      etcdsnapshot.CredentialsSecretName(kcp.Name),
+			Namespace: kcp.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, s, func() error {
+		if s.Labels == nil {
+			s.Labels = map[string]string{}
+		}
+		s.Labels[clusterv1.ClusterLabelName] = cluster.Name
+		s.Type = clusterv1.ClusterSecretType
+		s.Data = map[string][]byte{etcdsnapshot.CredentialsSecretKey: data}
+		return controllerutil.SetControllerReference(kcp, s, r.Scheme)
+	})
+	return errors.Wrapf(err, "failed to reconcile secret %s", s.Name)
+}
+
+// getEtcdSnapshotS3Credentials returns the access key and the secret key of the S3 endpoint,
+// or empty keys if no credentials secret is referenced.
+func (r *K3sControlPlaneReconciler) getEtcdSnapshotS3Credentials(ctx context.Context, kcp *infracontrolplanev1.K3sControlPlane) (string, string, error) {
+	ref := kcp.Spec.EtcdSnapshot.S3.CredentialsSecretRef
+	if ref == nil {
+		return "", "", nil
+	}
+
+	s := &corev1.Secret{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: ref.Name}, s); err != nil {
+		return "", "", errors.Wrapf(err, "failed to get the etcd snapshot S3 credentials secret %s", ref.Name)
+	}
+	accessKey, secretKey := string(s.Data[infracontrolplanev1.EtcdSnapshotS3AccessKey]), string(s.Data[infracontrolplanev1.EtcdSnapshotS3SecretKey])
+	if accessKey == "" || secretKey == "" {
+		return "", "", errors.Errorf("secret %s must hold both %s and %s", ref.Name, infracontrolplanev1.EtcdSnapshotS3AccessKey, infracontrolplanev1.EtcdSnapshotS3SecretKey)
+	}
+	return accessKey, secretKey, nil
+}
+
+// reconcileEtcdSnapshots lists the etcd snapshots in the status, from the S3 bucket when configured, otherwise from the workload cluster.
+// This operation is best effort, a listing failure is reported in the EtcdSnapshotsAvailable condition without returning any error.
+func (r *K3sControlPlaneReconciler) reconcileEtcdSnapshots(ctx context.Context, controlPlane *k3sCluster.ControlPlane) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	if kcp.Spec.EtcdSnapshot == nil {
+		kcp.Status.EtcdSnapshots = nil
+		conditions.Delete(kcp, infracontrolplanev1.EtcdSnapshotsAvailableCondition)
+		return
+	}
+
+	var snapshots []infracontrolplanev1.EtcdSnapshot
+	var err error
+	if s3 := kcp.Spec.EtcdSnapshot.S3; s3 != nil {
+		var accessKey, secretKey string
+		accessKey, secretKey, err = r.getEtcdSnapshotS3Credentials(ctx, kcp)
+		if err == nil {
+			snapshots, err = etcdsnapshot.ListS3(ctx, s3, accessKey, secretKey)
+		}
+	} else {
+		// The snapshots are recorded in the workload cluster, there is nothing to list before it is initialized.
+		if !kcp.Status.Initialized {
+			return
+		}
+		var workloadCluster k3sCluster.WorkloadCluster
+		workloadCluster, err = r.managementCluster.GetWorkloadCluster(ctx, util.ObjectKey(controlPlane.Cluster))
+		if err == nil {
+			snapshots, err = workloadCluster.ListEtcdSnapshots(ctx)
+		}
+	}
+	if err != nil {
+		log.Error(err, "Failed to list etcd snapshots")
+		conditions.MarkFalse(kcp, infracontrolplanev1.EtcdSnapshotsAvailableCondition, infracontrolplanev1.EtcdSnapshotsListingFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return
+	}
+
+	kcp.Status.EtcdSnapshots = snapshots
+	conditions.MarkTrue(kcp, infracontrolplanev1.EtcdSnapshotsAvailableCondition)
+}
+
+// reconcileEtcdSnapshotRestore restores the etcd snapshot requested with the restore annotation: the server holding the
+// snapshot, or the oldest server for a S3 snapshot, is reset with the snapshot and the other servers rejoin it.
+// The restore is recorded before its jobs are created, so a retry creates the same jobs instead of starting another restore.
+// While the restore is running, a non-zero result is returned so that no other operation is performed on the control plane.
+func (r *K3sControlPlaneReconciler) reconcileEtcdSnapshotRestore(ctx context.Context, controlPlane *k3sCluster.ControlPlane) (ctrl.Result, error) {
+	kcp := controlPlane.KCP
+
+	status := kcp.Status.LastEtcdSnapshotRestore
+	if status == nil || status.Phase != infracontrolplanev1.EtcdSnapshotRestoreRunning {
+		name, ok := kcp.Annotations[infracontrolplanev1.RestoreEtcdSnapshotAnnotation]
+		if !ok {
+			return ctrl.Result{}, nil
+		}
+
+		before := kcp.DeepCopy()
+		now := metav1.Now()
+		status = &infracontrolplanev1.EtcdSnapshotRestoreStatus{
+			ID:           etcdsnapshot.RestoreID(name, now.Time),
+			SnapshotName: name,
+			Phase:        infracontrolplanev1.EtcdSnapshotRestoreRunning,
+			StartedAt:    &now,
+		}
+		delete(kcp.Annotations, infracontrolplanev1.RestoreEtcdSnapshotAnnotation)
+		kcp.Status.LastEtcdSnapshotRestore = status
+
+		restore, err := newEtcdSnapshotRestore(controlPlane, name, status.ID)
+		if err != nil {
+			// The request cannot be fulfilled as is, report the failure and drop it.
+			r.failEtcdSnapshotRestore(kcp, err.Error())
+			return ctrl.Result{}, nil
+		}
+		status.InitNodeName = restore.InitNodeName
+		status.NodeNames = append([]string{restore.InitNodeName}, restore.JoinNodeNames...)
+		conditions.MarkFalse(kcp, infracontrolplanev1.EtcdSnapshotRestoredCondition, infracontrolplanev1.EtcdSnapshotRestoringReason, clusterv1.ConditionSeverityInfo, "Restoring etcd snapshot %s", name)
+		if err := r.persistEtcdSnapshotRestore(ctx, before, kcp); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if kcp.Annotations[infracontrolplanev1.RestoreEtcdSnapshotAnnotation] == status.SnapshotName {
+		// The annotation removal of the running restore has not been persisted yet.
+		delete(kcp.Annotations, infracontrolplanev1.RestoreEtcdSnapshotAnnotation)
+	}
+
+	if !status.JobsCreated {
+		return r.startEtcdSnapshotRestore(ctx, controlPlane)
+	}
+	return r.waitForEtcdSnapshotRestore(ctx, controlPlane)
+}
+
+// persistEtcdSnapshotRestore patches the restore status and the removal of the restore annotation right away,
+// instead of at the end of the reconciliation.
+func (r *K3sControlPlaneReconciler) persistEtcdSnapshotRestore(ctx context.Context, before, kcp *infracontrolplanev1.K3sControlPlane) error {
+	// The patches update the object they are given with the response, the changes are kept in kcp.
+	if err := r.Client.Status().Patch(ctx, kcp.DeepCopy(), client.MergeFrom(before)); err != nil {
+		return errors.Wrap(err, "failed to record the etcd snapshot restore")
+	}
+	if err := r.Client.Patch(ctx, kcp.DeepCopy(), client.MergeFrom(before)); err != nil {
+		return errors.Wrapf(err, "failed to remove the %s annotation", infracontrolplanev1.RestoreEtcdSnapshotAnnotation)
+	}
+	return nil
+}
+
+// startEtcdSnapshotRestore creates the jobs of the recorded restore. The jobs are named after the restore ID,
+// so the jobs created by a previous attempt are kept as is.
+func (r *K3sControlPlaneReconciler) startEtcdSnapshotRestore(ctx context.Context, controlPlane *k3sCluster.ControlPlane) (ctrl.Result, error) {
+	kcp := controlPlane.KCP
+	status := kcp.Status.LastEtcdSnapshotRestore
+	log := ctrl.LoggerFrom(ctx, "snapshot", status.SnapshotName)
+
+	if status.StartedAt == nil || time.Since(status.StartedAt.Time) > etcdSnapshotRestoreTimeout {
+		r.failEtcdSnapshotRestore(kcp, "timed out creating the restore jobs")
+		return ctrl.Result{}, nil
+	}
+
+	restore, err := newEtcdSnapshotRestore(controlPlane, status.SnapshotName, status.ID)
+	if err != nil {
+		r.failEtcdSnapshotRestore(kcp, err.Error())
+		return ctrl.Result{}, nil
+	}
+	// The servers planned when the restore was recorded are kept.
+	restore.InitNodeName = status.InitNodeName
+	restore.JoinNodeNames = nil
+	for _, n := range status.NodeNames {
+		if n != status.InitNodeName {
+			restore.JoinNodeNames = append(restore.JoinNodeNames, n)
+		}
+	}
+
+	workloadCluster, err := r.managementCluster.GetWorkloadCluster(ctx, util.ObjectKey(controlPlane.Cluster))
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "cannot get remote client to workload cluster")
+	}
+	// The jobs are gone from the datastore once the snapshot is restored, they must not be created again then.
+	restored, err := workloadCluster.EtcdSnapshotRestored(ctx, status.ID, []string{status.InitNodeName})
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to check the restore of etcd snapshot %s", status.SnapshotName)
+	}
+	if !restored {
+		if err := workloadCluster.RestoreEtcdSnapshot(ctx, restore); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to start the restore of etcd snapshot %s", status.SnapshotName)
+		}
+	}
+
+	log.Info("Restoring etcd snapshot", "initNode", restore.InitNodeName, "joinNodes", restore.JoinNodeNames)
+	r.recorder.Eventf(kcp, corev1.EventTypeNormal, "EtcdSnapshotRestoreStarted", "Restoring etcd snapshot %s on node %s", status.SnapshotName, restore.InitNodeName)
+	status.JobsCreated = true
+	return ctrl.Result{RequeueAfter: etcdSnapshotRestoreRequeueAfter}, nil
+}
+
+// newEtcdSnapshotRestore plans the restore of an etcd snapshot listed in the status on the control plane machines.
+func newEtcdSnapshotRestore(controlPlane *k3sCluster.ControlPlane, name, id string) (etcdsnapshot.Restore, error) {
+	kcp := controlPlane.KCP
+	restore := etcdsnapshot.Restore{}
+
+	if kcp.Spec.EtcdSnapshot == nil {
+		return restore, errors.New("etcd snapshots are not configured")
+	}
+	if !kcp.Status.Initialized {
+		return restore, errors.New("the control plane is not initialized")
+	}
+
+	var snapshot *infracontrolplanev1.EtcdSnapshot
+	for i := range kcp.Status.EtcdSnapshots {
+		if kcp.Status.EtcdSnapshots[i].Name == name {
+			snapshot = &kcp.Status.EtcdSnapshots[i]
+			break
+		}
+	}
+	if snapshot == nil {
+		return restore, errors.Errorf("etcd snapshot %s is not listed in the status", name)
+	}
+
+	nodeNames := make([]string, 0, len(controlPlane.Machines))
+	for _, m := range controlPlane.Machines.SortedByCreationTimestamp() {
+		if m.Status.NodeRef == nil {
+			return restore, errors.Errorf("machine %s has no node", m.Name)
+		}
+		nodeNames = append(nodeNames, m.Status.NodeRef.Name)
+	}
+	if len(nodeNames) == 0 {
+		return restore, errors.New("the control plane has no machines")
+	}
+
+	// A local snapshot is restored on the server holding it, a S3 snapshot on the oldest server.
+	initNodeName := nodeNames[0]
+	if etcdsnapshot.IsLocal(*snapshot) {
+		initNodeName = ""
+		for _, n := range nodeNames {
+			if n == snapshot.NodeName {
+				initNodeName = n
+			}
+		}
+		if initNodeName == "" {
+			return restore, errors.Errorf("etcd snapshot %s is stored on node %s, which is not a control plane node", name, snapshot.NodeName)
+		}
+	}
+
+	restore.ID = id
+	restore.Image = kcp.Spec.EtcdSnapshot.RestoreImage
+	restore.RestorePath = etcdsnapshot.RestorePath(*snapshot)
+	restore.InitNodeName = initNodeName
+	if sc := kcp.Spec.K3sConfigSpec.ServerConfiguration; sc != nil {
+		restore.DataDir = sc.Agent.Node.DataDir
+	}
+	for _, n := range nodeNames {
+		if n != initNodeName {
+			restore.JoinNodeNames = append(restore.JoinNodeNames, n)
+		}
+	}
+	return restore, nil
+}
+
+// waitForEtcdSnapshotRestore waits for all the servers to be annotated with the restore ID.
+// The workload cluster is expected to be unreachable while the servers restart, so connection errors are not reported.
+func (r *K3sControlPlaneReconciler) waitForEtcdSnapshotRestore(ctx context.Context, controlPlane *k3sCluster.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+	status := kcp.Status.LastEtcdSnapshotRestore
+
+	if status.StartedAt == nil || time.Since(status.StartedAt.Time) > etcdSnapshotRestoreTimeout {
+		r.failEtcdSnapshotRestore(kcp, "timed out waiting for the servers to restart with the restored datastore")
+		return ctrl.Result{}, nil
+	}
+
+	workloadCluster, err := r.managementCluster.GetWorkloadCluster(ctx, util.ObjectKey(controlPlane.Cluster))
+	if err != nil {
+		log.Info("Waiting for the workload cluster to be reachable after the etcd snapshot restore", "err", err.Error())
+		return ctrl.Result{RequeueAfter: etcdSnapshotRestoreRequeueAfter}, nil
+	}
+	restored, err := workloadCluster.EtcdSnapshotRestored(ctx, status.ID, status.NodeNames)
+	if err != nil {
+		log.Info("Waiting for the workload cluster to be reachable after the etcd snapshot restore", "err", err.Error())
+		return ctrl.Result{RequeueAfter: etcdSnapshotRestoreRequeueAfter}, nil
+	}
+	if !restored {
+		return ctrl.Result{RequeueAfter: etcdSnapshotRestoreRequeueAfter}, nil
+	}
+
+	now := metav1.Now()
+	status.Phase = infracontrolplanev1.EtcdSnapshotRestoreSucceeded
+	status.CompletedAt = &now
+	conditions.MarkTrue(kcp, infracontrolplanev1.EtcdSnapshotRestoredCondition)
+	r.recorder.Eventf(kcp, corev1.EventTypeNormal, "EtcdSnapshotRestored", "Restored etcd snapshot %s", status.SnapshotName)
+	log.Info("Restored etcd snapshot", "snapshot", status.SnapshotName)
+	return ctrl.Result{}, nil
+}
+
+func (r *K3sControlPlaneReconciler) failEtcdSnapshotRestore(kcp *infracontrolplanev1.K3sControlPlane, message string) {
+	now := metav1.Now()
+	status := kcp.Status.LastEtcdSnapshotRestore
+	status.Phase = infracontrolplanev1.EtcdSnapshotRestoreFailed
+	status.CompletedAt = &now
+	status.Message = message
+	conditions.MarkFalse(kcp, infracontrolplanev1.EtcdSnapshotRestoredCondition, infracontrolplanev1.EtcdSnapshotRestoreFailedReason, clusterv1.ConditionSeverityError, message)
+	r.recorder.Eventf(kcp, corev1.EventTypeWarning, "EtcdSnapshotRestoreFailed", "Failed to restore etcd snapshot %s: %s", status.SnapshotName, message)
+}
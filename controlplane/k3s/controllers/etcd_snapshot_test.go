@@ -0,0 +1,221 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infracontrolplanev1 "github.com/kubesphere/kubekey/v3/controlplane/k3s/api/v1beta1"
+	k3sCluster "github.com/kubesphere/kubekey/v3/controlplane/k3s/pkg/cluster"
+	"github.com/kubesphere/kubekey/v3/controlplane/k3s/pkg/etcdsnapshot"
+)
+
+type fakeManagementCluster struct {
+	client.Reader
+	workload *fakeWorkloadCluster
+}
+
+func (f *fakeManagementCluster) GetMachinesForCluster(_ context.Context, _ *clusterv1.Cluster, _ ...collections.Func) (collections.Machines, error) {
+	return nil, nil
+}
+
+func (f *fakeManagementCluster) GetMachinePoolsForCluster(_ context.Context, _ *clusterv1.Cluster) (*expv1.MachinePoolList, error) {
+	return nil, nil
+}
+
+func (f *fakeManagementCluster) GetWorkloadCluster(_ context.Context, _ client.ObjectKey) (k3sCluster.WorkloadCluster, error) {
+	return f.workload, nil
+}
+
+type fakeWorkloadCluster struct {
+	restoreErr error
+	restored   bool
+	restores   []etcdsnapshot.Restore
+}
+
+func (f *fakeWorkloadCluster) ClusterStatus(_ context.Context) (k3sCluster.Status, error) {
+	return k3sCluster.Status{}, nil
+}
+
+func (f *fakeWorkloadCluster) UpdateAgentConditions(_ context.Context, _ *k3sCluster.ControlPlane) {}
+
+func (f *fakeWorkloadCluster) UpdateEtcdConditions(_ context.Context, _ *k3sCluster.ControlPlane) {}
+
+func (f *fakeWorkloadCluster) ListEtcdSnapshots(_ context.Context) ([]infracontrolplanev1.EtcdSnapshot, error) {
+	return nil, nil
+}
+
+func (f *fakeWorkloadCluster) RestoreEtcdSnapshot(_ context.Context, restore etcdsnapshot.Restore) error {
+	f.restores = append(f.restores, restore)
+	return f.restoreErr
+}
+
+func (f *fakeWorkloadCluster) EtcdSnapshotRestored(_ context.Context, _ string, _ []string) (bool, error) {
+	return f.restored, nil
+}
+
+func newEtcdSnapshotRestoreControlPlane(g *WithT, snapshotName string) (*K3sControlPlaneReconciler, *fakeWorkloadCluster, *k3sCluster.ControlPlane) {
+	scheme := runtime.NewScheme()
+	g.Expect(infracontrolplanev1.AddToScheme(scheme)).To(Succeed())
+
+	kcp := &infracontrolplanev1.K3sControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "kcp",
+			Namespace:   metav1.NamespaceDefault,
+			Annotations: map[string]string{infracontrolplanev1.RestoreEtcdSnapshotAnnotation: snapshotName},
+		},
+		Spec: infracontrolplanev1.K3sControlPlaneSpec{
+			EtcdSnapshot: &infracontrolplanev1.EtcdSnapshotSpec{RestoreImage: infracontrolplanev1.DefaultEtcdSnapshotRestoreImage},
+		},
+		Status: infracontrolplanev1.K3sControlPlaneStatus{
+			Initialized: true,
+			EtcdSnapshots: []infracontrolplanev1.EtcdSnapshot{{
+				Name:     "etcd-snapshot-server-2-1663749600",
+				Location: "s3://k3s-snapshots/etcd-snapshot-server-2-1663749600",
+			}},
+		},
+	}
+
+	var machines []*clusterv1.Machine
+	for i, nodeName := range []string{"server-1", "server-2"} {
+		machines = append(machines, &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              nodeName,
+				Namespace:         metav1.NamespaceDefault,
+				CreationTimestamp: metav1.NewTime(time.Unix(1663700000, 0).Add(time.Duration(i) * time.Hour)),
+			},
+			Status: clusterv1.MachineStatus{NodeRef: &corev1.ObjectReference{Name: nodeName}},
+		})
+	}
+
+	workload := &fakeWorkloadCluster{}
+	r := &K3sControlPlaneReconciler{
+		Client:            fake.NewClientBuilder().WithScheme(scheme).WithObjects(kcp.DeepCopy()).Build(),
+		Scheme:            scheme,
+		recorder:          record.NewFakeRecorder(32),
+		managementCluster: &fakeManagementCluster{workload: workload},
+	}
+	controlPlane := &k3sCluster.ControlPlane{
+		KCP:      kcp,
+		Cluster:  &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: metav1.NamespaceDefault}},
+		Machines: collections.FromMachines(machines...),
+	}
+	return r, workload, controlPlane
+}
+
+func TestReconcileEtcdSnapshotRestore(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	snapshotName := "etcd-snapshot-server-2-1663749600"
+	r, workload, controlPlane := newEtcdSnapshotRestoreControlPlane(g, snapshotName)
+
+	// The jobs cannot be created, the restore is recorded anyway.
+	workload.restoreErr = errors.New("connection refused")
+	_, err := r.reconcileEtcdSnapshotRestore(ctx, controlPlane)
+	g.Expect(err).To(HaveOccurred())
+
+	persisted := &infracontrolplanev1.K3sControlPlane{}
+	g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(controlPlane.KCP), persisted)).To(Succeed())
+	g.Expect(persisted.Annotations).NotTo(HaveKey(infracontrolplanev1.RestoreEtcdSnapshotAnnotation))
+	status := persisted.Status.LastEtcdSnapshotRestore
+	g.Expect(status).NotTo(BeNil())
+	g.Expect(status.Phase).To(Equal(infracontrolplanev1.EtcdSnapshotRestoreRunning))
+	g.Expect(status.ID).To(HavePrefix(snapshotName + "-"))
+	g.Expect(status.JobsCreated).To(BeFalse())
+	g.Expect(status.NodeNames).To(Equal([]string{"server-1", "server-2"}))
+
+	// The next reconciliation starts from the persisted object and creates the jobs of the same restore.
+	controlPlane.KCP = persisted
+	workload.restoreErr = nil
+	result, err := r.reconcileEtcdSnapshotRestore(ctx, controlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(etcdSnapshotRestoreRequeueAfter))
+	g.Expect(workload.restores).To(HaveLen(2))
+	for _, restore := range workload.restores {
+		g.Expect(restore.ID).To(Equal(status.ID))
+		g.Expect(restore.InitNodeName).To(Equal("server-1"))
+		g.Expect(restore.JoinNodeNames).To(Equal([]string{"server-2"}))
+		g.Expect(restore.RestorePath).To(Equal(snapshotName))
+	}
+	g.Expect(persisted.Status.LastEtcdSnapshotRestore.JobsCreated).To(BeTrue())
+
+	// No more jobs are created while waiting for the servers.
+	result, err = r.reconcileEtcdSnapshotRestore(ctx, controlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(etcdSnapshotRestoreRequeueAfter))
+	g.Expect(workload.restores).To(HaveLen(2))
+
+	workload.restored = true
+	result, err = r.reconcileEtcdSnapshotRestore(ctx, controlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.IsZero()).To(BeTrue())
+	g.Expect(persisted.Status.LastEtcdSnapshotRestore.Phase).To(Equal(infracontrolplanev1.EtcdSnapshotRestoreSucceeded))
+	g.Expect(conditions.IsTrue(persisted, infracontrolplanev1.EtcdSnapshotRestoredCondition)).To(BeTrue())
+}
+
+func TestReconcileEtcdSnapshotRestoreAlreadyRestored(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	r, workload, controlPlane := newEtcdSnapshotRestoreControlPlane(g, "etcd-snapshot-server-2-1663749600")
+
+	// The jobs were created but not recorded, and the reset server is already restored: its jobs are gone.
+	now := metav1.Now()
+	delete(controlPlane.KCP.Annotations, infracontrolplanev1.RestoreEtcdSnapshotAnnotation)
+	controlPlane.KCP.Status.LastEtcdSnapshotRestore = &infracontrolplanev1.EtcdSnapshotRestoreStatus{
+		ID:           etcdsnapshot.RestoreID("etcd-snapshot-server-2-1663749600", now.Time),
+		SnapshotName: "etcd-snapshot-server-2-1663749600",
+		Phase:        infracontrolplanev1.EtcdSnapshotRestoreRunning,
+		InitNodeName: "server-1",
+		NodeNames:    []string{"server-1", "server-2"},
+		StartedAt:    &now,
+	}
+	workload.restored = true
+
+	result, err := r.reconcileEtcdSnapshotRestore(ctx, controlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(etcdSnapshotRestoreRequeueAfter))
+	g.Expect(workload.restores).To(BeEmpty())
+	g.Expect(controlPlane.KCP.Status.LastEtcdSnapshotRestore.JobsCreated).To(BeTrue())
+}
+
+func TestReconcileEtcdSnapshotRestoreUnknownSnapshot(t *testing.T) {
+	g := NewWithT(t)
+	r, workload, controlPlane := newEtcdSnapshotRestoreControlPlane(g, "etcd-snapshot-server-9-1663749600")
+
+	result, err := r.reconcileEtcdSnapshotRestore(context.Background(), controlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.IsZero()).To(BeTrue())
+	g.Expect(workload.restores).To(BeEmpty())
+	g.Expect(controlPlane.KCP.Annotations).NotTo(HaveKey(infracontrolplanev1.RestoreEtcdSnapshotAnnotation))
+	g.Expect(controlPlane.KCP.Status.LastEtcdSnapshotRestore.Phase).To(Equal(infracontrolplanev1.EtcdSnapshotRestoreFailed))
+	g.Expect(conditions.GetReason(controlPlane.KCP, infracontrolplanev1.EtcdSnapshotRestoredCondition)).To(Equal(infracontrolplanev1.EtcdSnapshotRestoreFailedReason))
+}
@@ -235,6 +235,8 @@ func patchK3sControlPlane(ctx context.Context, patchHelper *patch.Helper, kcp *i
 			infracontrolplanev1.MachinesReadyCondition,
 			infracontrolplanev1.AvailableCondition,
 			infracontrolplanev1.CertificatesAvailableCondition,
+			infracontrolplanev1.EtcdSnapshotsAvailableCondition,
+			infracontrolplanev1.EtcdSnapshotRestoredCondition,
 		}},
 		patch.WithStatusObservedGeneration{},
 	)
@@ -266,6 +268,12 @@ func (r *K3sControlPlaneReconciler) reconcile(ctx context.Context, cluster *clus
 	}
 	conditions.MarkTrue(kcp, infracontrolplanev1.CertificatesAvailableCondition)
 
+	// Generate the etcd snapshot S3 credentials referenced by the bootstrap configs of the machines.
+	if err := r.reconcileEtcdSnapshotCredentials(ctx, cluster, kcp); err != nil {
+		log.Error(err, "unable to reconcile the etcd snapshot S3 credentials")
+		return ctrl.Result{}, err
+	}
+
 	// If ControlPlaneEndpoint is not set, return early
 	if !cluster.Spec.ControlPlaneEndpoint.IsValid() {
 		log.Info("Cluster does not yet have a ControlPlaneEndpoint defined")
@@ -309,6 +317,13 @@ func (r *K3sControlPlaneReconciler) reconcile(ctx context.Context, cluster *clus
 	// source ref (reason@machine/name) so the problem can be easily tracked down to its source machine.
 	conditions.SetAggregate(controlPlane.KCP, infracontrolplanev1.MachinesReadyCondition, ownedMachines.ConditionGetters(), conditions.AddSourceRef(), conditions.WithStepCounterIf(false))
 
+	// An etcd snapshot restore takes precedence over any other operation, including the conditions update
+	// as the workload cluster is unreachable while the servers restart.
+	if result, err := r.reconcileEtcdSnapshotRestore(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+	r.reconcileEtcdSnapshots(ctx, controlPlane)
+
 	// Updates conditions reporting the status of static pods and the status of the etcd cluster.
 	// NOTE: Conditions reporting KCP operation progress like e.g. Resized or SpecUpToDate are inlined with the rest of the execution.
 	if result, err := r.reconcileControlPlaneConditions(ctx, controlPlane); err != nil || !result.IsZero() {
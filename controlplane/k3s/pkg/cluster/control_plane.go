@@ -38,6 +38,7 @@ import (
 
 	infrabootstrapv1 "github.com/kubesphere/kubekey/v3/bootstrap/k3s/api/v1beta1"
 	infracontrolplanev1 "github.com/kubesphere/kubekey/v3/controlplane/k3s/api/v1beta1"
+	"github.com/kubesphere/kubekey/v3/controlplane/k3s/pkg/etcdsnapshot"
 )
 
 // Log is the global logger for the internal package.
@@ -171,6 +172,7 @@ func (c *ControlPlane) NextFailureDomainForScaleUp() *string {
 func (c *ControlPlane) InitialControlPlaneConfig() *infrabootstrapv1.K3sConfigSpec {
 	bootstrapSpec := c.KCP.Spec.K3sConfigSpec.DeepCopy()
 	bootstrapSpec.AgentConfiguration = nil
+	bootstrapSpec.Files = append(bootstrapSpec.Files, etcdsnapshot.Files(c.KCP)...)
 	return bootstrapSpec
 }
 
@@ -178,6 +180,7 @@ func (c *ControlPlane) InitialControlPlaneConfig() *infrabootstrapv1.K3sConfigSp
 func (c *ControlPlane) JoinControlPlaneConfig() *infrabootstrapv1.K3sConfigSpec {
 	bootstrapSpec := c.KCP.Spec.K3sConfigSpec.DeepCopy()
 	bootstrapSpec.AgentConfiguration = nil
+	bootstrapSpec.Files = append(bootstrapSpec.Files, etcdsnapshot.Files(c.KCP)...)
 	return bootstrapSpec
 }
 
@@ -27,6 +27,7 @@ import (
 
 	infrabootstrapv1 "github.com/kubesphere/kubekey/v3/bootstrap/k3s/api/v1beta1"
 	infracontrolplanev1 "github.com/kubesphere/kubekey/v3/controlplane/k3s/api/v1beta1"
+	"github.com/kubesphere/kubekey/v3/controlplane/k3s/pkg/etcdsnapshot"
 )
 
 // MatchesMachineSpec returns a filter to find all machines that matches with KCP config and do not require any rollout.
@@ -183,6 +184,9 @@ func matchInitOrJoinConfiguration(machineConfig *infrabootstrapv1.K3sConfig, kcp
 func getAdjustedKcpConfig(kcp *infracontrolplanev1.K3sControlPlane, machineConfig *infrabootstrapv1.K3sConfig) *infrabootstrapv1.K3sConfigSpec {
 	kcpConfig := kcp.Spec.K3sConfigSpec.DeepCopy()
 
+	// The etcd snapshot configuration files are added to the K3sConfig of every machine.
+	kcpConfig.Files = append(kcpConfig.Files, etcdsnapshot.Files(kcp)...)
+
 	// Machine's join configuration is nil when it is the first machine in the control plane.
 	if machineConfig.Spec.AgentConfiguration == nil {
 		kcpConfig.AgentConfiguration = nil
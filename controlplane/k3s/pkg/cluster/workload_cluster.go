@@ -23,6 +23,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/cluster-api/util"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	infracontrolplanev1 "github.com/kubesphere/kubekey/v3/controlplane/k3s/api/v1beta1"
+	"github.com/kubesphere/kubekey/v3/controlplane/k3s/pkg/etcdsnapshot"
 )
 
 const (
@@ -37,6 +40,11 @@ type WorkloadCluster interface {
 	ClusterStatus(ctx context.Context) (Status, error)
 	UpdateAgentConditions(ctx context.Context, controlPlane *ControlPlane)
 	UpdateEtcdConditions(ctx context.Context, controlPlane *ControlPlane)
+
+	// Etcd snapshots.
+	ListEtcdSnapshots(ctx context.Context) ([]infracontrolplanev1.EtcdSnapshot, error)
+	RestoreEtcdSnapshot(ctx context.Context, restore etcdsnapshot.Restore) error
+	EtcdSnapshotRestored(ctx context.Context, restoreID string, nodeNames []string) (bool, error)
 }
 
 // Workload defines operations on workload clusters.
@@ -0,0 +1,84 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	infracontrolplanev1 "github.com/kubesphere/kubekey/v3/controlplane/k3s/api/v1beta1"
+	"github.com/kubesphere/kubekey/v3/controlplane/k3s/pkg/etcdsnapshot"
+)
+
+// ListEtcdSnapshots returns the etcd snapshots recorded by k3s in the workload cluster, newest first.
+func (w *Workload) ListEtcdSnapshots(ctx context.Context) ([]infracontrolplanev1.EtcdSnapshot, error) {
+	cm := &corev1.ConfigMap{}
+	key := ctrlclient.ObjectKey{Namespace: etcdsnapshot.ConfigMapNamespace, Name: etcdsnapshot.ConfigMapName}
+	if err := w.Client.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return []infracontrolplanev1.EtcdSnapshot{}, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get the %s ConfigMap", key)
+	}
+	return etcdsnapshot.FromConfigMap(cm), nil
+}
+
+// RestoreEtcdSnapshot starts the restore jobs on the server nodes.
+// The address of the reset server is looked up from its node.
+func (w *Workload) RestoreEtcdSnapshot(ctx context.Context, restore etcdsnapshot.Restore) error {
+	node := &corev1.Node{}
+	if err := w.Client.Get(ctx, ctrlclient.ObjectKey{Name: restore.InitNodeName}, node); err != nil {
+		return errors.Wrapf(err, "failed to get node %s", restore.InitNodeName)
+	}
+	for _, address := range node.Status.Addresses {
+		if address.Type == corev1.NodeInternalIP {
+			restore.InitNodeAddress = address.Address
+			break
+		}
+	}
+	if restore.InitNodeAddress == "" {
+		return errors.Errorf("node %s has no internal IP", restore.InitNodeName)
+	}
+
+	for _, job := range restore.Jobs() {
+		if err := w.Client.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "failed to create job %s", job.Name)
+		}
+	}
+	return nil
+}
+
+// EtcdSnapshotRestored returns true if all the given nodes are back with the datastore restored by the given restore.
+func (w *Workload) EtcdSnapshotRestored(ctx context.Context, restoreID string, nodeNames []string) (bool, error) {
+	for _, name := range nodeNames {
+		node := &corev1.Node{}
+		if err := w.Client.Get(ctx, ctrlclient.ObjectKey{Name: name}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, errors.Wrapf(err, "failed to get node %s", name)
+		}
+		if node.Annotations[etcdsnapshot.RestoredAnnotation] != restoreID {
+			return false, nil
+		}
+	}
+	return true, nil
+}
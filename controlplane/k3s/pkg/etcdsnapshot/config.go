@@ -0,0 +1,127 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package etcdsnapshot
+
+import (
+	"fmt"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	kubeyaml "sigs.k8s.io/yaml"
+
+	infracontrolplanev1 "github.com/kubesphere/kubekey/v3/controlplane/k3s/api/v1beta1"
+)
+
+const (
+	// ConfigFile is the k3s drop-in configuration file holding the etcd snapshot flags.
+	ConfigFile = "/etc/rancher/k3s/config.yaml.d/50-etcd-snapshot.yaml"
+
+	// CredentialsFile is the k3s drop-in configuration file holding the S3 credentials.
+	CredentialsFile = "/etc/rancher/k3s/config.yaml.d/51-etcd-snapshot-s3-credentials.yaml"
+
+	// EndpointCAFile is the file holding the CA certificate of the S3 endpoint.
+	EndpointCAFile = "/etc/rancher/k3s/etcd-s3-ca.crt"
+
+	// CredentialsSecretKey is the key of the credentials drop-in file in the secret generated by the controller.
+	CredentialsSecretKey = "config.yaml"
+)
+
+// config is the etcd snapshot part of the k3s server configuration.
+type config struct {
+	ScheduleCron  string `json:"etcd-snapshot-schedule-cron,omitempty"`
+	Retention     int32  `json:"etcd-snapshot-retention,omitempty"`
+	S3            bool   `json:"etcd-s3,omitempty"`
+	Endpoint      string `json:"etcd-s3-endpoint,omitempty"`
+	EndpointCA    string `json:"etcd-s3-endpoint-ca,omitempty"`
+	SkipSSLVerify bool   `json:"etcd-s3-skip-ssl-verify,omitempty"`
+	Bucket        string `json:"etcd-s3-bucket,omitempty"`
+	Region        string `json:"etcd-s3-region,omitempty"`
+	Folder        string `json:"etcd-s3-folder,omitempty"`
+	Insecure      bool   `json:"etcd-s3-insecure,omitempty"`
+}
+
+// s3Credentials is the S3 credentials part of the k3s server configuration.
+type s3Credentials struct {
+	AccessKey string `json:"etcd-s3-access-key"`
+	SecretKey string `json:"etcd-s3-secret-key"`
+}
+
+// CredentialsSecretName returns the name of the secret generated by the controller holding the S3 credentials drop-in file.
+func CredentialsSecretName(kcpName string) string {
+	return fmt.Sprintf("%s-etcd-snapshot-s3", kcpName)
+}
+
+// Files returns the files configuring the etcd snapshots on the servers of a K3sControlPlane.
+// The S3 credentials are not inlined, they are resolved from the secret generated by the controller.
+// NOTE: The files are part of the K3sConfig of the machines, so any change to the etcd snapshot spec rolls out the control plane.
+func Files(kcp *infracontrolplanev1.K3sControlPlane) []bootstrapv1.File {
+	spec := kcp.Spec.EtcdSnapshot
+	if spec == nil {
+		return nil
+	}
+
+	c := config{ScheduleCron: spec.ScheduleCron}
+	if spec.Retention != nil {
+		c.Retention = *spec.Retention
+	}
+
+	files := make([]bootstrapv1.File, 0, 3)
+	if s3 := spec.S3; s3 != nil {
+		c.S3 = true
+		c.Endpoint = s3.Endpoint
+		c.SkipSSLVerify = s3.SkipSSLVerify
+		c.Bucket = s3.Bucket
+		c.Region = s3.Region
+		c.Folder = s3.Folder
+		c.Insecure = s3.Insecure
+		if s3.EndpointCA != "" {
+			c.EndpointCA = EndpointCAFile
+			files = append(files, bootstrapv1.File{
+				Path:        EndpointCAFile,
+				Content:     s3.EndpointCA,
+				Owner:       "root:root",
+				Permissions: "0644",
+			})
+		}
+		if s3.CredentialsSecretRef != nil {
+			files = append(files, bootstrapv1.File{
+				Path:        CredentialsFile,
+				Owner:       "root:root",
+				Permissions: "0600",
+				ContentFrom: &bootstrapv1.FileSource{
+					Secret: bootstrapv1.SecretFileSource{
+						Name: CredentialsSecretName(kcp.Name),
+						Key:  CredentialsSecretKey,
+					},
+				},
+			})
+		}
+	}
+
+	// marshalling a struct of strings, booleans and integers cannot fail.
+	b, _ := kubeyaml.Marshal(c)
+	return append([]bootstrapv1.File{{
+		Path:        ConfigFile,
+		Content:     string(b),
+		Owner:       "root:root",
+		Permissions: "0640",
+	}}, files...)
+}
+
+// CredentialsConfig returns the k3s drop-in configuration file holding the S3 credentials.
+func CredentialsConfig(accessKey, secretKey string) ([]byte, error) {
+	return kubeyaml.Marshal(s3Credentials{AccessKey: accessKey, SecretKey: secretKey})
+}
@@ -0,0 +1,112 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package etcdsnapshot
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	infracontrolplanev1 "github.com/kubesphere/kubekey/v3/controlplane/k3s/api/v1beta1"
+)
+
+func TestFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *infracontrolplanev1.EtcdSnapshotSpec
+		want []bootstrapv1.File
+	}{
+		{
+			name: "no etcd snapshots",
+			spec: nil,
+			want: nil,
+		},
+		{
+			name: "local snapshots",
+			spec: &infracontrolplanev1.EtcdSnapshotSpec{ScheduleCron: "0 */6 * * *", Retention: pointer.Int32(3)},
+			want: []bootstrapv1.File{
+				{
+					Path:        ConfigFile,
+					Owner:       "root:root",
+					Permissions: "0640",
+					Content:     "etcd-snapshot-retention: 3\netcd-snapshot-schedule-cron: 0 */6 * * *\n",
+				},
+			},
+		},
+		{
+			name: "S3 snapshots",
+			spec: &infracontrolplanev1.EtcdSnapshotSpec{
+				ScheduleCron: "@every 1h",
+				Retention:    pointer.Int32(5),
+				S3: &infracontrolplanev1.EtcdSnapshotS3Spec{
+					Endpoint:             "minio.example.com:9000",
+					EndpointCA:           "-----BEGIN CERTIFICATE-----\n",
+					Bucket:               "k3s-snapshots",
+					Folder:               "cluster-a",
+					CredentialsSecretRef: &corev1.LocalObjectReference{Name: "minio"},
+				},
+			},
+			want: []bootstrapv1.File{
+				{
+					Path:        ConfigFile,
+					Owner:       "root:root",
+					Permissions: "0640",
+					Content: "etcd-s3: true\netcd-s3-bucket: k3s-snapshots\netcd-s3-endpoint: minio.example.com:9000\n" +
+						"etcd-s3-endpoint-ca: /etc/rancher/k3s/etcd-s3-ca.crt\netcd-s3-folder: cluster-a\n" +
+						"etcd-snapshot-retention: 5\netcd-snapshot-schedule-cron: '@every 1h'\n",
+				},
+				{
+					Path:        EndpointCAFile,
+					Owner:       "root:root",
+					Permissions: "0644",
+					Content:     "-----BEGIN CERTIFICATE-----\n",
+				},
+				{
+					Path:        CredentialsFile,
+					Owner:       "root:root",
+					Permissions: "0600",
+					ContentFrom: &bootstrapv1.FileSource{
+						Secret: bootstrapv1.SecretFileSource{Name: "k3s-cp-etcd-snapshot-s3", Key: CredentialsSecretKey},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			kcp := &infracontrolplanev1.K3sControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "k3s-cp"},
+				Spec:       infracontrolplanev1.K3sControlPlaneSpec{EtcdSnapshot: tt.spec},
+			}
+			g.Expect(Files(kcp)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestCredentialsConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	b, err := CredentialsConfig("minioadmin", "pa$$word")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(b)).To(Equal("etcd-s3-access-key: minioadmin\netcd-s3-secret-key: pa$$word\n"))
+}
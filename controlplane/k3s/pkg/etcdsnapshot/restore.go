@@ -0,0 +1,173 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package etcdsnapshot
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	infracontrolplanev1 "github.com/kubesphere/kubekey/v3/controlplane/k3s/api/v1beta1"
+)
+
+const (
+	// RestoredAnnotation is set on a server node once it is back with the restored datastore.
+	// Its value is the ID of the restore.
+	RestoredAnnotation = "controlplane.cluster.x-k8s.io/etcd-snapshot-restored"
+
+	// DefaultDataDir is the default k3s data directory.
+	DefaultDataDir = "/var/lib/rancher/k3s"
+
+	restoreJobLabel = "controlplane.cluster.x-k8s.io/etcd-snapshot-restore"
+	kubeconfigFile  = "/etc/rancher/k3s/k3s.yaml"
+)
+
+// RestoreID returns the ID of a restore, used to tell the servers restored by this restore from the previous ones.
+func RestoreID(snapshotName string, startedAt time.Time) string {
+	return fmt.Sprintf("%s-%d", snapshotName, startedAt.Unix())
+}
+
+// RestorePath returns the value of the k3s --cluster-reset-restore-path flag for an etcd snapshot:
+// the path of a local snapshot, or the name of a snapshot k3s downloads from the S3 bucket.
+func RestorePath(s infracontrolplanev1.EtcdSnapshot) string {
+	if IsLocal(s) {
+		if u, err := url.Parse(s.Location); err == nil && u.Path != "" {
+			return u.Path
+		}
+	}
+	return s.Name
+}
+
+// Restore describes the restore of an etcd snapshot on the servers of a workload cluster.
+type Restore struct {
+	// ID is the ID of the restore, see RestoreID.
+	ID string
+	// Image is the image of the restore jobs.
+	Image string
+	// RestorePath is the value of the k3s --cluster-reset-restore-path flag.
+	RestorePath string
+	// DataDir is the k3s data directory of the servers.
+	DataDir string
+	// InitNodeName is the name of the node reset with the snapshot.
+	InitNodeName string
+	// InitNodeAddress is the address the other servers reach the reset server at.
+	InitNodeAddress string
+	// JoinNodeNames are the names of the other server nodes.
+	JoinNodeNames []string
+}
+
+// InitScript returns the script resetting the first server with the snapshot.
+// k3s exits once the etcd cluster membership is reset, the server is then started again as a single member cluster.
+func (r Restore) InitScript() string {
+	var script strings.Builder
+	script.WriteString("set -e\n")
+	script.WriteString("systemctl stop k3s\n")
+	fmt.Fprintf(&script, "k3s server --cluster-reset --cluster-reset-restore-path=%s\n", shellQuote(r.RestorePath))
+	script.WriteString("systemctl start k3s\n")
+	script.WriteString(r.annotateNode(r.InitNodeName))
+	return script.String()
+}
+
+// JoinScript returns the script rejoining a server to the reset server.
+// The server waits for the reset server to be annotated with the restore ID before removing its etcd data and starting again.
+func (r Restore) JoinScript(nodeName string) string {
+	var script strings.Builder
+	script.WriteString("set -e\n")
+	script.WriteString("systemctl stop k3s\n")
+	fmt.Fprintf(&script, "until [ \"$(k3s kubectl --kubeconfig %s --server %s get node %s -o jsonpath=%s 2>/dev/null)\" = %s ]; do sleep 10; done\n",
+		kubeconfigFile,
+		shellQuote(fmt.Sprintf("https://%s:6443", r.InitNodeAddress)),
+		shellQuote(r.InitNodeName),
+		shellQuote(fmt.Sprintf("{.metadata.annotations.%s}", strings.ReplaceAll(RestoredAnnotation, ".", "\\."))),
+		shellQuote(r.ID))
+	fmt.Fprintf(&script, "rm -rf %s\n", shellQuote(strings.TrimSuffix(r.dataDir(), "/")+"/server/db"))
+	script.WriteString("systemctl start k3s\n")
+	script.WriteString(r.annotateNode(nodeName))
+	return script.String()
+}
+
+func (r Restore) annotateNode(nodeName string) string {
+	return fmt.Sprintf("until k3s kubectl annotate node --overwrite %s %s; do sleep 10; done\n",
+		shellQuote(nodeName), shellQuote(fmt.Sprintf("%s=%s", RestoredAnnotation, r.ID)))
+}
+
+func (r Restore) dataDir() string {
+	if r.DataDir == "" {
+		return DefaultDataDir
+	}
+	return r.DataDir
+}
+
+// Jobs returns the jobs running the restore scripts on the servers.
+// The scripts are run as transient systemd units on the hosts, so they outlive the jobs when k3s is stopped.
+func (r Restore) Jobs() []*batchv1.Job {
+	jobs := []*batchv1.Job{r.job(0, r.InitNodeName, r.InitScript())}
+	for i, nodeName := range r.JoinNodeNames {
+		jobs = append(jobs, r.job(i+1, nodeName, r.JoinScript(nodeName)))
+	}
+	return jobs
+}
+
+func (r Restore) job(index int, nodeName, script string) *batchv1.Job {
+	name := fmt.Sprintf("etcd-snapshot-restore-%s-%d", shortID(r.ID), index)
+	labels := map[string]string{restoreJobLabel: shortID(r.ID)}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceSystem,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            pointer.Int32(0),
+			TTLSecondsAfterFinished: pointer.Int32(3600),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					NodeName:      nodeName,
+					HostPID:       true,
+					RestartPolicy: corev1.RestartPolicyNever,
+					Tolerations:   []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					Containers: []corev1.Container{{
+						Name:  "restore",
+						Image: r.Image,
+						Command: []string{
+							"nsenter", "-t", "1", "-m", "-u", "-i", "-n", "-p", "--",
+							"systemd-run", "--collect", "--unit", name,
+							"/bin/sh", "-c", script,
+						},
+						SecurityContext: &corev1.SecurityContext{Privileged: pointer.Bool(true)},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// shortID returns the timestamp part of a restore ID, which is unique and short enough for object names.
+func shortID(id string) string {
+	return id[strings.LastIndex(id, "-")+1:]
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
@@ -0,0 +1,107 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package etcdsnapshot
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	infracontrolplanev1 "github.com/kubesphere/kubekey/v3/controlplane/k3s/api/v1beta1"
+)
+
+func TestRestorePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot infracontrolplanev1.EtcdSnapshot
+		want     string
+	}{
+		{
+			name: "local snapshot",
+			snapshot: infracontrolplanev1.EtcdSnapshot{
+				Name:     "etcd-snapshot-server-1-1663749600",
+				Location: "file:///var/lib/rancher/k3s/server/db/snapshots/etcd-snapshot-server-1-1663749600",
+			},
+			want: "/var/lib/rancher/k3s/server/db/snapshots/etcd-snapshot-server-1-1663749600",
+		},
+		{
+			name: "S3 snapshot",
+			snapshot: infracontrolplanev1.EtcdSnapshot{
+				Name:     "etcd-snapshot-server-1-1663749600",
+				Location: "s3://k3s-snapshots/cluster-a/etcd-snapshot-server-1-1663749600",
+			},
+			want: "etcd-snapshot-server-1-1663749600",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(RestorePath(tt.snapshot)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestRestoreJobs(t *testing.T) {
+	g := NewWithT(t)
+
+	r := Restore{
+		ID:              RestoreID("etcd-snapshot-server-1-1663749600", time.Unix(1663800000, 0)),
+		Image:           infracontrolplanev1.DefaultEtcdSnapshotRestoreImage,
+		RestorePath:     "/var/lib/rancher/k3s/server/db/snapshots/etcd-snapshot-server-1-1663749600",
+		InitNodeName:    "server-1",
+		InitNodeAddress: "10.0.0.1",
+		JoinNodeNames:   []string{"server-2", "server-3"},
+	}
+	g.Expect(r.ID).To(Equal("etcd-snapshot-server-1-1663749600-1663800000"))
+
+	g.Expect(r.InitScript()).To(Equal(`set -e
+systemctl stop k3s
+k3s server --cluster-reset --cluster-reset-restore-path='/var/lib/rancher/k3s/server/db/snapshots/etcd-snapshot-server-1-1663749600'
+systemctl start k3s
+until k3s kubectl annotate node --overwrite 'server-1' 'controlplane.cluster.x-k8s.io/etcd-snapshot-restored=etcd-snapshot-server-1-1663749600-1663800000'; do sleep 10; done
+`))
+	g.Expect(r.JoinScript("server-2")).To(Equal(`set -e
+systemctl stop k3s
+until [ "$(k3s kubectl --kubeconfig /etc/rancher/k3s/k3s.yaml --server 'https://10.0.0.1:6443' get node 'server-1' -o jsonpath='{.metadata.annotations.controlplane\.cluster\.x-k8s\.io/etcd-snapshot-restored}' 2>/dev/null)" = 'etcd-snapshot-server-1-1663749600-1663800000' ]; do sleep 10; done
+rm -rf '/var/lib/rancher/k3s/server/db'
+systemctl start k3s
+until k3s kubectl annotate node --overwrite 'server-2' 'controlplane.cluster.x-k8s.io/etcd-snapshot-restored=etcd-snapshot-server-1-1663749600-1663800000'; do sleep 10; done
+`))
+
+	jobs := r.Jobs()
+	g.Expect(jobs).To(HaveLen(3))
+	for i, nodeName := range []string{"server-1", "server-2", "server-3"} {
+		job := jobs[i]
+		g.Expect(job.Namespace).To(Equal("kube-system"))
+		g.Expect(job.Name).To(HavePrefix("etcd-snapshot-restore-1663800000-"))
+		g.Expect(*job.Spec.BackoffLimit).To(BeZero())
+
+		pod := job.Spec.Template.Spec
+		g.Expect(pod.NodeName).To(Equal(nodeName))
+		g.Expect(pod.HostPID).To(BeTrue())
+		g.Expect(pod.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+		g.Expect(pod.Containers).To(HaveLen(1))
+		g.Expect(pod.Containers[0].Image).To(Equal("busybox:1.35"))
+		g.Expect(*pod.Containers[0].SecurityContext.Privileged).To(BeTrue())
+		g.Expect(pod.Containers[0].Command).To(ContainElements("nsenter", "systemd-run", "--collect", job.Name))
+	}
+	g.Expect(jobs[0].Spec.Template.Spec.Containers[0].Command).To(ContainElement(r.InitScript()))
+	g.Expect(jobs[2].Spec.Template.Spec.Containers[0].Command).To(ContainElement(r.JoinScript("server-3")))
+}
@@ -0,0 +1,137 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package etcdsnapshot
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infracontrolplanev1 "github.com/kubesphere/kubekey/v3/controlplane/k3s/api/v1beta1"
+)
+
+const (
+	// defaultS3Endpoint and defaultS3Region are the k3s defaults.
+	defaultS3Endpoint = "s3.amazonaws.com"
+	defaultS3Region   = "us-east-1"
+
+	// metadataFolder is the folder k3s stores the snapshot metadata in, next to the snapshots.
+	metadataFolder = ".metadata"
+)
+
+// ListS3 returns the etcd snapshots stored in the S3 bucket, newest first.
+// When the access key is empty, the default AWS credential chain is used.
+func ListS3(ctx context.Context, spec *infracontrolplanev1.EtcdSnapshotS3Spec, accessKey, secretKey string) ([]infracontrolplanev1.EtcdSnapshot, error) {
+	client, err := newS3Client(spec, accessKey, secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.Trim(spec.Folder, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	snapshots := make([]infracontrolplanev1.EtcdSnapshot, 0)
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(spec.Bucket),
+		Prefix: aws.String(prefix),
+	}
+	err = client.ListObjectsV2PagesWithContext(ctx, input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			key := aws.StringValue(obj.Key)
+			name := strings.TrimPrefix(key, prefix)
+			if name == "" || strings.HasSuffix(name, "/") || strings.HasPrefix(name, metadataFolder+"/") {
+				continue
+			}
+			snapshot := infracontrolplanev1.EtcdSnapshot{
+				Name:     path.Base(name),
+				Location: fmt.Sprintf("s3://%s/%s", spec.Bucket, key),
+				NodeName: nodeNameFromSnapshotName(path.Base(name)),
+				Size:     aws.Int64Value(obj.Size),
+			}
+			if obj.LastModified != nil {
+				t := metav1.NewTime(*obj.LastModified)
+				snapshot.CreatedAt = &t
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the etcd snapshots in bucket %s", spec.Bucket)
+	}
+
+	Sort(snapshots)
+	return snapshots, nil
+}
+
+func newS3Client(spec *infracontrolplanev1.EtcdSnapshotS3Spec, accessKey, secretKey string) (*s3.S3, error) {
+	endpoint := spec.Endpoint
+	if endpoint == "" {
+		endpoint = defaultS3Endpoint
+	}
+	scheme := "https"
+	if spec.Insecure {
+		scheme = "http"
+	}
+	region := spec.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: spec.SkipSSLVerify, //nolint:gosec
+	}
+	if spec.EndpointCA != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(spec.EndpointCA)) {
+			return nil, errors.New("failed to parse the S3 endpoint CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	cfg := &aws.Config{
+		Endpoint:         aws.String(fmt.Sprintf("%s://%s", scheme, endpoint)),
+		Region:           aws.String(region),
+		S3ForcePathStyle: aws.Bool(true),
+		HTTPClient:       &http.Client{Transport: transport},
+	}
+	if accessKey != "" {
+		cfg.Credentials = credentials.NewStaticCredentials(accessKey, secretKey, "")
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the S3 session")
+	}
+	return s3.New(sess), nil
+}
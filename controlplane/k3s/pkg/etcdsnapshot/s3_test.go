@@ -0,0 +1,189 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package etcdsnapshot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	infracontrolplanev1 "github.com/kubesphere/kubekey/v3/controlplane/k3s/api/v1beta1"
+)
+
+const (
+	minioBucket    = "k3s-snapshots"
+	minioAccessKey = "minioadmin"
+	minioSecretKey = "minioadmin-secret"
+)
+
+// newMinIO starts a stand-in for a MinIO server answering the ListObjectsV2 requests of a single bucket.
+// The objects are listed in pages of two objects.
+func newMinIO(t *testing.T, keys map[string]string) *httptest.Server {
+	t.Helper()
+
+	names := make([]string, 0, len(keys))
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "Credential="+minioAccessKey+"/") {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>`)
+			return
+		}
+		if r.URL.Path != "/"+minioBucket || r.URL.Query().Get("list-type") != "2" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message></Error>`)
+			return
+		}
+
+		prefix := r.URL.Query().Get("prefix")
+		matching := make([]string, 0)
+		for _, name := range names {
+			if strings.HasPrefix(name, prefix) {
+				matching = append(matching, name)
+			}
+		}
+		start := 0
+		if token := r.URL.Query().Get("continuation-token"); token != "" {
+			fmt.Sscanf(token, "%d", &start)
+		}
+		end := start + 2
+		if end > len(matching) {
+			end = len(matching)
+		}
+
+		var body strings.Builder
+		fmt.Fprintf(&body, `<?xml version="1.0" encoding="UTF-8"?><ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Name>%s</Name><Prefix>%s</Prefix><KeyCount>%d</KeyCount><MaxKeys>1000</MaxKeys>`,
+			minioBucket, prefix, end-start)
+		if end < len(matching) {
+			fmt.Fprintf(&body, `<IsTruncated>true</IsTruncated><NextContinuationToken>%d</NextContinuationToken>`, end)
+		} else {
+			body.WriteString(`<IsTruncated>false</IsTruncated>`)
+		}
+		for _, name := range matching[start:end] {
+			fmt.Fprintf(&body, `<Contents><Key>%s</Key><LastModified>%s</LastModified><ETag>"etag"</ETag><Size>%d</Size><StorageClass>STANDARD</StorageClass></Contents>`,
+				name, keys[name], len(name))
+		}
+		body.WriteString(`</ListBucketResult>`)
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprint(w, body.String())
+	}))
+}
+
+func TestListS3(t *testing.T) {
+	minio := newMinIO(t, map[string]string{
+		"cluster-a/etcd-snapshot-server-1-1663749600":           "2022-09-21T08:40:00.000Z",
+		"cluster-a/etcd-snapshot-server-2-1663792800.zip":       "2022-09-21T20:40:00.000Z",
+		"cluster-a/on-demand-server-1-1663700000":               "2022-09-20T18:53:20.000Z",
+		"cluster-a/.metadata/etcd-snapshot-server-1-1663749600": "2022-09-21T08:40:00.000Z",
+		"cluster-b/etcd-snapshot-server-9-1663749600":           "2022-09-21T08:40:00.000Z",
+	})
+	defer minio.Close()
+	endpoint := strings.TrimPrefix(minio.URL, "http://")
+
+	tests := []struct {
+		name      string
+		spec      infracontrolplanev1.EtcdSnapshotS3Spec
+		accessKey string
+		want      []infracontrolplanev1.EtcdSnapshot
+		wantErr   bool
+	}{
+		{
+			name:      "snapshots of the folder, newest first",
+			spec:      infracontrolplanev1.EtcdSnapshotS3Spec{Endpoint: endpoint, Insecure: true, Bucket: minioBucket, Folder: "cluster-a"},
+			accessKey: minioAccessKey,
+			want: []infracontrolplanev1.EtcdSnapshot{
+				{
+					Name:     "etcd-snapshot-server-2-1663792800.zip",
+					Location: "s3://k3s-snapshots/cluster-a/etcd-snapshot-server-2-1663792800.zip",
+					NodeName: "server-2",
+					Size:     int64(len("cluster-a/etcd-snapshot-server-2-1663792800.zip")),
+				},
+				{
+					Name:     "etcd-snapshot-server-1-1663749600",
+					Location: "s3://k3s-snapshots/cluster-a/etcd-snapshot-server-1-1663749600",
+					NodeName: "server-1",
+					Size:     int64(len("cluster-a/etcd-snapshot-server-1-1663749600")),
+				},
+				{
+					Name:     "on-demand-server-1-1663700000",
+					Location: "s3://k3s-snapshots/cluster-a/on-demand-server-1-1663700000",
+					NodeName: "server-1",
+					Size:     int64(len("cluster-a/on-demand-server-1-1663700000")),
+				},
+			},
+		},
+		{
+			name:      "folder with a trailing slash",
+			spec:      infracontrolplanev1.EtcdSnapshotS3Spec{Endpoint: endpoint, Insecure: true, Bucket: minioBucket, Folder: "/cluster-b/"},
+			accessKey: minioAccessKey,
+			want: []infracontrolplanev1.EtcdSnapshot{
+				{
+					Name:     "etcd-snapshot-server-9-1663749600",
+					Location: "s3://k3s-snapshots/cluster-b/etcd-snapshot-server-9-1663749600",
+					NodeName: "server-9",
+					Size:     int64(len("cluster-b/etcd-snapshot-server-9-1663749600")),
+				},
+			},
+		},
+		{
+			name:      "wrong credentials",
+			spec:      infracontrolplanev1.EtcdSnapshotS3Spec{Endpoint: endpoint, Insecure: true, Bucket: minioBucket},
+			accessKey: "someone-else",
+			wantErr:   true,
+		},
+		{
+			name:      "missing bucket",
+			spec:      infracontrolplanev1.EtcdSnapshotS3Spec{Endpoint: endpoint, Insecure: true, Bucket: "missing"},
+			accessKey: minioAccessKey,
+			wantErr:   true,
+		},
+		{
+			name:      "invalid CA",
+			spec:      infracontrolplanev1.EtcdSnapshotS3Spec{Endpoint: endpoint, Bucket: minioBucket, EndpointCA: "not a certificate"},
+			accessKey: minioAccessKey,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := ListS3(context.Background(), &tt.spec, tt.accessKey, minioSecretKey)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(HaveLen(len(tt.want)))
+			for i := range got {
+				g.Expect(got[i].CreatedAt).NotTo(BeNil())
+				got[i].CreatedAt = nil
+			}
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
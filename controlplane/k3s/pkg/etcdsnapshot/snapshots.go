@@ -0,0 +1,120 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package etcdsnapshot
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infracontrolplanev1 "github.com/kubesphere/kubekey/v3/controlplane/k3s/api/v1beta1"
+)
+
+const (
+	// ConfigMapNamespace is the namespace of the ConfigMap k3s records the etcd snapshots in.
+	ConfigMapNamespace = metav1.NamespaceSystem
+
+	// ConfigMapName is the name of the ConfigMap k3s records the etcd snapshots in.
+	ConfigMapName = "k3s-etcd-snapshots"
+
+	snapshotFailed = "failed"
+)
+
+// configMapEntry is an etcd snapshot as recorded by k3s in the etcd snapshots ConfigMap.
+type configMapEntry struct {
+	Name      string       `json:"name"`
+	Location  string       `json:"location"`
+	NodeName  string       `json:"nodeName"`
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+	Size      int64        `json:"size,omitempty"`
+	Status    string       `json:"status,omitempty"`
+}
+
+// FromConfigMap returns the successful etcd snapshots recorded by k3s in the etcd snapshots ConfigMap, newest first.
+// Entries that cannot be parsed are skipped.
+func FromConfigMap(cm *corev1.ConfigMap) []infracontrolplanev1.EtcdSnapshot {
+	snapshots := make([]infracontrolplanev1.EtcdSnapshot, 0, len(cm.Data))
+	for _, v := range cm.Data {
+		entry := configMapEntry{}
+		if err := json.Unmarshal([]byte(v), &entry); err != nil {
+			continue
+		}
+		if entry.Name == "" || entry.Status == snapshotFailed {
+			continue
+		}
+		snapshots = append(snapshots, infracontrolplanev1.EtcdSnapshot{
+			Name:      entry.Name,
+			Location:  entry.Location,
+			NodeName:  entry.NodeName,
+			CreatedAt: entry.CreatedAt,
+			Size:      entry.Size,
+		})
+	}
+	Sort(snapshots)
+	return snapshots
+}
+
+// Sort sorts the etcd snapshots newest first.
+func Sort(snapshots []infracontrolplanev1.EtcdSnapshot) {
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		ti, tj := createdAt(snapshots[i]), createdAt(snapshots[j])
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return snapshots[i].Name < snapshots[j].Name
+	})
+}
+
+func createdAt(s infracontrolplanev1.EtcdSnapshot) time.Time {
+	if s.CreatedAt == nil {
+		return time.Time{}
+	}
+	return s.CreatedAt.Time
+}
+
+// IsLocal returns true if the etcd snapshot is stored on a server rather than in a S3 bucket.
+func IsLocal(s infracontrolplanev1.EtcdSnapshot) bool {
+	return strings.HasPrefix(s.Location, "file://")
+}
+
+// nodeNameFromSnapshotName returns the node name embedded by k3s in the name of a snapshot,
+// e.g. node-1 in etcd-snapshot-node-1-1663749825, or an empty string for a custom snapshot name.
+func nodeNameFromSnapshotName(name string) string {
+	name = strings.TrimSuffix(name, ".zip")
+	for _, prefix := range []string{"etcd-snapshot-", "on-demand-"} {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		name = strings.TrimPrefix(name, prefix)
+		// the name ends with the unix time the snapshot was taken at.
+		i := strings.LastIndex(name, "-")
+		if i <= 0 || len(name)-i-1 < 10 {
+			return ""
+		}
+		for _, c := range name[i+1:] {
+			if c < '0' || c > '9' {
+				return ""
+			}
+		}
+		return name[:i]
+	}
+	return ""
+}
@@ -0,0 +1,118 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package etcdsnapshot
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infracontrolplanev1 "github.com/kubesphere/kubekey/v3/controlplane/k3s/api/v1beta1"
+)
+
+func TestFromConfigMap(t *testing.T) {
+	older := metav1.NewTime(time.Date(2022, 9, 21, 8, 40, 0, 0, time.UTC))
+	newer := metav1.NewTime(time.Date(2022, 9, 21, 20, 40, 0, 0, time.UTC))
+
+	tests := []struct {
+		name string
+		data map[string]string
+		want []infracontrolplanev1.EtcdSnapshot
+	}{
+		{
+			name: "empty",
+			data: nil,
+			want: []infracontrolplanev1.EtcdSnapshot{},
+		},
+		{
+			name: "newest first",
+			data: map[string]string{
+				"etcd-snapshot-server-1-1663749600":    `{"name":"etcd-snapshot-server-1-1663749600","location":"file:///var/lib/rancher/k3s/server/db/snapshots/etcd-snapshot-server-1-1663749600","nodeName":"server-1","createdAt":"2022-09-21T08:40:00Z","size":2437152,"status":"successful"}`,
+				"s3-etcd-snapshot-server-1-1663792800": `{"name":"etcd-snapshot-server-1-1663792800","location":"s3://k3s-snapshots/etcd-snapshot-server-1-1663792800","nodeName":"s3","createdAt":"2022-09-21T20:40:00Z","size":2437152,"status":"successful","s3":{"bucket":"k3s-snapshots"}}`,
+			},
+			want: []infracontrolplanev1.EtcdSnapshot{
+				{
+					Name:      "etcd-snapshot-server-1-1663792800",
+					Location:  "s3://k3s-snapshots/etcd-snapshot-server-1-1663792800",
+					NodeName:  "s3",
+					CreatedAt: &newer,
+					Size:      2437152,
+				},
+				{
+					Name:      "etcd-snapshot-server-1-1663749600",
+					Location:  "file:///var/lib/rancher/k3s/server/db/snapshots/etcd-snapshot-server-1-1663749600",
+					NodeName:  "server-1",
+					CreatedAt: &older,
+					Size:      2437152,
+				},
+			},
+		},
+		{
+			name: "failed and invalid entries are skipped",
+			data: map[string]string{
+				"etcd-snapshot-server-1-1663749600": `{"name":"etcd-snapshot-server-1-1663749600","location":"file:///var/lib/rancher/k3s/server/db/snapshots/etcd-snapshot-server-1-1663749600","nodeName":"server-1","createdAt":"2022-09-21T08:40:00Z","size":2437152}`,
+				"etcd-snapshot-server-2-1663749600": `{"name":"etcd-snapshot-server-2-1663749600","nodeName":"server-2","createdAt":"2022-09-21T08:40:00Z","status":"failed","message":"ZXJyb3I="}`,
+				"invalid":                           `not json`,
+			},
+			want: []infracontrolplanev1.EtcdSnapshot{
+				{
+					Name:      "etcd-snapshot-server-1-1663749600",
+					Location:  "file:///var/lib/rancher/k3s/server/db/snapshots/etcd-snapshot-server-1-1663749600",
+					NodeName:  "server-1",
+					CreatedAt: &older,
+					Size:      2437152,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got := FromConfigMap(&corev1.ConfigMap{Data: tt.data})
+			g.Expect(got).To(HaveLen(len(tt.want)))
+			for i := range got {
+				g.Expect(got[i].CreatedAt.Equal(tt.want[i].CreatedAt)).To(BeTrue())
+				got[i].CreatedAt, tt.want[i].CreatedAt = nil, nil
+			}
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestNodeNameFromSnapshotName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "etcd-snapshot-server-1-1663749600", want: "server-1"},
+		{name: "etcd-snapshot-server-1-1663749600.zip", want: "server-1"},
+		{name: "on-demand-k3s-cp-x2v8z-1663749600", want: "k3s-cp-x2v8z"},
+		{name: "etcd-snapshot-server-1", want: ""},
+		{name: "before-upgrade", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(nodeNameFromSnapshotName(tt.name)).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,27 @@
+#!/bin/bash
+
+# Fetch the sha256 of the cri-dockerd release tarballs and print them as the "cri-dockerd" entry of version/components.json.
+# cri-dockerd does not publish the checksums, so the tarballs are downloaded and hashed.
+# Usage: hack/fetch-cri-dockerd-hash.sh 0.3.1 0.3.4
+
+versions=("$@")
+if [ ${#versions[@]} -eq 0 ]; then
+  versions=("0.3.1")
+fi
+
+arches=("amd64" "arm64")
+json="{}"
+for arch in "${arches[@]}"
+do
+  echo "cri-dockerd@${arch}"
+  for ver in "${versions[@]}"
+  do
+    url="https://github.com/Mirantis/cri-dockerd/releases/download/v${ver}/cri-dockerd-${ver}.${arch}.tgz"
+    hash=$(wget --quiet -O - "$url" | sha256sum | awk '{print $1}')
+    echo "\"${ver}\": \"${hash}\","
+    json=$(echo "$json" | jq ".\"cri-dockerd\".${arch} += {\"${ver}\":\"${hash}\"}")
+  done
+done
+
+file="cri-dockerd-hashes.json"
+echo "$json" | jq --indent 4 > "${file}" && echo -e "\n\nThe hash info have saved to file ${file}.\n\n"
@@ -0,0 +1,26 @@
+#!/bin/bash
+
+# Fetch the sha256 of the cri-o static bundles and print them as the "crio" entry of version/components.json.
+# Usage: hack/fetch-crio-hash.sh v1.24.6 v1.25.4
+
+versions=("$@")
+if [ ${#versions[@]} -eq 0 ]; then
+  versions=("v1.24.6")
+fi
+
+arches=("amd64" "arm64")
+json="{}"
+for arch in "${arches[@]}"
+do
+  echo "crio@${arch}"
+  for ver in "${versions[@]}"
+  do
+    url="https://storage.googleapis.com/cri-o/artifacts/cri-o.${arch}.${ver}.tar.gz.sha256sum"
+    hash=$(wget --quiet -O - "$url" | awk '{print $1}')
+    echo "\"${ver}\": \"${hash}\","
+    json=$(echo "$json" | jq ".crio.${arch} += {\"${ver}\":\"${hash}\"}")
+  done
+done
+
+file="crio-hashes.json"
+echo "$json" | jq --indent 4 > "${file}" && echo -e "\n\nThe hash info have saved to file ${file}.\n\n"
@@ -43,6 +43,7 @@ CRICTL_VERSION=${CRICTL_VERSION}
 K3S_VERSION=${K3S_VERSION}
 CONTAINERD_VERSION=${CONTAINERD_VERSION}
 RUNC_VERSION=${RUNC_VERSION}
+CRIO_VERSION=${CRIO_VERSION}
 COMPOSE_VERSION=${COMPOSE_VERSION}
 CALICO_VERSION=${CALICO_VERSION}
 
@@ -261,6 +262,24 @@ if [ $RUNC_VERSION ]; then
    rm -rf binaries
 fi
 
+# Sync cri-o Binary
+if [ $CRIO_VERSION ]; then
+   for arch in ${ARCHS[@]}
+   do
+     mkdir -p binaries/crio/$CRIO_VERSION/$arch
+     echo "Synchronizing cri-o-$arch"
+
+     curl -L -o binaries/crio/$CRIO_VERSION/$arch/cri-o.$arch.$CRIO_VERSION.tar.gz \
+                https://storage.googleapis.com/cri-o/artifacts/cri-o.$arch.$CRIO_VERSION.tar.gz
+
+     qsctl cp binaries/crio/$CRIO_VERSION/$arch/cri-o.$arch.$CRIO_VERSION.tar.gz \
+           qs://kubernetes-release/cri-o/artifacts/cri-o.$arch.$CRIO_VERSION.tar.gz \
+           -c qsctl-config.yaml
+   done
+
+   rm -rf binaries
+fi
+
 # Sync docker-compose Binary
 if [ $RUNC_VERSION ]; then
    for arch in ${ARCHS[@]}
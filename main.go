@@ -41,6 +41,7 @@ import (
 
 	infrav1 "github.com/kubesphere/kubekey/v3/api/v1beta1"
 	"github.com/kubesphere/kubekey/v3/controllers"
+	"github.com/kubesphere/kubekey/v3/pkg/clients/ssh"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -69,6 +70,10 @@ var (
 	syncPeriod              time.Duration
 	watchNamespace          string
 	dataDir                 string
+	sshKeepAliveInterval    time.Duration
+	sshKeepAliveTimeout     time.Duration
+	sshIdleTimeout          time.Duration
+	strictCloudInit         bool
 )
 
 func main() {
@@ -118,6 +123,18 @@ func main() {
 	// Initialize event recorder.
 	record.InitFromRecorder(mgr.GetEventRecorderFor("kk-controller"))
 
+	sshLog := ctrl.Log.WithName("ssh")
+	sshPool := ssh.NewPool(ssh.PoolOptions{
+		KeepAliveInterval: sshKeepAliveInterval,
+		KeepAliveTimeout:  sshKeepAliveTimeout,
+		IdleTimeout:       sshIdleTimeout,
+		Log:               &sshLog,
+	})
+	if err := mgr.Add(sshPool); err != nil {
+		setupLog.Error(err, "unable to add ssh connection pool")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.KKClusterReconciler{
 		Client:           mgr.GetClient(),
 		Recorder:         mgr.GetEventRecorderFor("kkcluster-controller"),
@@ -144,6 +161,8 @@ func main() {
 		Recorder:         mgr.GetEventRecorderFor("kkinstance-controller"),
 		Scheme:           mgr.GetScheme(),
 		Tracker:          tracker,
+		SSHPool:          sshPool,
+		StrictCloudInit:  strictCloudInit,
 		WatchFilterValue: watchFilterValue,
 		DataDir:          dataDir,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: kkInstanceConcurrency, RecoverPanic: true}); err != nil {
@@ -260,4 +279,28 @@ func initFlags(fs *pflag.FlagSet) {
 		"",
 		"The KubeKey data dir.",
 	)
+
+	fs.DurationVar(&sshKeepAliveInterval,
+		"ssh-keepalive-interval",
+		ssh.DefaultKeepAliveInterval,
+		"The interval to send keepalive requests on the pooled SSH connections.",
+	)
+
+	fs.DurationVar(&sshKeepAliveTimeout,
+		"ssh-keepalive-timeout",
+		ssh.DefaultKeepAliveTimeout,
+		"How long to wait for the reply of a keepalive request before the pooled SSH connection is closed.",
+	)
+
+	fs.DurationVar(&sshIdleTimeout,
+		"ssh-idle-timeout",
+		ssh.DefaultIdleTimeout,
+		"How long an unused SSH connection is kept in the pool.",
+	)
+
+	fs.BoolVar(&strictCloudInit,
+		"strict-cloud-init",
+		false,
+		"Fail the provisioning of a KKInstance when its cloud-init bootstrap data uses an unsupported module, instead of skipping the module.",
+	)
 }
@@ -37,6 +37,7 @@ import (
 
 	infrav1 "github.com/kubesphere/kubekey/v3/api/v1beta1"
 	"github.com/kubesphere/kubekey/v3/pkg/util/filesystem"
+	"github.com/kubesphere/kubekey/v3/util/hostkey"
 )
 
 // Default values.
@@ -56,10 +57,17 @@ type Client struct {
 	privateKey     string
 	privateKeyPath string
 	timeout        *time.Duration
+	hostKeyCheck   string
+	knownHostsFile string
 	host           string
 	sshClient      *ssh.Client
 	sftpClient     *sftp.Client
 	fs             filesystem.Interface
+
+	pool *Pool
+	conn *pooledConn
+	// refs counts the nested connects which share the sshClient.
+	refs int
 }
 
 // NewClient returns a new client given ssh information.
@@ -89,36 +97,97 @@ func NewClient(host string, auth infrav1.Auth, log *logr.Logger) Interface {
 		privateKey:     auth.PrivateKey,
 		privateKeyPath: auth.PrivateKeyPath,
 		timeout:        auth.Timeout,
+		hostKeyCheck:   auth.HostKeyChecking,
+		knownHostsFile: auth.KnownHostsFile,
 		host:           host,
 		fs:             filesystem.NewFileSystem(),
 		Logger:         *log,
 	}
 }
 
+// NewPooledClient returns a new client which shares the connections of the pool.
+func NewPooledClient(host string, auth infrav1.Auth, pool *Pool, log *logr.Logger) Interface {
+	i := NewClient(host, auth, log)
+	if pool != nil {
+		i.(*Client).pool = pool
+	}
+	return i
+}
+
 // Connect connects to the host using the provided ssh information.
 func (c *Client) Connect() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.sshClient != nil && c.refs > 0 {
+		c.refs++
+		return nil
+	}
+
+	if c.pool != nil {
+		conn, err := c.pool.acquire(c.poolKey(), c.fingerprint(), c.dial)
+		if err != nil {
+			return err
+		}
+		c.conn = conn
+		c.sshClient = conn.client
+		c.refs = 1
+		return nil
+	}
+
+	sshClient, err := c.dial()
+	if err != nil {
+		return err
+	}
+	c.sshClient = sshClient
+	c.refs = 1
+	return nil
+}
+
+func (c *Client) dial() (*ssh.Client, error) {
 	authMethods, err := c.authMethod(c.password, c.privateKey, c.privateKeyPath)
 	if err != nil {
-		return errors.Wrap(err, "The given SSH key could not be parsed")
+		return nil, errors.Wrap(err, "The given SSH key could not be parsed")
+	}
+
+	hostKeyCallback, err := hostkey.Callback(c.hostKeyCheck, c.knownHostsFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create the SSH host key callback")
 	}
 
 	sshConfig := &ssh.ClientConfig{
-		User:    c.user,
-		Timeout: *c.timeout,
-		Auth:    authMethods,
-		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
-			return nil
-		},
+		User:            c.user,
+		Timeout:         *c.timeout,
+		Auth:            authMethods,
+		HostKeyCallback: hostKeyCallback,
 	}
 
 	endpoint := net.JoinHostPort(c.host, strconv.Itoa(*c.port))
 	sshClient, err := ssh.Dial("tcp", endpoint, sshConfig)
 	if err != nil {
-		return errors.Wrapf(err, "could not establish connection to %s", endpoint)
+		return nil, errors.Wrapf(err, "could not establish connection to %s", endpoint)
 	}
+	return sshClient, nil
+}
 
-	c.sshClient = sshClient
-	return nil
+func (c *Client) poolKey() string {
+	return fmt.Sprintf("%s@%s", c.user, net.JoinHostPort(c.host, strconv.Itoa(*c.port)))
+}
+
+func (c *Client) fingerprint() string {
+	return fingerprint(c.user, c.password, c.privateKey, c.privateKeyPath, c.hostKeyCheck, c.knownHostsFile)
+}
+
+// disconnect releases the connection of the outermost connect.
+func (c *Client) disconnect() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.refs > 1 {
+		c.refs--
+		return
+	}
+	c.closeLocked()
 }
 
 // ConnectSftpClient connects to the host sftp client using the provided ssh information.
@@ -133,23 +202,28 @@ func (c *Client) ConnectSftpClient(opts ...sftp.ClientOption) error {
 	return err
 }
 
-// Close closes the underlying ssh and sftp connection.
+// Close closes the underlying ssh and sftp connection, a pooled connection is given back to the pool.
 func (c *Client) Close() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.closeLocked()
+}
 
-	if c.sshClient == nil && c.sftpClient == nil {
-		return
+func (c *Client) closeLocked() {
+	c.refs = 0
+	if c.sftpClient != nil {
+		_ = c.sftpClient.Close()
+		c.sftpClient = nil
+	}
+	if c.conn != nil {
+		c.pool.release(c.conn)
+		c.conn = nil
+		c.sshClient = nil
 	}
-
 	if c.sshClient != nil {
 		_ = c.sshClient.Close()
 		c.sshClient = nil
 	}
-	if c.sftpClient != nil {
-		_ = c.sftpClient.Close()
-		c.sftpClient = nil
-	}
 }
 
 func (c *Client) authMethod(password, privateKey, privateKeyPath string) (auths []ssh.AuthMethod, err error) {
@@ -206,6 +280,9 @@ func (c *Client) session() (*ssh.Session, error) {
 
 	sess, err := c.sshClient.NewSession()
 	if err != nil {
+		if c.conn != nil {
+			c.pool.invalidate(c.conn)
+		}
 		return nil, err
 	}
 
@@ -233,7 +310,7 @@ func (c *Client) Cmd(cmd string) (string, error) {
 		return "", errors.Wrapf(err, "[%s] create ssh session failed", c.host)
 	}
 	defer session.Close()
-	defer c.sshClient.Close()
+	defer c.disconnect()
 
 	c.Logger.V(4).Info(fmt.Sprintf("cmd: %s", cmd))
 
@@ -255,7 +332,7 @@ func (c *Client) SudoCmd(cmd string) (string, error) {
 	if err := c.Connect(); err != nil {
 		return "", errors.Wrapf(err, "[%s] connect ssh client failed", c.host)
 	}
-	defer c.sshClient.Close()
+	defer c.disconnect()
 	return c.sudoCmd(cmd)
 }
 
@@ -359,11 +436,11 @@ func (c *Client) copy(src, dst string) error {
 	if err := c.Connect(); err != nil {
 		return errors.Wrapf(err, "[%s] connect ssh client failed", c.host)
 	}
+	defer c.disconnect()
+
 	if err := c.ConnectSftpClient(); err != nil {
 		return errors.Wrapf(err, "[%s] connect sftp client failed", c.host)
 	}
-	defer c.sshClient.Close()
-	defer c.sftpClient.Close()
 
 	if err := c.copyLocalFileToRemote(src, dst); err != nil {
 		return errors.Wrapf(err, "[%s] copy file failed", c.host)
@@ -455,14 +532,13 @@ func (c *Client) fetch(local, remote string) error {
 	if err := c.Connect(); err != nil {
 		return errors.Wrapf(err, "[%s] connect ssh client failed", c.host)
 	}
+	defer c.disconnect()
 
 	if err := c.ConnectSftpClient(); err != nil {
 		return errors.Wrapf(err, "[%s] connect sftp client failed", c.host)
 	}
-	defer c.sshClient.Close()
-	defer c.sftpClient.Close()
 
-	ok, err := c.RemoteFileExist(remote)
+	ok, err := c.remoteFileExist(remote)
 	if err != nil {
 		return errors.Wrapf(err, "[%s] check remote file failed", c.host)
 	}
@@ -515,7 +591,7 @@ func (c *Client) RemoteFileExist(remote string) (bool, error) {
 	if err := c.Connect(); err != nil {
 		return false, errors.Wrapf(err, "[%s] connect failed", c.host)
 	}
-	defer c.sshClient.Close()
+	defer c.disconnect()
 	return c.remoteFileExist(remote)
 }
 
@@ -551,7 +627,15 @@ func (c *Client) Ping() error {
 	if err := c.Connect(); err != nil {
 		return errors.Wrapf(err, "[%s] connect failed", c.host)
 	}
-	defer c.Close()
+	defer c.disconnect()
+
+	// A pooled connection may be broken since it was dialed.
+	if c.conn != nil {
+		if _, _, err := c.sshClient.SendRequest("keepalive@openssh.com", true, nil); err != nil {
+			c.pool.invalidate(c.conn)
+			return errors.Wrapf(err, "[%s] ping failed", c.host)
+		}
+	}
 	return nil
 }
 
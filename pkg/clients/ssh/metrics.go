@@ -0,0 +1,69 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ssh
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Eviction reasons of the pooled connections.
+const (
+	evictIdle        = "idle"
+	evictKeepAlive   = "keepalive"
+	evictAuthChanged = "auth_changed"
+	evictBroken      = "broken"
+	evictShutdown    = "shutdown"
+)
+
+var (
+	poolConnections = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "capkk_ssh_pool_connections",
+		Help: "Number of open SSH connections in the pool.",
+	})
+	poolLeases = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "capkk_ssh_pool_leases",
+		Help: "Number of SSH connection leases currently held by the reconcilers.",
+	})
+	poolDials = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "capkk_ssh_pool_dials_total",
+		Help: "Total number of SSH connections dialed by the pool.",
+	})
+	poolDialErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "capkk_ssh_pool_dial_errors_total",
+		Help: "Total number of failed SSH dials of the pool.",
+	})
+	poolReuses = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "capkk_ssh_pool_reuses_total",
+		Help: "Total number of SSH connections reused from the pool.",
+	})
+	poolEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "capkk_ssh_pool_evictions_total",
+		Help: "Total number of SSH connections evicted from the pool by reason.",
+	}, []string{"reason"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		poolConnections,
+		poolLeases,
+		poolDials,
+		poolDialErrors,
+		poolReuses,
+		poolEvictions,
+	)
+}
@@ -0,0 +1,286 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ssh
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/klog/v2/klogr"
+)
+
+// Default values of the pool.
+const (
+	DefaultKeepAliveInterval = 30 * time.Second
+	DefaultKeepAliveTimeout  = 15 * time.Second
+	DefaultIdleTimeout       = 5 * time.Minute
+)
+
+// PoolOptions are the options to create a Pool.
+type PoolOptions struct {
+	// KeepAliveInterval is the interval to send keepalive requests on the pooled connections.
+	KeepAliveInterval time.Duration
+	// KeepAliveTimeout is how long to wait for the reply of a keepalive request before the connection is evicted.
+	KeepAliveTimeout time.Duration
+	// IdleTimeout is how long an unused connection is kept in the pool.
+	IdleTimeout time.Duration
+	Log         *logr.Logger
+}
+
+// Pool is a controller-wide pool of SSH connections which are shared by the clients of the same host.
+// The connections are ref-counted and closed when they are idle, broken or the auth changes.
+type Pool struct {
+	mu                sync.Mutex
+	conns             map[string]*pooledConn
+	keepAliveInterval time.Duration
+	keepAliveTimeout  time.Duration
+	idleTimeout       time.Duration
+	log               logr.Logger
+}
+
+type pooledConn struct {
+	key         string
+	fingerprint string
+	client      *ssh.Client
+	refs        int
+	lastUsed    time.Time
+	// evicted connections are not handed out anymore and closed once released.
+	evicted bool
+}
+
+// NewPool returns a new Pool.
+func NewPool(opts PoolOptions) *Pool {
+	if opts.KeepAliveInterval <= 0 {
+		opts.KeepAliveInterval = DefaultKeepAliveInterval
+	}
+	if opts.KeepAliveTimeout <= 0 {
+		opts.KeepAliveTimeout = DefaultKeepAliveTimeout
+	}
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = DefaultIdleTimeout
+	}
+	if opts.Log == nil {
+		l := klogr.New()
+		opts.Log = &l
+	}
+	return &Pool{
+		conns:             make(map[string]*pooledConn),
+		keepAliveInterval: opts.KeepAliveInterval,
+		keepAliveTimeout:  opts.KeepAliveTimeout,
+		idleTimeout:       opts.IdleTimeout,
+		log:               opts.Log.WithName("ssh-pool"),
+	}
+}
+
+// Start runs the keepalive and idle eviction loop until the context is done, then closes all connections.
+func (p *Pool) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.keepAliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			p.closeAll()
+			return nil
+		case <-ticker.C:
+			p.evictIdle()
+			p.keepAlive()
+		}
+	}
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface, the pool is needed by all replicas.
+func (p *Pool) NeedLeaderElection() bool {
+	return false
+}
+
+// Len returns the number of connections in the pool.
+func (p *Pool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.conns)
+}
+
+// acquire returns a connection of the key, it dials a new one if there is no usable connection.
+func (p *Pool) acquire(key, fingerprint string, dial func() (*ssh.Client, error)) (*pooledConn, error) {
+	p.mu.Lock()
+	if c, ok := p.conns[key]; ok {
+		if c.fingerprint == fingerprint {
+			c.refs++
+			c.lastUsed = time.Now()
+			poolReuses.Inc()
+			poolLeases.Inc()
+			p.mu.Unlock()
+			return c, nil
+		}
+		p.log.V(4).Info("auth changed, invalidate the connection", "key", key)
+		p.evictLocked(c, evictAuthChanged)
+	}
+	p.mu.Unlock()
+
+	client, err := dial()
+	if err != nil {
+		poolDialErrors.Inc()
+		return nil, err
+	}
+	poolDials.Inc()
+	poolConnections.Inc()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Another reconciler may have dialed the same host in the meantime.
+	if c, ok := p.conns[key]; ok && c.fingerprint == fingerprint {
+		_ = client.Close()
+		poolConnections.Dec()
+		c.refs++
+		c.lastUsed = time.Now()
+		poolReuses.Inc()
+		poolLeases.Inc()
+		return c, nil
+	} else if ok {
+		p.evictLocked(c, evictAuthChanged)
+	}
+	c := &pooledConn{
+		key:         key,
+		fingerprint: fingerprint,
+		client:      client,
+		refs:        1,
+		lastUsed:    time.Now(),
+	}
+	p.conns[key] = c
+	poolLeases.Inc()
+	return c, nil
+}
+
+// release gives back a connection acquired from the pool.
+func (p *Pool) release(c *pooledConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if c.refs == 0 {
+		return
+	}
+	c.refs--
+	c.lastUsed = time.Now()
+	poolLeases.Dec()
+	if c.evicted && c.refs == 0 {
+		p.closeLocked(c)
+	}
+}
+
+// invalidate evicts a broken connection, it is closed once all leases are released.
+func (p *Pool) invalidate(c *pooledConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.evictLocked(c, evictBroken)
+}
+
+// evictLocked removes the connection from the pool and closes it if it is not in use.
+func (p *Pool) evictLocked(c *pooledConn, reason string) {
+	if c.evicted {
+		return
+	}
+	c.evicted = true
+	if p.conns[c.key] == c {
+		delete(p.conns, c.key)
+	}
+	poolEvictions.WithLabelValues(reason).Inc()
+	if c.refs == 0 {
+		p.closeLocked(c)
+	}
+}
+
+func (p *Pool) closeLocked(c *pooledConn) {
+	if c.client == nil {
+		return
+	}
+	_ = c.client.Close()
+	c.client = nil
+	poolConnections.Dec()
+}
+
+func (p *Pool) evictIdle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, c := range p.conns {
+		if c.refs == 0 && time.Since(c.lastUsed) > p.idleTimeout {
+			p.log.V(4).Info("evict the idle connection", "key", c.key)
+			p.evictLocked(c, evictIdle)
+		}
+	}
+}
+
+func (p *Pool) keepAlive() {
+	p.mu.Lock()
+	conns := make([]*pooledConn, 0, len(p.conns))
+	for _, c := range p.conns {
+		conns = append(conns, c)
+	}
+	p.mu.Unlock()
+
+	for _, c := range conns {
+		p.mu.Lock()
+		client := c.client
+		p.mu.Unlock()
+		if client == nil {
+			continue
+		}
+		if err := sendKeepAlive(client, p.keepAliveTimeout); err != nil {
+			p.log.V(4).Info("keepalive failed, evict the connection", "key", c.key, "error", err.Error())
+			p.mu.Lock()
+			p.evictLocked(c, evictKeepAlive)
+			p.mu.Unlock()
+		}
+	}
+}
+
+// sendKeepAlive sends a keepalive request, a connection which does not reply in time is considered broken.
+func sendKeepAlive(client *ssh.Client, timeout time.Duration) error {
+	errCh := make(chan error, 1)
+	go func() {
+		_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+		errCh <- err
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-errCh:
+		return err
+	case <-timer.C:
+		return errors.Errorf("no reply to the keepalive request in %s", timeout)
+	}
+}
+
+func (p *Pool) closeAll() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, c := range p.conns {
+		p.evictLocked(c, evictShutdown)
+	}
+}
+
+// fingerprint returns the digest of the auth information, so that the credentials are not kept in the pool.
+func fingerprint(fields ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,172 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// newTestServer starts an in-process SSH server which accepts any password and answers the global requests
+// unless it hangs.
+func newTestServer(t *testing.T, hang bool) string {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(ssh.ConnMetadata, []byte) (*ssh.Permissions, error) {
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+	go func() {
+		for {
+			nc, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_, chans, reqs, err := ssh.NewServerConn(nc, config)
+				if err != nil {
+					return
+				}
+				go func() {
+					for req := range reqs {
+						if req.WantReply && !hang {
+							_ = req.Reply(true, nil)
+						}
+					}
+				}()
+				for ch := range chans {
+					_ = ch.Reject(ssh.Prohibited, "")
+				}
+			}()
+		}
+	}()
+	return l.Addr().String()
+}
+
+func testDialer(addr string, dials *int) func() (*ssh.Client, error) {
+	return func() (*ssh.Client, error) {
+		*dials++
+		return ssh.Dial("tcp", addr, &ssh.ClientConfig{
+			User:            "root",
+			Auth:            []ssh.AuthMethod{ssh.Password("test")},
+			HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+			Timeout:         5 * time.Second,
+		})
+	}
+}
+
+func TestPool(t *testing.T) {
+	addr := newTestServer(t, false)
+	var dials int
+	dial := testDialer(addr, &dials)
+	p := NewPool(PoolOptions{IdleTimeout: time.Millisecond})
+
+	// The connection is shared by the clients of the same key.
+	c1, err := p.acquire("root@"+addr, "a", dial)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := p.acquire("root@"+addr, "a", dial)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c1 != c2 || dials != 1 || c1.refs != 2 {
+		t.Fatalf("expected a shared connection, got dials %d refs %d", dials, c1.refs)
+	}
+
+	// The keepalive keeps the healthy connection.
+	p.keepAlive()
+	if p.Len() != 1 {
+		t.Fatalf("expected the healthy connection to be kept, got %d", p.Len())
+	}
+
+	// The changed auth invalidates the connection, which is closed once released.
+	c3, err := p.acquire("root@"+addr, "b", dial)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c3 == c1 || dials != 2 || p.Len() != 1 {
+		t.Fatalf("expected a new connection for the changed auth, got dials %d len %d", dials, p.Len())
+	}
+	if c1.client == nil {
+		t.Fatal("expected the invalidated connection to stay open while it is in use")
+	}
+	p.release(c1)
+	p.release(c2)
+	if c1.client != nil {
+		t.Fatal("expected the invalidated connection to be closed after it is released")
+	}
+
+	// The idle connection is evicted.
+	p.evictIdle()
+	if p.Len() != 1 {
+		t.Fatal("expected the connection in use not to be evicted")
+	}
+	p.release(c3)
+	time.Sleep(2 * time.Millisecond)
+	p.evictIdle()
+	if p.Len() != 0 || c3.client != nil {
+		t.Fatal("expected the idle connection to be evicted")
+	}
+}
+
+func TestPoolKeepAliveTimeout(t *testing.T) {
+	addr := newTestServer(t, true)
+	var dials int
+	p := NewPool(PoolOptions{KeepAliveTimeout: 10 * time.Millisecond})
+
+	c, err := p.acquire("root@"+addr, "a", testDialer(addr, &dials))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.release(c)
+
+	// The connection which does not reply to the keepalive is evicted instead of blocking the pool.
+	done := make(chan struct{})
+	go func() {
+		p.keepAlive()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the keepalive to time out")
+	}
+	if p.Len() != 0 || c.client != nil {
+		t.Fatal("expected the hung connection to be evicted")
+	}
+}
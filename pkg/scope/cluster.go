@@ -246,6 +246,7 @@ func (s *ClusterScope) PatchObject() error {
 			infrav1.HostReadyCondition,
 			infrav1.ExternalLoadBalancerReadyCondition,
 			infrav1.PrincipalPreparedCondition,
+			infrav1.ControlPlaneLoadBalancerReadyCondition,
 		}})
 }
 
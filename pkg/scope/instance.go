@@ -219,6 +219,7 @@ func (i *InstanceScope) PatchObject() error {
 			infrav1.KKInstanceCRIReadyCondition,
 			infrav1.KKInstanceProvisionedCondition,
 			infrav1.KKInstanceDeletingBootstrapCondition,
+			infrav1.KKInstanceLoadBalancerReadyCondition,
 		}})
 }
 
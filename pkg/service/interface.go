@@ -18,6 +18,7 @@
 package service
 
 import (
+	"context"
 	"time"
 
 	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
@@ -64,3 +65,10 @@ type ContainerManager interface {
 type Provisioning interface {
 	RawBootstrapDataToProvisioningCommands(config []byte) ([]commands.Cmd, error)
 }
+
+// LoadBalancer is the interface for the managed control plane load balancer provision.
+type LoadBalancer interface {
+	Prepare(ctx context.Context) error
+	Deploy(ctx context.Context) error
+	UpToDate() bool
+}
@@ -0,0 +1,106 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package loadbalancer
+
+import (
+	"crypto/md5" //nolint:gosec
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	infrav1 "github.com/kubesphere/kubekey/v3/api/v1beta1"
+	"github.com/kubesphere/kubekey/v3/pkg/service/operation/file"
+)
+
+const (
+	// DefaultHaproxyImage is the default haproxy image.
+	DefaultHaproxyImage = "library/haproxy:2.3"
+
+	haproxyDir             = "/etc/kubekey/haproxy"
+	haproxyHealthCheckPort = 8081
+)
+
+func (s *Service) generateHaproxy() error {
+	cfg, err := template.ParseFS(f, "templates/haproxy.cfg")
+	if err != nil {
+		return err
+	}
+	port := s.scope.ControlPlaneEndpoint().Port
+	addresses := s.controlPlaneAddresses()
+	var backends []string
+	for _, address := range addresses {
+		backends = append(backends, fmt.Sprintf("%s %s:%d", address, address, port))
+	}
+	cfgData := file.Data{
+		"HealthCheckPort": haproxyHealthCheckPort,
+		"Port":            port,
+		"K3s":             s.scope.Distribution() == infrav1.K3S,
+		"Backends":        backends,
+	}
+	if err := s.renderAndCopy(cfg, cfgData, filepath.Join(haproxyDir, cfg.Name())); err != nil {
+		return err
+	}
+
+	// The checksum of the config makes the kubelet restart haproxy when the backends change.
+	var sb strings.Builder
+	if err := cfg.Execute(&sb, cfgData); err != nil {
+		return err
+	}
+	manifest, err := template.ParseFS(f, "templates/haproxy.yaml")
+	if err != nil {
+		return err
+	}
+	image := DefaultHaproxyImage
+	if h := s.scope.ControlPlaneLoadBalancer().Haproxy; h != nil && h.Image != "" {
+		image = h.Image
+	}
+	manifestDir := kubeManifestDir
+	if s.scope.Distribution() == infrav1.K3S {
+		manifestDir = k3sPodManifestDir
+	}
+	if err := s.renderAndCopy(manifest, file.Data{
+		"HaproxyImage":    image,
+		"HealthCheckPort": haproxyHealthCheckPort,
+		"Checksum":        fmt.Sprintf("%x", md5.Sum([]byte(sb.String()))), //nolint:gosec
+		"HaproxyDir":      haproxyDir,
+	}, filepath.Join(manifestDir, manifest.Name())); err != nil {
+		return err
+	}
+
+	annotations := s.instanceScope.KKInstance.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[infrav1.HaproxyBackendsAnnotation] = strings.Join(addresses, ",")
+	s.instanceScope.KKInstance.SetAnnotations(annotations)
+	return nil
+}
+
+func (s *Service) renderAndCopy(temp *template.Template, data file.Data, dst string) error {
+	svc, err := s.getTemplateService(temp, data, dst)
+	if err != nil {
+		return err
+	}
+	if err := svc.RenderToLocal(); err != nil {
+		return err
+	}
+	if err := svc.Copy(true); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package loadbalancer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	infrav1 "github.com/kubesphere/kubekey/v3/api/v1beta1"
+	"github.com/kubesphere/kubekey/v3/pkg/service/operation/file"
+)
+
+const (
+	// DefaultKubeVipImage is the default kube-vip image.
+	DefaultKubeVipImage = "ghcr.io/kube-vip/kube-vip:v0.5.0"
+	// DefaultKubeVipBGPAS is the default BGP AS number of kube-vip.
+	DefaultKubeVipBGPAS = 65000
+
+	kubeManifestDir    = "/etc/kubernetes/manifests"
+	k3sPodManifestDir  = "/var/lib/rancher/k3s/agent/pod-manifests"
+	kubeAdminConfig    = "/etc/kubernetes/admin.conf"
+	k3sKubeConfig      = "/etc/rancher/k3s/k3s.yaml"
+	kubeVipLoInterface = "lo"
+)
+
+func (s *Service) kubeVip() *infrav1.KubeVipSpec {
+	if s.scope.ControlPlaneLoadBalancer().KubeVip == nil {
+		return &infrav1.KubeVipSpec{}
+	}
+	return s.scope.ControlPlaneLoadBalancer().KubeVip
+}
+
+func (s *Service) generateKubeVipManifest() error {
+	temp, err := template.ParseFS(f, "templates/kube-vip.yaml")
+	if err != nil {
+		return err
+	}
+
+	spec := s.kubeVip()
+	bgpMode := spec.Mode == infrav1.KubeVipBGPMode
+	vipInterface, err := s.kubeVipInterface(bgpMode)
+	if err != nil {
+		return err
+	}
+	image := spec.Image
+	if image == "" {
+		image = DefaultKubeVipImage
+	}
+	var as uint32 = DefaultKubeVipBGPAS
+	if spec.BGP != nil && spec.BGP.AS != 0 {
+		as = spec.BGP.AS
+	}
+
+	manifestDir, kubeConfig := kubeManifestDir, kubeAdminConfig
+	if s.scope.Distribution() == infrav1.K3S {
+		manifestDir, kubeConfig = k3sPodManifestDir, k3sKubeConfig
+	}
+
+	return s.renderAndCopy(
+		temp,
+		file.Data{
+			"BGPMode":      bgpMode,
+			"VipInterface": vipInterface,
+			"BGPRouterID":  s.instanceScope.InternalAddress(),
+			"BGPAS":        as,
+			"BGPPeers":     s.kubeVipBGPPeers(as),
+			"KubeVip":      s.scope.ControlPlaneEndpoint().Host,
+			"Port":         s.scope.ControlPlaneEndpoint().Port,
+			"KubevipImage": image,
+			"KubeConfig":   kubeConfig,
+		},
+		filepath.Join(manifestDir, temp.Name()))
+}
+
+// kubeVipInterface returns the network interface which the VIP is bound to.
+func (s *Service) kubeVipInterface(bgpMode bool) (string, error) {
+	if bgpMode {
+		return kubeVipLoInterface, nil
+	}
+	if s.kubeVip().Interface != "" {
+		return s.kubeVip().Interface, nil
+	}
+	out, err := s.sshClient.SudoCmdf("ip route "+
+		"| grep ' %s ' "+
+		"| grep 'proto kernel scope link src' "+
+		"| sed -e \"s/^.*dev.//\" -e \"s/.proto.*//\" "+
+		"| uniq", s.instanceScope.InternalAddress())
+	if err != nil {
+		return "", err
+	}
+	out = strings.TrimSpace(out)
+	if out == "" {
+		return "", errors.Errorf("failed to get the network interface of address %s", s.instanceScope.InternalAddress())
+	}
+	return out, nil
+}
+
+// kubeVipBGPPeers returns the BGP peers in the kube-vip format, the other control planes are used by default.
+func (s *Service) kubeVipBGPPeers(as uint32) string {
+	var peers []string
+	if s.kubeVip().BGP != nil && len(s.kubeVip().BGP.Peers) > 0 {
+		for _, p := range s.kubeVip().BGP.Peers {
+			peers = append(peers, fmt.Sprintf("%s:%d::false", p.Address, p.AS))
+		}
+		return strings.Join(peers, ",")
+	}
+	for _, address := range s.controlPlaneAddresses() {
+		if address == s.instanceScope.InternalAddress() {
+			continue
+		}
+		peers = append(peers, fmt.Sprintf("%s:%d::false", address, as))
+	}
+	return strings.Join(peers, ",")
+}
@@ -0,0 +1,171 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package loadbalancer
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/kubesphere/kubekey/v3/api/v1beta1"
+	"github.com/kubesphere/kubekey/v3/pkg/clients/ssh"
+	"github.com/kubesphere/kubekey/v3/pkg/scope"
+	"github.com/kubesphere/kubekey/v3/pkg/service/operation"
+	"github.com/kubesphere/kubekey/v3/pkg/service/operation/file"
+)
+
+//go:embed templates
+var f embed.FS
+
+// ErrControlPlaneNotInitialized is returned when a worker waits for the control plane to be initialized.
+var ErrControlPlaneNotInitialized = errors.New("waiting for the control plane to be initialized")
+
+// Service holds a collection of interfaces.
+// The interfaces are broken down like this to group functions together.
+type Service struct {
+	sshClient     ssh.Interface
+	scope         scope.LBScope
+	instanceScope *scope.InstanceScope
+
+	templateFactory func(sshClient ssh.Interface, template *template.Template, data file.Data, dst string) (operation.Template, error)
+}
+
+// NewService returns a new service given the remote instance control plane load balancer client.
+func NewService(sshClient ssh.Interface, scope scope.LBScope, instanceScope *scope.InstanceScope) *Service {
+	return &Service{
+		sshClient:     sshClient,
+		scope:         scope,
+		instanceScope: instanceScope,
+	}
+}
+
+func (s *Service) getTemplateService(template *template.Template, data file.Data, dst string) (operation.Template, error) {
+	if s.templateFactory != nil {
+		return s.templateFactory(s.sshClient, template, data, dst)
+	}
+	return file.NewTemplate(s.sshClient, s.scope.RootFs(), template, data, dst)
+}
+
+// Prepare makes the control plane endpoint reachable by the instance before it is provisioned.
+func (s *Service) Prepare(ctx context.Context) error {
+	switch s.scope.ControlPlaneLoadBalancer().Type {
+	case infrav1.KubeVipLoadBalancer:
+		// The first control plane serves the VIP by itself, the others join through it.
+		if !s.instanceScope.IsControlPlane() || s.controlPlaneInitialized() {
+			return nil
+		}
+		return s.generateKubeVipManifest()
+	case infrav1.HaproxyLoadBalancer:
+		if !s.controlPlaneInitialized() {
+			if !s.instanceScope.IsControlPlane() {
+				return ErrControlPlaneNotInitialized
+			}
+			return s.updateHosts(s.instanceScope.InternalAddress())
+		}
+		address, err := s.joinedControlPlaneAddress(ctx)
+		if err != nil {
+			return err
+		}
+		return s.updateHosts(address)
+	default:
+		return errors.Errorf("unsupported control plane load balancer type %q", s.scope.ControlPlaneLoadBalancer().Type)
+	}
+}
+
+// Deploy deploys the control plane load balancer on the provisioned instance.
+func (s *Service) Deploy(_ context.Context) error {
+	switch s.scope.ControlPlaneLoadBalancer().Type {
+	case infrav1.KubeVipLoadBalancer:
+		if !s.instanceScope.IsControlPlane() {
+			return nil
+		}
+		return s.generateKubeVipManifest()
+	case infrav1.HaproxyLoadBalancer:
+		if s.instanceScope.IsControlPlane() {
+			return s.updateHosts(s.instanceScope.InternalAddress())
+		}
+		if err := s.generateHaproxy(); err != nil {
+			return err
+		}
+		if _, err := s.sshClient.SudoCmdf("curl -sf http://127.0.0.1:%d/healthz", haproxyHealthCheckPort); err != nil {
+			return errors.Wrap(err, "haproxy is not healthy")
+		}
+		return s.updateHosts("127.0.0.1")
+	default:
+		return errors.Errorf("unsupported control plane load balancer type %q", s.scope.ControlPlaneLoadBalancer().Type)
+	}
+}
+
+// UpToDate reports whether the deployed load balancer still matches the control plane instances.
+func (s *Service) UpToDate() bool {
+	if s.scope.ControlPlaneLoadBalancer().Type != infrav1.HaproxyLoadBalancer || s.instanceScope.IsControlPlane() {
+		return true
+	}
+	return s.instanceScope.KKInstance.GetAnnotations()[infrav1.HaproxyBackendsAnnotation] == strings.Join(s.controlPlaneAddresses(), ",")
+}
+
+func (s *Service) controlPlaneInitialized() bool {
+	return conditions.IsTrue(s.instanceScope.Cluster, clusterv1.ControlPlaneInitializedCondition)
+}
+
+// joinedControlPlaneAddress returns the internal address of a control plane which has joined the cluster.
+func (s *Service) joinedControlPlaneAddress(ctx context.Context) (string, error) {
+	machines, err := s.scope.GetMachines(ctx, collections.ControlPlaneMachines(s.scope.Name()), func(m *clusterv1.Machine) bool {
+		return m.Status.NodeRef != nil
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, m := range machines.SortedByCreationTimestamp() {
+		for _, addr := range m.Status.Addresses {
+			if addr.Type == clusterv1.MachineInternalIP && addr.Address != "" {
+				return addr.Address, nil
+			}
+		}
+	}
+	return "", errors.New("no control plane has joined the cluster")
+}
+
+// controlPlaneAddresses returns the internal addresses of all control plane instances.
+func (s *Service) controlPlaneAddresses() []string {
+	var addresses []string
+	for _, info := range s.scope.AllInstancesInfo() {
+		for _, r := range info.Roles {
+			if r == infrav1.ControlPlane || r == infrav1.Master {
+				addresses = append(addresses, info.InternalAddress)
+				break
+			}
+		}
+	}
+	return addresses
+}
+
+// updateHosts resolves the control plane endpoint host to the address in /etc/hosts.
+func (s *Service) updateHosts(address string) error {
+	host := s.scope.ControlPlaneEndpoint().Host
+	if _, err := s.sshClient.SudoCmd(fmt.Sprintf("sed -i '/ %s$/d' /etc/hosts && echo '%s %s' >> /etc/hosts", host, address, host)); err != nil {
+		return errors.Wrapf(err, "failed to resolve %s to %s", host, address)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package loadbalancer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	infrav1 "github.com/kubesphere/kubekey/v3/api/v1beta1"
+	"github.com/kubesphere/kubekey/v3/pkg/scope"
+)
+
+type fakeLBScope struct {
+	scope.LBScope
+	instances []infrav1.InstanceInfo
+}
+
+func (f *fakeLBScope) ControlPlaneLoadBalancer() *infrav1.KKLoadBalancerSpec {
+	return &infrav1.KKLoadBalancerSpec{Type: infrav1.HaproxyLoadBalancer}
+}
+
+func (f *fakeLBScope) AllInstancesInfo() []infrav1.InstanceInfo {
+	return f.instances
+}
+
+func newWorkerService(instances []infrav1.InstanceInfo, annotations map[string]string) *Service {
+	instanceScope := &scope.InstanceScope{
+		Cluster:    &clusterv1.Cluster{},
+		Machine:    &clusterv1.Machine{},
+		KKInstance: &infrav1.KKInstance{},
+	}
+	instanceScope.KKInstance.SetAnnotations(annotations)
+	return NewService(nil, &fakeLBScope{instances: instances}, instanceScope)
+}
+
+func TestService_UpToDate(t *testing.T) {
+	instances := []infrav1.InstanceInfo{
+		{Name: "cp1", InternalAddress: "10.0.0.1", Roles: []infrav1.Role{infrav1.ControlPlane}},
+		{Name: "cp2", InternalAddress: "10.0.0.2", Roles: []infrav1.Role{infrav1.ControlPlane}},
+		{Name: "worker1", InternalAddress: "10.0.0.3", Roles: []infrav1.Role{infrav1.Worker}},
+	}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{
+			name: "never rendered",
+			want: false,
+		},
+		{
+			name:        "rendered with the same control planes",
+			annotations: map[string]string{infrav1.HaproxyBackendsAnnotation: "10.0.0.1,10.0.0.2"},
+			want:        true,
+		},
+		{
+			name:        "a control plane was added",
+			annotations: map[string]string{infrav1.HaproxyBackendsAnnotation: "10.0.0.1"},
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newWorkerService(instances, tt.annotations).UpToDate(); got != tt.want {
+				t.Errorf("UpToDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_PrepareWaitsForControlPlane(t *testing.T) {
+	err := newWorkerService(nil, nil).Prepare(context.Background())
+	if !errors.Is(err, ErrControlPlaneNotInitialized) {
+		t.Errorf("Prepare() error = %v, want %v", err, ErrControlPlaneNotInitialized)
+	}
+}
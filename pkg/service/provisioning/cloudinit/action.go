@@ -17,16 +17,30 @@
 package cloudinit
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/kubesphere/kubekey/v3/pkg/clients/ssh"
 	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
 )
 
 const (
 	// Supported cloud config modules.
+	bootcmd    = "bootcmd"
 	writefiles = "write_files"
+	diskSetup  = "disk_setup"
+	fsSetup    = "fs_setup"
+	mounts     = "mounts"
+	users      = "users"
+	ntpModule  = "ntp"
+	aptModule  = "apt"
+	yumRepos   = "yum_repos"
 	runcmd     = "runcmd"
 )
 
+// moduleOrder is the order cloud-init runs the supported modules in, whatever their order in the cloud config:
+// bootcmd and write_files first, then the disks, the users, the packages configuration and finally runcmd.
+var moduleOrder = []string{bootcmd, writefiles, diskSetup, fsSetup, mounts, users, ntpModule, aptModule, yumRepos, runcmd}
+
 type action interface {
 	Unmarshal(userData []byte) error
 	Commands() ([]commands.Cmd, error)
@@ -35,23 +49,55 @@ type action interface {
 
 type actionFactory struct {
 	sshClient ssh.Interface
+	strict    bool
 }
 
 // newActionFactory returns a new action factory.
-func newActionFactory(sshClient ssh.Interface) *actionFactory {
+// In strict mode, the factory fails on the modules it does not support instead of ignoring them.
+func newActionFactory(sshClient ssh.Interface, strict bool) *actionFactory {
 	return &actionFactory{
 		sshClient: sshClient,
+		strict:    strict,
 	}
 }
 
-func (a *actionFactory) action(name string) action {
+func (a *actionFactory) action(name string) (action, error) {
 	switch name {
+	case bootcmd:
+		return newBootCmdAction(), nil
 	case writefiles:
-		return newWriteFilesAction(a.sshClient)
+		return newWriteFilesAction(a.sshClient), nil
+	case diskSetup:
+		return newDiskSetupAction(), nil
+	case fsSetup:
+		return newFSSetupAction(), nil
+	case mounts:
+		return newMountsAction(), nil
+	case users:
+		return newUsersAction(), nil
+	case ntpModule:
+		return newNTPAction(), nil
+	case aptModule:
+		return newAptAction(), nil
+	case yumRepos:
+		return newYumReposAction(), nil
 	case runcmd:
-		return newRunCmdAction()
+		return newRunCmdAction(), nil
 	default:
+		if a.strict {
+			return nil, errors.Errorf("unsupported cloud-config module %q", name)
+		}
 		// TODO Add a logger during the refactor and log this unknown module
-		return newUnknown(name)
+		return newUnknown(name), nil
+	}
+}
+
+// order returns the position of the module in moduleOrder; the unknown modules come last.
+func order(name string) int {
+	for i, m := range moduleOrder {
+		if m == name {
+			return i
+		}
 	}
+	return len(moduleOrder)
 }
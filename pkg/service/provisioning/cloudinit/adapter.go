@@ -20,6 +20,7 @@ import (
 	"bufio"
 	"bytes"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -27,15 +28,21 @@ import (
 	"github.com/kubesphere/kubekey/v3/pkg/clients/ssh"
 )
 
+type module struct {
+	name string
+	action
+}
+
 // getActions parses the cloud config yaml into a slice of actions to run.
-// Parsing manually is required because the order of the cloud config's actions must be maintained.
-func getActions(sshClient ssh.Interface, userData []byte) ([]action, error) {
+// Parsing manually is required because the order of the cloud config's actions must be maintained;
+// the actions are then sorted by module, keeping the cloud config order within the same module.
+func getActions(sshClient ssh.Interface, userData []byte, strict bool) ([]action, error) {
 	actionRegEx := regexp.MustCompile(`^[a-zA-Z_]*:`)
 	lines := make([]string, 0)
-	actions := make([]action, 0)
-	actionFactory := newActionFactory(sshClient)
+	modules := make([]module, 0)
+	actionFactory := newActionFactory(sshClient, strict)
 
-	var act action
+	var act *module
 
 	// scans the file searching for keys/top level actions.
 	scanner := bufio.NewScanner(bytes.NewReader(userData))
@@ -49,13 +56,17 @@ func getActions(sshClient ssh.Interface, userData []byte) ([]action, error) {
 				if err := act.Unmarshal([]byte(actionBlock)); err != nil {
 					return nil, errors.WithStack(err)
 				}
-				actions = append(actions, act)
+				modules = append(modules, *act)
 				lines = lines[:0]
 			}
 
 			// creates the new action
-			actionName := strings.TrimSuffix(line, ":")
-			act = actionFactory.action(actionName)
+			actionName := strings.TrimSuffix(actionRegEx.FindString(line), ":")
+			a, err := actionFactory.action(actionName)
+			if err != nil {
+				return nil, err
+			}
+			act = &module{name: actionName, action: a}
 		}
 
 		lines = append(lines, line)
@@ -67,8 +78,18 @@ func getActions(sshClient ssh.Interface, userData []byte) ([]action, error) {
 		if err := act.Unmarshal([]byte(actionBlock)); err != nil {
 			return nil, errors.WithStack(err)
 		}
-		actions = append(actions, act)
+		modules = append(modules, *act)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.WithStack(err)
 	}
 
-	return actions, scanner.Err()
+	sort.SliceStable(modules, func(i, j int) bool {
+		return order(modules[i].name) < order(modules[j].name)
+	})
+	actions := make([]action, 0, len(modules))
+	for _, m := range modules {
+		actions = append(actions, m.action)
+	}
+	return actions, nil
 }
@@ -0,0 +1,90 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+)
+
+func TestGetActions(t *testing.T) {
+	cloudData := `## template: jinja
+#cloud-config
+runcmd:
+- echo run
+timezone: UTC
+users:
+- name: foo
+bootcmd:
+- echo boot
+write_files:
+- path: /tmp/foo
+  content: bar
+`
+
+	var useCases = []struct {
+		name          string
+		strict        bool
+		expectedCmds  []commands.Cmd
+		expectedError bool
+	}{
+		{
+			name:   "actions are sorted by module and unknown modules are skipped",
+			strict: false,
+			expectedCmds: []commands.Cmd{
+				{Cmd: "/bin/sh", Args: []string{"-c", "echo boot"}},
+				{Cmd: "mkdir", Args: []string{"-p", "/tmp"}},
+				{Cmd: "/bin/sh", Args: []string{"-c", "echo 'bar' > /tmp/foo"}},
+				{Cmd: "/bin/sh", Args: []string{"-c", "id -u 'foo' >/dev/null 2>&1 || useradd -m 'foo'"}},
+				{Cmd: "/bin/sh", Args: []string{"-c", "echo run"}},
+			},
+		},
+		{
+			name:          "unknown modules fail in strict mode",
+			strict:        true,
+			expectedError: true,
+		},
+	}
+
+	for _, rt := range useCases {
+		t.Run(rt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cmds, err := NewService(nil, rt.strict).RawBootstrapDataToProvisioningCommands([]byte(cloudData))
+			if rt.expectedError {
+				g.Expect(err).To(MatchError(ContainSubstring("timezone")))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(cmds).To(Equal(rt.expectedCmds))
+		})
+	}
+}
+
+// decodeScript returns the script run by a command generated with commands.Script.
+func decodeScript(g *WithT, cmd commands.Cmd) string {
+	g.Expect(cmd.Args).To(HaveLen(2))
+	b64 := strings.TrimSuffix(strings.TrimPrefix(cmd.Args[1], "echo '"), "' | base64 -d | /bin/sh")
+	script, err := base64.StdEncoding.DecodeString(b64)
+	g.Expect(err).NotTo(HaveOccurred())
+	return string(script)
+}
@@ -0,0 +1,153 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+)
+
+const (
+	aptSourcesDir   = "/etc/apt/sources.list.d"
+	aptTrustedDir   = "/etc/apt/trusted.gpg.d"
+	aptProxyFile    = "/etc/apt/apt.conf.d/90cloud-init-aptproxy"
+	aptConfFile     = "/etc/apt/apt.conf.d/94cloud-init-config"
+	aptKeyserver    = "keyserver.ubuntu.com"
+	aptReleaseToken = "$RELEASE"
+)
+
+// aptAction defines the apt configuration of a node, replicating the cloud-init apt module.
+// The proxies, the additional apt configuration and the sources are supported; the mirrors are not.
+// As in cloud-init, the action does nothing on distributions without apt.
+type aptAction struct {
+	Apt apt `json:"apt,"`
+}
+
+type apt struct {
+	Proxy      string               `json:"proxy,omitempty"`
+	HTTPProxy  string               `json:"http_proxy,omitempty"`
+	HTTPSProxy string               `json:"https_proxy,omitempty"`
+	FTPProxy   string               `json:"ftp_proxy,omitempty"`
+	Conf       string               `json:"conf,omitempty"`
+	Sources    map[string]aptSource `json:"sources,omitempty"`
+}
+
+type aptSource struct {
+	Source    string `json:"source,omitempty"`
+	Key       string `json:"key,omitempty"`
+	KeyID     string `json:"keyid,omitempty"`
+	Keyserver string `json:"keyserver,omitempty"`
+	Filename  string `json:"filename,omitempty"`
+}
+
+func newAptAction() action {
+	return &aptAction{}
+}
+
+// Unmarshal the aptAction.
+func (a *aptAction) Unmarshal(userData []byte) error {
+	if err := yaml.Unmarshal(userData, a); err != nil {
+		return errors.Wrapf(err, "error parsing apt action: %s", userData)
+	}
+	return nil
+}
+
+// Commands returns the commands configuring apt.
+func (a *aptAction) Commands() ([]commands.Cmd, error) {
+	var script strings.Builder
+	script.WriteString("if ! command -v apt-get >/dev/null 2>&1; then exit 0; fi\n")
+	fmt.Fprintf(&script, "mkdir -p %s %s %s\n", filepath.Dir(aptProxyFile), aptSourcesDir, aptTrustedDir)
+
+	httpProxy := a.Apt.HTTPProxy
+	if httpProxy == "" {
+		httpProxy = a.Apt.Proxy
+	}
+	proxies := make([]string, 0)
+	for _, p := range []struct{ scheme, value string }{
+		{"http", httpProxy},
+		{"https", a.Apt.HTTPSProxy},
+		{"ftp", a.Apt.FTPProxy},
+	} {
+		if p.value != "" {
+			proxies = append(proxies, fmt.Sprintf("Acquire::%s::Proxy \"%s\";", p.scheme, p.value))
+		}
+	}
+	if len(proxies) > 0 {
+		fmt.Fprintf(&script, "echo %s > %s\n", commands.Quote(strings.Join(proxies, "\n")), aptProxyFile)
+	}
+	if a.Apt.Conf != "" {
+		fmt.Fprintf(&script, "echo %s > %s\n", commands.Quote(a.Apt.Conf), aptConfFile)
+	}
+
+	names := make([]string, 0, len(a.Apt.Sources))
+	for name := range a.Apt.Sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) > 0 {
+		script.WriteString("release=$(. /etc/os-release && echo \"$VERSION_CODENAME\")\n")
+	}
+	for _, name := range names {
+		s := a.Apt.Sources[name]
+		base := strings.TrimSuffix(filepath.Base(name), ".list")
+		if base == "" || base == "." || base == "/" {
+			return nil, errors.Errorf("invalid apt source name %q", name)
+		}
+
+		if s.Key != "" {
+			fmt.Fprintf(&script, "echo %s > %s\n", commands.Quote(s.Key), commands.Quote(filepath.Join(aptTrustedDir, base+".asc")))
+		} else if s.KeyID != "" {
+			keyserver := s.Keyserver
+			if keyserver == "" {
+				keyserver = aptKeyserver
+			}
+			script.WriteString("export GNUPGHOME=$(mktemp -d)\n")
+			fmt.Fprintf(&script, "gpg --batch --keyserver %s --recv-keys %s\n", commands.Quote(keyserver), commands.Quote(s.KeyID))
+			fmt.Fprintf(&script, "gpg --batch --export %s > %s\n", commands.Quote(s.KeyID), commands.Quote(filepath.Join(aptTrustedDir, base+".gpg")))
+			script.WriteString("rm -rf \"$GNUPGHOME\"\nunset GNUPGHOME\n")
+		}
+
+		if s.Source == "" {
+			continue
+		}
+		filename := s.Filename
+		if filename == "" {
+			filename = base
+		}
+		if !strings.HasSuffix(filename, ".list") {
+			filename += ".list"
+		}
+		if !filepath.IsAbs(filename) {
+			filename = filepath.Join(aptSourcesDir, filename)
+		}
+		source := strings.ReplaceAll(s.Source, aptReleaseToken, "@RELEASE@")
+		fmt.Fprintf(&script, "echo %s | sed \"s/@RELEASE@/$release/g\" >> %s\n", commands.Quote(source), commands.Quote(filename))
+	}
+	return []commands.Cmd{commands.Script(script.String())}, nil
+}
+
+// Run runs the commands.
+func (a *aptAction) Run() error {
+	return nil
+}
@@ -0,0 +1,49 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestApt(t *testing.T) {
+	g := NewWithT(t)
+
+	cloudData := `
+apt:
+  http_proxy: http://proxy.example.com:3128
+  sources:
+    docker.list:
+      source: deb [arch=amd64] https://download.docker.com/linux/ubuntu $RELEASE stable
+      keyid: 9DC858229FC7DD38854AE2D88D81803C0EBFCD88`
+	a := aptAction{}
+	g.Expect(a.Unmarshal([]byte(cloudData))).To(Succeed())
+
+	cmds, err := a.Commands()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cmds).To(HaveLen(1))
+
+	script := decodeScript(g, cmds[0])
+	g.Expect(script).To(HavePrefix("set -e\nif ! command -v apt-get >/dev/null 2>&1; then exit 0; fi\n"))
+	g.Expect(script).To(ContainSubstring("echo 'Acquire::http::Proxy \"http://proxy.example.com:3128\";' > /etc/apt/apt.conf.d/90cloud-init-aptproxy\n"))
+	g.Expect(script).To(ContainSubstring("gpg --batch --keyserver 'keyserver.ubuntu.com' --recv-keys '9DC858229FC7DD38854AE2D88D81803C0EBFCD88'\n"))
+	g.Expect(script).To(ContainSubstring("gpg --batch --export '9DC858229FC7DD38854AE2D88D81803C0EBFCD88' > '/etc/apt/trusted.gpg.d/docker.gpg'\n"))
+	g.Expect(script).To(ContainSubstring("echo 'deb [arch=amd64] https://download.docker.com/linux/ubuntu @RELEASE@ stable' | " +
+		"sed \"s/@RELEASE@/$release/g\" >> '/etc/apt/sources.list.d/docker.list'\n"))
+}
@@ -0,0 +1,52 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+)
+
+// bootCmd defines parameters of a shell command that is equivalent to an action found in the cloud init bootcmd module.
+// Unlike cloud-init, the commands are run only once, when the instance is provisioned.
+type bootCmd struct {
+	Cmds []commands.Cmd `json:"bootcmd,"`
+}
+
+func newBootCmdAction() action {
+	return &bootCmd{}
+}
+
+// Unmarshal the bootCmd.
+func (a *bootCmd) Unmarshal(userData []byte) error {
+	if err := yaml.Unmarshal(userData, a); err != nil {
+		return errors.Wrapf(err, "error parsing bootcmd action: %s", userData)
+	}
+	return nil
+}
+
+// Commands returns the commands.
+func (a *bootCmd) Commands() ([]commands.Cmd, error) {
+	return append([]commands.Cmd{}, a.Cmds...), nil
+}
+
+// Run runs the commands.
+func (a *bootCmd) Run() error {
+	return nil
+}
@@ -0,0 +1,278 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+)
+
+// diskSetupAction defines the partition tables of the node disks, replicating the disk_setup part of the
+// cloud-init disk_setup module. The partitions are created with parted.
+type diskSetupAction struct {
+	Disks map[string]disk `json:"disk_setup,"`
+}
+
+type disk struct {
+	TableType string          `json:"table_type,omitempty"`
+	Layout    json.RawMessage `json:"layout,omitempty"`
+	Overwrite bool            `json:"overwrite,omitempty"`
+}
+
+type partition struct {
+	percent int
+	swap    bool
+}
+
+func newDiskSetupAction() action {
+	return &diskSetupAction{}
+}
+
+// Unmarshal the diskSetupAction.
+func (a *diskSetupAction) Unmarshal(userData []byte) error {
+	if err := yaml.Unmarshal(userData, a); err != nil {
+		return errors.Wrapf(err, "error parsing disk_setup action: %s", userData)
+	}
+	return nil
+}
+
+// Commands returns the commands partitioning the disks.
+// Unless overwrite is set, a disk already holding a partition table or a filesystem is left untouched.
+func (a *diskSetupAction) Commands() ([]commands.Cmd, error) {
+	devices := make([]string, 0, len(a.Disks))
+	for d := range a.Disks {
+		devices = append(devices, d)
+	}
+	sort.Strings(devices)
+
+	cmds := make([]commands.Cmd, 0)
+	for _, device := range devices {
+		d := a.Disks[device]
+		partitions, err := d.partitions()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid layout for %s", device)
+		}
+		if len(partitions) == 0 {
+			continue
+		}
+
+		label := "msdos"
+		switch d.TableType {
+		case "", "mbr":
+		case "gpt":
+			label = "gpt"
+		default:
+			return nil, errors.Errorf("unsupported table_type %q for %s", d.TableType, device)
+		}
+
+		args := []string{"parted", "-s", "-a", "optimal", commands.Quote(device), "mklabel", label}
+		start := 0
+		for i, p := range partitions {
+			end := start + p.percent
+			if i == len(partitions)-1 || end > 100 {
+				end = 100
+			}
+			args = append(args, "mkpart", "primary")
+			if p.swap {
+				args = append(args, "linux-swap")
+			}
+			args = append(args, fmt.Sprintf("%d%%", start), fmt.Sprintf("%d%%", end))
+			start = end
+		}
+
+		var script strings.Builder
+		if !d.Overwrite {
+			fmt.Fprintf(&script, "if [ -z \"$(blkid -o value -s PTTYPE %s)\" ] && [ -z \"$(blkid -o value -s TYPE %s)\" ]; then\n",
+				commands.Quote(device), commands.Quote(device))
+		} else {
+			script.WriteString("if true; then\n")
+		}
+		fmt.Fprintf(&script, "%s\npartprobe %s || true\nudevadm settle || true\nfi\n", strings.Join(args, " "), commands.Quote(device))
+		cmds = append(cmds, commands.Script(script.String()))
+	}
+	return cmds, nil
+}
+
+// partitions parses the layout, that is either a boolean or a list of percentages, optionally paired with a partition type.
+func (d disk) partitions() ([]partition, error) {
+	if len(d.Layout) == 0 {
+		return nil, nil
+	}
+
+	var b bool
+	if err := json.Unmarshal(d.Layout, &b); err == nil {
+		if !b {
+			return nil, nil
+		}
+		return []partition{{percent: 100}}, nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(d.Layout, &items); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	partitions := make([]partition, 0, len(items))
+	for _, item := range items {
+		var p partition
+		var percent int
+		if err := json.Unmarshal(item, &percent); err == nil {
+			p.percent = percent
+			partitions = append(partitions, p)
+			continue
+		}
+
+		var pair []interface{}
+		if err := json.Unmarshal(item, &pair); err != nil || len(pair) != 2 {
+			return nil, errors.Errorf("invalid partition %s", item)
+		}
+		f, ok := pair[0].(float64)
+		if !ok {
+			return nil, errors.Errorf("invalid partition %s", item)
+		}
+		p.percent = int(f)
+		typeCode := fmt.Sprint(pair[1])
+		p.swap = typeCode == "82" || typeCode == "8200"
+		partitions = append(partitions, p)
+	}
+	return partitions, nil
+}
+
+// Run runs the commands.
+func (a *diskSetupAction) Run() error {
+	return nil
+}
+
+// fsSetupAction defines the filesystems to create on the node disks, replicating the fs_setup part of the
+// cloud-init disk_setup module.
+type fsSetupAction struct {
+	Filesystems []fsEntry `json:"fs_setup,"`
+}
+
+type fsEntry struct {
+	Label      string      `json:"label,omitempty"`
+	Filesystem string      `json:"filesystem,"`
+	Device     string      `json:"device,"`
+	Partition  interface{} `json:"partition,omitempty"`
+	Overwrite  bool        `json:"overwrite,omitempty"`
+	ReplaceFS  string      `json:"replace_fs,omitempty"`
+	ExtraOpts  stringList  `json:"extra_opts,omitempty"`
+	Cmd        stringList  `json:"cmd,omitempty"`
+}
+
+func newFSSetupAction() action {
+	return &fsSetupAction{}
+}
+
+// Unmarshal the fsSetupAction.
+func (a *fsSetupAction) Unmarshal(userData []byte) error {
+	if err := yaml.Unmarshal(userData, a); err != nil {
+		return errors.Wrapf(err, "error parsing fs_setup action: %s", userData)
+	}
+	return nil
+}
+
+// Commands returns the commands creating the filesystems.
+// Unless overwrite is set, or the existing filesystem matches replace_fs, a device already holding a filesystem is left untouched.
+func (a *fsSetupAction) Commands() ([]commands.Cmd, error) {
+	cmds := make([]commands.Cmd, 0)
+	for _, fs := range a.Filesystems {
+		if fs.Device == "" || fs.Filesystem == "" {
+			return nil, errors.Errorf("fs_setup entry %q requires both device and filesystem", fs.Label)
+		}
+		if len(fs.Cmd) > 0 {
+			return nil, errors.Errorf("fs_setup cmd is not supported, found for %s", fs.Device)
+		}
+
+		var script strings.Builder
+		part := ""
+		if fs.Partition != nil {
+			part = strings.TrimSpace(fmt.Sprint(fs.Partition))
+		}
+		switch part {
+		case "", "none":
+			fmt.Fprintf(&script, "target=%s\n", commands.Quote(fs.Device))
+		case "auto", "any":
+			// the first partition without a filesystem, or the device itself if it is not partitioned.
+			fmt.Fprintf(&script, "target=\nparts=$(lsblk -lnpo NAME,TYPE %s | awk '$2 == \"part\" {print $1}')\n", commands.Quote(fs.Device))
+			fmt.Fprintf(&script, "[ -n \"$parts\" ] || target=%s\n", commands.Quote(fs.Device))
+			script.WriteString("for p in $parts; do\nif [ -z \"$(blkid -o value -s TYPE \"$p\")\" ]; then target=$p; break; fi\ndone\n")
+			script.WriteString("if [ -z \"$target\" ]; then exit 0; fi\n")
+		default:
+			if _, err := strconv.Atoi(part); err != nil {
+				return nil, errors.Errorf("invalid partition %q for %s", part, fs.Device)
+			}
+			fmt.Fprintf(&script, "target=%s\n", commands.Quote(partitionPath(fs.Device, part)))
+		}
+
+		mkfs := []string{"mkfs." + fs.Filesystem}
+		if fs.Filesystem == "swap" {
+			mkfs = []string{"mkswap"}
+		}
+		if fs.Label != "" {
+			labelFlag := "-L"
+			if fs.Filesystem == "vfat" || fs.Filesystem == "fat" {
+				labelFlag = "-n"
+			}
+			mkfs = append(mkfs, labelFlag, commands.Quote(fs.Label))
+		}
+		if fs.Overwrite || fs.ReplaceFS != "" {
+			switch fs.Filesystem {
+			case "ext2", "ext3", "ext4":
+				mkfs = append(mkfs, "-F")
+			case "xfs", "btrfs", "swap":
+				mkfs = append(mkfs, "-f")
+			}
+		}
+		for _, o := range fs.ExtraOpts {
+			mkfs = append(mkfs, commands.Quote(o))
+		}
+		mkfs = append(mkfs, "\"$target\"")
+
+		script.WriteString("current=$(blkid -o value -s TYPE \"$target\" || true)\n")
+		cond := "[ -z \"$current\" ]"
+		if fs.Overwrite {
+			cond = "true"
+		} else if fs.ReplaceFS != "" {
+			cond = fmt.Sprintf("[ -z \"$current\" ] || [ \"$current\" = %s ]", commands.Quote(fs.ReplaceFS))
+		}
+		fmt.Fprintf(&script, "if %s; then\n%s\nfi\n", cond, strings.Join(mkfs, " "))
+		cmds = append(cmds, commands.Script(script.String()))
+	}
+	return cmds, nil
+}
+
+// Run runs the commands.
+func (a *fsSetupAction) Run() error {
+	return nil
+}
+
+// partitionPath returns the path of the given partition of a device, e.g. /dev/sdb1 or /dev/nvme0n1p1.
+func partitionPath(device, part string) string {
+	if device != "" && unicode.IsDigit(rune(device[len(device)-1])) {
+		return device + "p" + part
+	}
+	return device + part
+}
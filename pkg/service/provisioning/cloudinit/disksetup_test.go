@@ -0,0 +1,176 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDiskSetup(t *testing.T) {
+	var useCases = []struct {
+		name           string
+		cloudData      string
+		expectedScript []string
+		expectedError  bool
+	}{
+		{
+			name: "whole disk",
+			cloudData: `
+disk_setup:
+  /dev/sdb:
+    table_type: gpt
+    layout: true`,
+			expectedScript: []string{
+				"if [ -z \"$(blkid -o value -s PTTYPE '/dev/sdb')\" ] && [ -z \"$(blkid -o value -s TYPE '/dev/sdb')\" ]; then\n",
+				"parted -s -a optimal '/dev/sdb' mklabel gpt mkpart primary 0% 100%\n",
+			},
+		},
+		{
+			name: "layout with swap partition and overwrite",
+			cloudData: `
+disk_setup:
+  /dev/sdb:
+    layout: [ 33, [ 67, 82 ] ]
+    overwrite: true`,
+			expectedScript: []string{
+				"if true; then\n",
+				"parted -s -a optimal '/dev/sdb' mklabel msdos mkpart primary 0% 33% mkpart primary linux-swap 33% 100%\n",
+			},
+		},
+		{
+			name: "no layout",
+			cloudData: `
+disk_setup:
+  /dev/sdb:
+    layout: false`,
+		},
+		{
+			name: "unsupported table type",
+			cloudData: `
+disk_setup:
+  /dev/sdb:
+    table_type: aix
+    layout: true`,
+			expectedError: true,
+		},
+	}
+
+	for _, rt := range useCases {
+		t.Run(rt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			a := diskSetupAction{}
+			g.Expect(a.Unmarshal([]byte(rt.cloudData))).To(Succeed())
+			cmds, err := a.Commands()
+			if rt.expectedError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if len(rt.expectedScript) == 0 {
+				g.Expect(cmds).To(BeEmpty())
+				return
+			}
+			g.Expect(cmds).To(HaveLen(1))
+			script := decodeScript(g, cmds[0])
+			for _, s := range rt.expectedScript {
+				g.Expect(script).To(ContainSubstring(s))
+			}
+		})
+	}
+}
+
+func TestFSSetup(t *testing.T) {
+	var useCases = []struct {
+		name           string
+		cloudData      string
+		expectedScript []string
+		expectedError  bool
+	}{
+		{
+			name: "partition",
+			cloudData: `
+fs_setup:
+- label: etcd
+  filesystem: ext4
+  device: /dev/nvme0n1
+  partition: 1`,
+			expectedScript: []string{
+				"target='/dev/nvme0n1p1'\n",
+				"if [ -z \"$current\" ]; then\nmkfs.ext4 -L 'etcd' \"$target\"\nfi\n",
+			},
+		},
+		{
+			name: "auto partition with replace_fs",
+			cloudData: `
+fs_setup:
+- filesystem: xfs
+  device: /dev/sdb
+  partition: auto
+  replace_fs: ntfs
+  extra_opts: [ -i, size=512 ]`,
+			expectedScript: []string{
+				"parts=$(lsblk -lnpo NAME,TYPE '/dev/sdb' | awk '$2 == \"part\" {print $1}')\n",
+				"if [ -z \"$current\" ] || [ \"$current\" = 'ntfs' ]; then\nmkfs.xfs -f '-i' 'size=512' \"$target\"\nfi\n",
+			},
+		},
+		{
+			name: "swap with overwrite",
+			cloudData: `
+fs_setup:
+- label: swap
+  filesystem: swap
+  device: /dev/sdc
+  overwrite: true`,
+			expectedScript: []string{
+				"target='/dev/sdc'\n",
+				"if true; then\nmkswap -L 'swap' -f \"$target\"\nfi\n",
+			},
+		},
+		{
+			name: "cmd is not supported",
+			cloudData: `
+fs_setup:
+- filesystem: ext4
+  device: /dev/sdb
+  cmd: mkfs -t %(filesystem)s %(device)s`,
+			expectedError: true,
+		},
+	}
+
+	for _, rt := range useCases {
+		t.Run(rt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			a := fsSetupAction{}
+			g.Expect(a.Unmarshal([]byte(rt.cloudData))).To(Succeed())
+			cmds, err := a.Commands()
+			if rt.expectedError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(cmds).To(HaveLen(1))
+			script := decodeScript(g, cmds[0])
+			for _, s := range rt.expectedScript {
+				g.Expect(script).To(ContainSubstring(s))
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+)
+
+// mountComment marks the fstab entries managed by the mounts action, so they can be replaced on the next run.
+const mountComment = "comment=cloudconfig"
+
+// mountDefaults are the default values of the fstab fields, as in cloud-init mount_default_fields.
+var mountDefaults = []string{"", "", "auto", "defaults,nofail", "0", "2"}
+
+// mountsAction defines a list of fstab entries, replicating the cloud-init mounts module.
+type mountsAction struct {
+	Mounts [][]interface{} `json:"mounts,"`
+}
+
+func newMountsAction() action {
+	return &mountsAction{}
+}
+
+// Unmarshal the mountsAction.
+func (a *mountsAction) Unmarshal(userData []byte) error {
+	if err := yaml.Unmarshal(userData, a); err != nil {
+		return errors.Wrapf(err, "error parsing mounts action: %s", userData)
+	}
+	return nil
+}
+
+// Commands returns the commands adding the entries to /etc/fstab and mounting them.
+// As in cloud-init, an entry without mount point removes the device from the managed entries.
+func (a *mountsAction) Commands() ([]commands.Cmd, error) {
+	if len(a.Mounts) == 0 {
+		return []commands.Cmd{}, nil
+	}
+
+	lines := make([]string, 0)
+	mountPoints := make([]string, 0)
+	swap := false
+	for _, m := range a.Mounts {
+		if len(m) > len(mountDefaults) {
+			return nil, errors.Errorf("invalid mount entry %v", m)
+		}
+		fields := append([]string{}, mountDefaults...)
+		for i, v := range m {
+			if v != nil {
+				fields[i] = strings.TrimSpace(fmt.Sprint(v))
+			}
+		}
+		if fields[0] == "" || fields[1] == "" || strings.EqualFold(fields[1], "none") && fields[2] != "swap" {
+			continue
+		}
+		if !strings.HasPrefix(fields[0], "/") && !strings.Contains(fields[0], "=") && !strings.Contains(fields[0], ":") {
+			fields[0] = "/dev/" + fields[0]
+		}
+		fields[3] = fields[3] + "," + mountComment
+		lines = append(lines, strings.Join(fields, "\t"))
+		if fields[2] == "swap" {
+			swap = true
+		} else {
+			mountPoints = append(mountPoints, fields[1])
+		}
+	}
+
+	cmds := make([]commands.Cmd, 0)
+	for _, p := range mountPoints {
+		cmds = append(cmds, commands.Cmd{Cmd: "mkdir", Args: []string{"-p", p}})
+	}
+	cmds = append(cmds, commands.Cmd{Cmd: "sed", Args: []string{"-i", fmt.Sprintf("'/%s/d'", mountComment), "/etc/fstab"}})
+	if len(lines) > 0 {
+		cmds = append(cmds, commands.WriteFile([]byte(strings.Join(lines, "\n")+"\n"), ">>", "/etc/fstab"))
+	}
+	cmds = append(cmds,
+		commands.Cmd{Cmd: "/bin/sh", Args: []string{"-c", "systemctl daemon-reload || true"}},
+		commands.Cmd{Cmd: "mount", Args: []string{"-a"}},
+	)
+	if swap {
+		cmds = append(cmds, commands.Cmd{Cmd: "swapon", Args: []string{"-a"}})
+	}
+	return cmds, nil
+}
+
+// Run runs the commands.
+func (a *mountsAction) Run() error {
+	return nil
+}
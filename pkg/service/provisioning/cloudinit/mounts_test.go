@@ -0,0 +1,54 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+)
+
+func TestMounts(t *testing.T) {
+	g := NewWithT(t)
+
+	cloudData := `
+mounts:
+- [ sdb, /data ]
+- [ LABEL=etcd, /var/lib/etcd, ext4, "defaults,noatime", 0, 0 ]
+- [ /dev/sdc, none, swap, sw, 0, 0 ]
+- [ sdd, null ]`
+	a := mountsAction{}
+	g.Expect(a.Unmarshal([]byte(cloudData))).To(Succeed())
+
+	cmds, err := a.Commands()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	fstab := "/dev/sdb\t/data\tauto\tdefaults,nofail,comment=cloudconfig\t0\t2\n" +
+		"LABEL=etcd\t/var/lib/etcd\text4\tdefaults,noatime,comment=cloudconfig\t0\t0\n" +
+		"/dev/sdc\tnone\tswap\tsw,comment=cloudconfig\t0\t0\n"
+	g.Expect(cmds).To(Equal([]commands.Cmd{
+		{Cmd: "mkdir", Args: []string{"-p", "/data"}},
+		{Cmd: "mkdir", Args: []string{"-p", "/var/lib/etcd"}},
+		{Cmd: "sed", Args: []string{"-i", "'/comment=cloudconfig/d'", "/etc/fstab"}},
+		commands.WriteFile([]byte(fstab), ">>", "/etc/fstab"),
+		{Cmd: "/bin/sh", Args: []string{"-c", "systemctl daemon-reload || true"}},
+		{Cmd: "mount", Args: []string{"-a"}},
+		{Cmd: "swapon", Args: []string{"-a"}},
+	}))
+}
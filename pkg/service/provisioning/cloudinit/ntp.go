@@ -0,0 +1,125 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+)
+
+const (
+	ntpClientAuto      = "auto"
+	ntpClientChrony    = "chrony"
+	ntpClientTimesyncd = "systemd-timesyncd"
+
+	timesyncdDropIn = "/etc/systemd/timesyncd.conf.d/cloud-init.conf"
+)
+
+// ntpAction defines the NTP configuration of a node, replicating the cloud-init ntp module.
+// Only the chrony and systemd-timesyncd clients are supported.
+type ntpAction struct {
+	NTP ntp `json:"ntp,"`
+}
+
+type ntp struct {
+	Enabled   *bool    `json:"enabled,omitempty"`
+	NTPClient string   `json:"ntp_client,omitempty"`
+	Servers   []string `json:"servers,omitempty"`
+	Pools     []string `json:"pools,omitempty"`
+}
+
+func newNTPAction() action {
+	return &ntpAction{}
+}
+
+// Unmarshal the ntpAction.
+func (a *ntpAction) Unmarshal(userData []byte) error {
+	if err := yaml.Unmarshal(userData, a); err != nil {
+		return errors.Wrapf(err, "error parsing ntp action: %s", userData)
+	}
+	return nil
+}
+
+// Commands returns the commands configuring the NTP client available on the node.
+func (a *ntpAction) Commands() ([]commands.Cmd, error) {
+	n := a.NTP
+	if n.Enabled != nil && !*n.Enabled {
+		return []commands.Cmd{}, nil
+	}
+	if len(n.Servers) == 0 && len(n.Pools) == 0 {
+		return []commands.Cmd{}, nil
+	}
+
+	var chrony, timesyncd string
+	switch n.NTPClient {
+	case "", ntpClientAuto:
+		chrony, timesyncd = n.chronyScript(), n.timesyncdScript()
+	case ntpClientChrony:
+		chrony = n.chronyScript()
+	case ntpClientTimesyncd:
+		timesyncd = n.timesyncdScript()
+	default:
+		return nil, errors.Errorf("unsupported ntp client %q", n.NTPClient)
+	}
+
+	var script strings.Builder
+	if chrony != "" {
+		fmt.Fprintf(&script, "if command -v chronyd >/dev/null 2>&1; then\n%s", chrony)
+		if timesyncd != "" {
+			script.WriteString("el")
+		}
+	}
+	if timesyncd != "" {
+		fmt.Fprintf(&script, "if systemctl cat systemd-timesyncd.service >/dev/null 2>&1; then\n%s", timesyncd)
+	}
+	script.WriteString("else\necho 'no supported ntp client found' >&2\nexit 1\nfi\n")
+	return []commands.Cmd{commands.Script(script.String())}, nil
+}
+
+func (n ntp) chronyScript() string {
+	var script strings.Builder
+	script.WriteString("conf=/etc/chrony.conf\n[ -d /etc/chrony ] && conf=/etc/chrony/chrony.conf\n")
+	script.WriteString("sed -i -E '/^(server|pool) /d' \"$conf\"\n")
+	for _, s := range n.Servers {
+		fmt.Fprintf(&script, "echo %s >> \"$conf\"\n", commands.Quote("server "+s+" iburst"))
+	}
+	for _, p := range n.Pools {
+		fmt.Fprintf(&script, "echo %s >> \"$conf\"\n", commands.Quote("pool "+p+" iburst"))
+	}
+	script.WriteString("unit=chronyd\nsystemctl cat chronyd.service >/dev/null 2>&1 || unit=chrony\n")
+	script.WriteString("systemctl enable \"$unit\"\nsystemctl restart \"$unit\"\n")
+	return script.String()
+}
+
+func (n ntp) timesyncdScript() string {
+	servers := strings.Join(append(append([]string{}, n.Servers...), n.Pools...), " ")
+	var script strings.Builder
+	fmt.Fprintf(&script, "mkdir -p /etc/systemd/timesyncd.conf.d\n")
+	fmt.Fprintf(&script, "printf '[Time]\\nNTP=%%s\\n' %s > %s\n", commands.Quote(servers), timesyncdDropIn)
+	script.WriteString("systemctl enable systemd-timesyncd\nsystemctl restart systemd-timesyncd\n")
+	return script.String()
+}
+
+// Run runs the commands.
+func (a *ntpAction) Run() error {
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNTP(t *testing.T) {
+	disabled := false
+	var useCases = []struct {
+		name           string
+		ntp            ntp
+		expectedScript []string
+		expectedEmpty  bool
+		expectedError  bool
+	}{
+		{
+			name: "auto",
+			ntp:  ntp{Servers: []string{"0.pool.ntp.org"}, Pools: []string{"pool.ntp.org"}},
+			expectedScript: []string{
+				"if command -v chronyd >/dev/null 2>&1; then\n",
+				"echo 'server 0.pool.ntp.org iburst' >> \"$conf\"\necho 'pool pool.ntp.org iburst' >> \"$conf\"\n",
+				"elif systemctl cat systemd-timesyncd.service >/dev/null 2>&1; then\n",
+				"printf '[Time]\\nNTP=%s\\n' '0.pool.ntp.org pool.ntp.org' > /etc/systemd/timesyncd.conf.d/cloud-init.conf\n",
+			},
+		},
+		{
+			name: "systemd-timesyncd only",
+			ntp:  ntp{NTPClient: "systemd-timesyncd", Servers: []string{"ntp.example.com"}},
+			expectedScript: []string{
+				"if systemctl cat systemd-timesyncd.service >/dev/null 2>&1; then\n",
+				"else\necho 'no supported ntp client found' >&2\nexit 1\nfi\n",
+			},
+		},
+		{
+			name:          "disabled",
+			ntp:           ntp{Enabled: &disabled, Servers: []string{"ntp.example.com"}},
+			expectedEmpty: true,
+		},
+		{
+			name:          "unsupported client",
+			ntp:           ntp{NTPClient: "openntpd", Servers: []string{"ntp.example.com"}},
+			expectedError: true,
+		},
+	}
+
+	for _, rt := range useCases {
+		t.Run(rt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			a := ntpAction{NTP: rt.ntp}
+			cmds, err := a.Commands()
+			if rt.expectedError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if rt.expectedEmpty {
+				g.Expect(cmds).To(BeEmpty())
+				return
+			}
+			g.Expect(cmds).To(HaveLen(1))
+			script := decodeScript(g, cmds[0])
+			for _, s := range rt.expectedScript {
+				g.Expect(script).To(ContainSubstring(s))
+			}
+		})
+	}
+}
@@ -28,12 +28,15 @@ import (
 // The interfaces are broken down like this to group functions together.
 type Service struct {
 	SSHClient ssh.Interface
+	// Strict makes the conversion fail on the cloud config modules that are not supported, instead of skipping them.
+	Strict bool
 }
 
 // NewService returns a new service.
-func NewService(sshClient ssh.Interface) *Service {
+func NewService(sshClient ssh.Interface, strict bool) *Service {
 	return &Service{
 		SSHClient: sshClient,
+		Strict:    strict,
 	}
 }
 
@@ -45,7 +48,7 @@ func (s *Service) RawBootstrapDataToProvisioningCommands(config []byte) ([]comma
 	}
 
 	// parse the cloud config yaml into a slice of cloud config actions.
-	actions, err := getActions(s.SSHClient, config)
+	actions, err := getActions(s.SSHClient, config, s.Strict)
 	if err != nil {
 		return nil, err
 	}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 
 	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
 
 	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
 )
@@ -34,7 +35,20 @@ func newUnknown(module string) action {
 }
 
 // Unmarshal will unmarshal unknown actions and slurp the value.
+// The value of a module can be any yaml value; it is kept as a single line unless it is a string or a list of strings.
 func (u *unknown) Unmarshal(data []byte) error {
+	// extract the value of the module from the cloud config block
+	m := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &m); err == nil {
+		if v, ok := m[u.module]; ok {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			data = b
+		}
+	}
+
 	// try unmarshalling to a slice of strings
 	var s1 []string
 	if err := json.Unmarshal(data, &s1); err != nil {
@@ -49,7 +63,10 @@ func (u *unknown) Unmarshal(data []byte) error {
 	// If it's not a slice of strings it should be one string value
 	var s2 string
 	if err := json.Unmarshal(data, &s2); err != nil {
-		return errors.WithStack(err)
+		if _, ok := err.(*json.UnmarshalTypeError); !ok {
+			return errors.WithStack(err)
+		}
+		s2 = string(data)
 	}
 
 	u.lines = []string{s2}
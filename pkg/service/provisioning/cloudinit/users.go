@@ -0,0 +1,181 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+)
+
+const sudoersFile = "/etc/sudoers.d/90-cloud-init-users"
+
+// usersAction defines a list of users that should be created on a node, replicating the cloud-init users module.
+type usersAction struct {
+	Users []user `json:"users,"`
+}
+
+type user struct {
+	Name              string     `json:"name,"`
+	Passwd            string     `json:"passwd,omitempty"`
+	Gecos             string     `json:"gecos,omitempty"`
+	Groups            stringList `json:"groups,omitempty"`
+	HomeDir           string     `json:"homedir,omitempty"`
+	Inactive          bool       `json:"inactive,omitempty"`
+	LockPassword      *bool      `json:"lock_passwd,omitempty"`
+	Shell             string     `json:"shell,omitempty"`
+	PrimaryGroup      string     `json:"primary_group,omitempty"`
+	Sudo              stringList `json:"sudo,omitempty"`
+	SSHAuthorizedKeys []string   `json:"ssh_authorized_keys,omitempty"`
+}
+
+// UnmarshalJSON a user.
+// It can be either a mapping or a string; the "default" string refers to the distro default user and is ignored.
+func (u *user) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		u.Name = name
+		return nil
+	}
+
+	type plain user
+	return json.Unmarshal(data, (*plain)(u))
+}
+
+// stringList is a value that can be defined either as a string or as a list of strings.
+// A false or null value results in an empty list.
+type stringList []string
+
+// UnmarshalJSON a stringList.
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var s1 []string
+	if err := json.Unmarshal(data, &s1); err == nil {
+		*l = s1
+		return nil
+	}
+
+	var s2 string
+	if err := json.Unmarshal(data, &s2); err == nil {
+		*l = []string{s2}
+		return nil
+	}
+
+	var b bool
+	if err := json.Unmarshal(data, &b); err != nil || b {
+		return errors.Errorf("invalid value %s, expected a string or a list of strings", data)
+	}
+	*l = nil
+	return nil
+}
+
+func newUsersAction() action {
+	return &usersAction{}
+}
+
+// Unmarshal the usersAction.
+func (a *usersAction) Unmarshal(userData []byte) error {
+	if err := yaml.Unmarshal(userData, a); err != nil {
+		return errors.Wrapf(err, "error parsing users action: %s", userData)
+	}
+	return nil
+}
+
+// Commands returns the commands creating the users.
+// NB. cloud-init locks the password of new users by default; this is not replicated here, because the users
+// may already exist on the node and be used by KubeKey itself, so the password is locked only if lock_passwd is true.
+func (a *usersAction) Commands() ([]commands.Cmd, error) {
+	cmds := make([]commands.Cmd, 0)
+	for _, u := range a.Users {
+		for _, name := range splitList(u.Name) {
+			if name == "default" {
+				continue
+			}
+			cmds = append(cmds, u.commands(name)...)
+		}
+	}
+	return cmds, nil
+}
+
+func (u user) commands(name string) []commands.Cmd {
+	cmds := make([]commands.Cmd, 0)
+
+	groups := make([]string, 0)
+	for _, g := range u.Groups {
+		groups = append(groups, splitList(g)...)
+	}
+	for _, g := range append([]string{u.PrimaryGroup}, groups...) {
+		if g == "" {
+			continue
+		}
+		cmds = append(cmds, commands.Cmd{Cmd: "/bin/sh", Args: []string{"-c",
+			fmt.Sprintf("getent group %s >/dev/null || groupadd %s", commands.Quote(g), commands.Quote(g))}})
+	}
+
+	cmds = append(cmds, commands.UserCommands(commands.User{
+		Name:         name,
+		Gecos:        u.Gecos,
+		HomeDir:      u.HomeDir,
+		PrimaryGroup: u.PrimaryGroup,
+		Shell:        u.Shell,
+		Groups:       groups,
+		PasswordHash: u.Passwd,
+	})...)
+	if u.LockPassword != nil && *u.LockPassword {
+		cmds = append(cmds, commands.Cmd{Cmd: "passwd", Args: []string{"-l", name}})
+	}
+	if u.Inactive {
+		cmds = append(cmds, commands.Cmd{Cmd: "usermod", Args: []string{"--expiredate", "1", name}})
+	}
+
+	if len(u.Sudo) > 0 {
+		var script strings.Builder
+		fmt.Fprintf(&script, "mkdir -p %s\n", filepath.Dir(sudoersFile))
+		for _, rule := range u.Sudo {
+			line := commands.Quote(name + " " + rule)
+			fmt.Fprintf(&script, "grep -qxF %s %s 2>/dev/null || echo %s >> %s\n", line, sudoersFile, line, sudoersFile)
+		}
+		fmt.Fprintf(&script, "chmod 0440 %s\n", sudoersFile)
+		cmds = append(cmds, commands.Script(script.String()))
+	}
+
+	if len(u.SSHAuthorizedKeys) > 0 {
+		cmds = append(cmds, commands.AuthorizedKeysCommands(name, u.HomeDir, u.SSHAuthorizedKeys)...)
+	}
+	return cmds
+}
+
+// Run runs the commands.
+func (a *usersAction) Run() error {
+	return nil
+}
+
+// splitList splits a comma separated list, trimming the spaces around the items.
+func splitList(s string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,119 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+)
+
+func TestUsersUnmarshal(t *testing.T) {
+	g := NewWithT(t)
+
+	cloudData := `
+users:
+- default
+- name: foo
+  groups: adm, docker
+  sudo: ALL=(ALL) NOPASSWD:ALL
+  lock_passwd: true
+- name: bar
+  groups: [ wheel ]
+  sudo: false`
+	a := usersAction{}
+	g.Expect(a.Unmarshal([]byte(cloudData))).To(Succeed())
+	g.Expect(a.Users).To(HaveLen(3))
+	g.Expect(a.Users[0].Name).To(Equal("default"))
+	g.Expect(a.Users[1].Groups).To(Equal(stringList{"adm, docker"}))
+	g.Expect(a.Users[1].Sudo).To(Equal(stringList{"ALL=(ALL) NOPASSWD:ALL"}))
+	g.Expect(*a.Users[1].LockPassword).To(BeTrue())
+	g.Expect(a.Users[2].Groups).To(Equal(stringList{"wheel"}))
+	g.Expect(a.Users[2].Sudo).To(BeEmpty())
+}
+
+func TestUsers(t *testing.T) {
+	lock := true
+	var useCases = []struct {
+		name            string
+		u               usersAction
+		expectedCmds    []commands.Cmd
+		expectedScripts []string
+		expectedKeyCmds []commands.Cmd
+	}{
+		{
+			name: "default user is skipped",
+			u: usersAction{
+				Users: []user{{Name: "default"}},
+			},
+			expectedCmds: []commands.Cmd{},
+		},
+		{
+			name: "user with groups and locked password",
+			u: usersAction{
+				Users: []user{{Name: "foo", Shell: "/bin/bash", PrimaryGroup: "foo", Groups: stringList{"adm, docker"}, LockPassword: &lock}},
+			},
+			expectedCmds: []commands.Cmd{
+				{Cmd: "/bin/sh", Args: []string{"-c", "getent group 'foo' >/dev/null || groupadd 'foo'"}},
+				{Cmd: "/bin/sh", Args: []string{"-c", "getent group 'adm' >/dev/null || groupadd 'adm'"}},
+				{Cmd: "/bin/sh", Args: []string{"-c", "getent group 'docker' >/dev/null || groupadd 'docker'"}},
+				{Cmd: "/bin/sh", Args: []string{"-c", "id -u 'foo' >/dev/null 2>&1 || useradd -m -g 'foo' -s '/bin/bash' 'foo'"}},
+				{Cmd: "usermod", Args: []string{"-a", "-G", "adm,docker", "foo"}},
+				{Cmd: "passwd", Args: []string{"-l", "foo"}},
+			},
+		},
+		{
+			name: "password, sudo and ssh keys",
+			u: usersAction{
+				Users: []user{{Name: "root", Passwd: "$6$salt$hash", Sudo: stringList{"ALL=(ALL) ALL"}, SSHAuthorizedKeys: []string{"ssh-rsa AAAA foo@bar"}}},
+			},
+			expectedCmds: []commands.Cmd{
+				{Cmd: "/bin/sh", Args: []string{"-c", "id -u 'root' >/dev/null 2>&1 || useradd -m 'root'"}},
+				{Cmd: "/bin/sh", Args: []string{"-c", "echo 'cm9vdDokNiRzYWx0JGhhc2g=' | base64 -d | chpasswd -e"}},
+			},
+			expectedScripts: []string{
+				"grep -qxF 'root ALL=(ALL) ALL' /etc/sudoers.d/90-cloud-init-users 2>/dev/null || echo 'root ALL=(ALL) ALL' >> /etc/sudoers.d/90-cloud-init-users\n",
+			},
+			expectedKeyCmds: []commands.Cmd{
+				{Cmd: "mkdir", Args: []string{"-p", "/root/.ssh"}},
+				{Cmd: "/bin/sh", Args: []string{"-c", "echo 'c3NoLXJzYSBBQUFBIGZvb0BiYXIK' | base64 -d >> /root/.ssh/authorized_keys"}},
+				{Cmd: "chmod", Args: []string{"0700", "/root/.ssh"}},
+				{Cmd: "chmod", Args: []string{"0600", "/root/.ssh/authorized_keys"}},
+				{Cmd: "chown", Args: []string{"-R", "root:", "/root/.ssh"}},
+			},
+		},
+	}
+
+	for _, rt := range useCases {
+		t.Run(rt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cmds, err := rt.u.Commands()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(cmds).To(HaveLen(len(rt.expectedCmds) + len(rt.expectedScripts) + len(rt.expectedKeyCmds)))
+			g.Expect(cmds[:len(rt.expectedCmds)]).To(Equal(rt.expectedCmds))
+			for i, s := range rt.expectedScripts {
+				g.Expect(decodeScript(g, cmds[len(rt.expectedCmds)+i])).To(ContainSubstring(s))
+			}
+			if len(rt.expectedKeyCmds) > 0 {
+				g.Expect(cmds[len(rt.expectedCmds)+len(rt.expectedScripts):]).To(Equal(rt.expectedKeyCmds))
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+)
+
+const yumReposDir = "/etc/yum.repos.d"
+
+// yumReposAction defines a list of yum repositories, replicating the cloud-init yum_add_repo module.
+// As in cloud-init, an existing repository file is never overwritten.
+type yumReposAction struct {
+	Repos map[string]map[string]interface{} `json:"yum_repos,"`
+}
+
+func newYumReposAction() action {
+	return &yumReposAction{}
+}
+
+// Unmarshal the yumReposAction.
+func (a *yumReposAction) Unmarshal(userData []byte) error {
+	if err := yaml.Unmarshal(userData, a); err != nil {
+		return errors.Wrapf(err, "error parsing yum_repos action: %s", userData)
+	}
+	return nil
+}
+
+// Commands returns the commands writing the repository files.
+func (a *yumReposAction) Commands() ([]commands.Cmd, error) {
+	ids := make([]string, 0, len(a.Repos))
+	for id := range a.Repos {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	cmds := make([]commands.Cmd, 0)
+	for _, id := range ids {
+		repoID := strings.ReplaceAll(strings.TrimSpace(id), " ", "_")
+		if repoID == "" || strings.Contains(repoID, "/") {
+			return nil, errors.Errorf("invalid yum repository id %q", id)
+		}
+		repo := a.Repos[id]
+		if _, ok := repo["baseurl"]; !ok {
+			if _, ok := repo["metalink"]; !ok {
+				if _, ok := repo["mirrorlist"]; !ok {
+					return nil, errors.Errorf("yum repository %q requires a baseurl, metalink or mirrorlist", id)
+				}
+			}
+		}
+
+		keys := make([]string, 0, len(repo))
+		for k := range repo {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var content strings.Builder
+		fmt.Fprintf(&content, "[%s]\n", repoID)
+		if _, ok := repo["name"]; !ok {
+			fmt.Fprintf(&content, "name=%s\n", repoID)
+		}
+		for _, k := range keys {
+			fmt.Fprintf(&content, "%s=%s\n", strings.ReplaceAll(k, "-", "_"), formatRepoValue(repo[k]))
+		}
+
+		path := filepath.Join(yumReposDir, repoID+".repo")
+		write := commands.WriteFile([]byte(content.String()), ">", path)
+		cmds = append(cmds,
+			commands.Cmd{Cmd: "mkdir", Args: []string{"-p", yumReposDir}},
+			commands.Cmd{Cmd: "/bin/sh", Args: []string{"-c", fmt.Sprintf("[ -f %s ] || %s", path, write.Args[1])}},
+		)
+	}
+	return cmds, nil
+}
+
+// formatRepoValue formats a value as cloud-init does: booleans as 1 or 0 and lists as multiple lines.
+func formatRepoValue(v interface{}) string {
+	switch value := v.(type) {
+	case bool:
+		if value {
+			return "1"
+		}
+		return "0"
+	case []interface{}:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			items = append(items, formatRepoValue(item))
+		}
+		return strings.Join(items, "\n    ")
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// Run runs the commands.
+func (a *yumReposAction) Run() error {
+	return nil
+}
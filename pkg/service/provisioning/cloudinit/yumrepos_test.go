@@ -0,0 +1,62 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+)
+
+func TestYumRepos(t *testing.T) {
+	g := NewWithT(t)
+
+	cloudData := `
+yum_repos:
+  kubernetes:
+    baseurl: https://example.com/kubernetes/el7-x86_64
+    enabled: true
+    gpgcheck: false
+    gpgkey:
+    - https://example.com/yum-key.gpg
+    - https://example.com/rpm-package-key.gpg`
+	a := yumReposAction{}
+	g.Expect(a.Unmarshal([]byte(cloudData))).To(Succeed())
+
+	cmds, err := a.Commands()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	repo := `[kubernetes]
+name=kubernetes
+baseurl=https://example.com/kubernetes/el7-x86_64
+enabled=1
+gpgcheck=0
+gpgkey=https://example.com/yum-key.gpg
+    https://example.com/rpm-package-key.gpg
+`
+	write := commands.WriteFile([]byte(repo), ">", "/etc/yum.repos.d/kubernetes.repo")
+	g.Expect(cmds).To(Equal([]commands.Cmd{
+		{Cmd: "mkdir", Args: []string{"-p", "/etc/yum.repos.d"}},
+		{Cmd: "/bin/sh", Args: []string{"-c", "[ -f /etc/yum.repos.d/kubernetes.repo ] || " + write.Args[1]}},
+	}))
+
+	a = yumReposAction{Repos: map[string]map[string]interface{}{"foo": {"enabled": true}}}
+	_, err = a.Commands()
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,49 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// The commands are executed through an unquoted heredoc (see ssh.SudoPrefix), so any user provided
+// content or shell variable is transferred in base64 to keep it from being expanded by the remote shell.
+
+// WriteFile returns a command writing the given data to the path, redirects is either ">" or ">>".
+func WriteFile(data []byte, redirects, path string) Cmd {
+	return Cmd{Cmd: "/bin/sh", Args: []string{"-c",
+		fmt.Sprintf("echo '%s' | base64 -d %s %s", base64.StdEncoding.EncodeToString(data), redirects, path)}}
+}
+
+// Script returns a command running the given shell script, which stops at the first error.
+func Script(script string) Cmd {
+	return Cmd{Cmd: "/bin/sh", Args: []string{"-c",
+		fmt.Sprintf("echo '%s' | base64 -d | /bin/sh", base64.StdEncoding.EncodeToString([]byte("set -e\n"+script)))}}
+}
+
+// SetPasswordHash returns a command setting the already hashed password of the user.
+func SetPasswordHash(name, hash string) Cmd {
+	return Cmd{Cmd: "/bin/sh", Args: []string{"-c",
+		fmt.Sprintf("echo '%s' | base64 -d | chpasswd -e", base64.StdEncoding.EncodeToString([]byte(name+":"+hash)))}}
+}
+
+// Quote quotes the string for the shell.
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
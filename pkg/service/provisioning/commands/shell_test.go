@@ -0,0 +1,67 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWriteFile(t *testing.T) {
+	g := NewWithT(t)
+
+	cmd := WriteFile([]byte("$HOME 'quoted'\n"), ">", "/foo")
+	g.Expect(cmd).To(Equal(Cmd{Cmd: "/bin/sh", Args: []string{"-c", "echo 'JEhPTUUgJ3F1b3RlZCcK' | base64 -d > /foo"}}))
+}
+
+func TestSetPasswordHash(t *testing.T) {
+	g := NewWithT(t)
+
+	cmd := SetPasswordHash("root", "$6$salt$hash")
+	g.Expect(cmd).To(Equal(Cmd{Cmd: "/bin/sh", Args: []string{"-c", "echo 'cm9vdDokNiRzYWx0JGhhc2g=' | base64 -d | chpasswd -e"}}))
+}
+
+func TestQuote(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(Quote("it's $HOME")).To(Equal(`'it'\''s $HOME'`))
+}
+
+func TestUserCommands(t *testing.T) {
+	g := NewWithT(t)
+
+	cmds := UserCommands(User{Name: "core", Shell: "/bin/bash", Groups: []string{"wheel", "docker"}, PasswordHash: "$6$salt$hash"})
+	g.Expect(cmds).To(Equal([]Cmd{
+		{Cmd: "/bin/sh", Args: []string{"-c", "id -u 'core' >/dev/null 2>&1 || useradd -m -s '/bin/bash' 'core'"}},
+		{Cmd: "usermod", Args: []string{"-a", "-G", "wheel,docker", "core"}},
+		SetPasswordHash("core", "$6$salt$hash"),
+	}))
+}
+
+func TestAuthorizedKeysCommands(t *testing.T) {
+	g := NewWithT(t)
+
+	cmds := AuthorizedKeysCommands("core", "", []string{"ssh-rsa AAAA foo@bar\n", "ssh-ed25519 BBBB $USER@baz"})
+	g.Expect(cmds).To(Equal([]Cmd{
+		{Cmd: "mkdir", Args: []string{"-p", "/home/core/.ssh"}},
+		WriteFile([]byte("ssh-rsa AAAA foo@bar\nssh-ed25519 BBBB $USER@baz\n"), ">>", "/home/core/.ssh/authorized_keys"),
+		{Cmd: "chmod", Args: []string{"0700", "/home/core/.ssh"}},
+		{Cmd: "chmod", Args: []string{"0600", "/home/core/.ssh/authorized_keys"}},
+		{Cmd: "chown", Args: []string{"-R", "core:", "/home/core/.ssh"}},
+	}))
+}
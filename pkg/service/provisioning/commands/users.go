@@ -0,0 +1,90 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// User defines a user to create on a node, as in the cloud-init users module and the Ignition passwd section.
+type User struct {
+	Name         string
+	Gecos        string
+	HomeDir      string
+	PrimaryGroup string
+	Shell        string
+	Groups       []string
+	PasswordHash string
+}
+
+// UserCommands returns the commands creating the user if it does not exist, adding it to its supplementary
+// groups and setting its password. The groups must exist.
+func UserCommands(u User) []Cmd {
+	cmds := make([]Cmd, 0)
+
+	args := []string{"useradd", "-m"}
+	if u.Gecos != "" {
+		args = append(args, "-c", Quote(u.Gecos))
+	}
+	if u.HomeDir != "" {
+		args = append(args, "-d", Quote(u.HomeDir))
+	}
+	if u.PrimaryGroup != "" {
+		args = append(args, "-g", Quote(u.PrimaryGroup))
+	}
+	if u.Shell != "" {
+		args = append(args, "-s", Quote(u.Shell))
+	}
+	args = append(args, Quote(u.Name))
+	cmds = append(cmds, Cmd{Cmd: "/bin/sh", Args: []string{"-c",
+		fmt.Sprintf("id -u %s >/dev/null 2>&1 || %s", Quote(u.Name), strings.Join(args, " "))}})
+
+	if len(u.Groups) > 0 {
+		cmds = append(cmds, Cmd{Cmd: "usermod", Args: []string{"-a", "-G", strings.Join(u.Groups, ","), u.Name}})
+	}
+	if u.PasswordHash != "" {
+		cmds = append(cmds, SetPasswordHash(u.Name, u.PasswordHash))
+	}
+	return cmds
+}
+
+// AuthorizedKeysCommands returns the commands appending the keys to the authorized_keys of the user.
+// The home directory defaults to /home/<name>, or /root for root.
+func AuthorizedKeysCommands(name, homeDir string, keys []string) []Cmd {
+	if homeDir == "" {
+		homeDir = filepath.Join("/home", name)
+		if name == "root" {
+			homeDir = "/root"
+		}
+	}
+	sshDir := filepath.Join(homeDir, ".ssh")
+	keysFile := filepath.Join(sshDir, "authorized_keys")
+
+	var content strings.Builder
+	for _, key := range keys {
+		content.WriteString(strings.TrimSpace(key) + "\n")
+	}
+	return []Cmd{
+		{Cmd: "mkdir", Args: []string{"-p", sshDir}},
+		WriteFile([]byte(content.String()), ">>", keysFile),
+		{Cmd: "chmod", Args: []string{"0700", sshDir}},
+		{Cmd: "chmod", Args: []string{"0600", keysFile}},
+		{Cmd: "chown", Args: []string{"-R", name + ":", sshDir}},
+	}
+}
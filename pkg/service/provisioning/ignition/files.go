@@ -0,0 +1,190 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ignition
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+)
+
+const (
+	defaultFileMode = 0644
+	defaultOwner    = "root:root"
+)
+
+// filesCommands returns the commands replicating the Ignition storage.files section.
+func filesCommands(files []File) ([]commands.Cmd, error) {
+	cmds := make([]commands.Cmd, 0)
+	for _, f := range files {
+		path := strings.TrimSpace(f.Path)
+		cmds = append(cmds, commands.Cmd{Cmd: "mkdir", Args: []string{"-p", filepath.Dir(path)}})
+
+		appends, appendContents, err := fixAppend(f.Append)
+		if err != nil {
+			return cmds, errors.Wrapf(err, "error parsing append for %s", path)
+		}
+
+		redirects := ">"
+		if appendContents {
+			redirects = ">>"
+		}
+		if f.Contents.Source != nil {
+			cmd, err := resourceCommand(f.Contents, redirects, path)
+			if err != nil {
+				return cmds, errors.Wrapf(err, "error decoding content for %s", path)
+			}
+			cmds = append(cmds, cmd)
+		} else {
+			cmds = append(cmds, commands.Cmd{Cmd: "touch", Args: []string{path}})
+		}
+		for _, r := range appends {
+			cmd, err := resourceCommand(r, ">>", path)
+			if err != nil {
+				return cmds, errors.Wrapf(err, "error decoding appended content for %s", path)
+			}
+			cmds = append(cmds, cmd)
+		}
+
+		// if permissions are different than default ownership, add a command to modify the permissions.
+		if f.Mode != nil && *f.Mode != defaultFileMode {
+			cmds = append(cmds, commands.Cmd{Cmd: "chmod", Args: []string{fmt.Sprintf("%04o", *f.Mode), path}})
+		}
+
+		// if ownership is different than default ownership, add a command to modify file ownership.
+		if owner := fixOwner(f.User, f.Group); owner != defaultOwner {
+			cmds = append(cmds, commands.Cmd{Cmd: "chown", Args: []string{owner, path}})
+		}
+	}
+	return cmds, nil
+}
+
+// fixAppend returns the appended resources of the spec v3.x, or whether the contents are appended in the spec v2.x.
+func fixAppend(raw json.RawMessage) ([]Resource, bool, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, false, nil
+	}
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return nil, b, nil
+	}
+	var resources []Resource
+	if err := json.Unmarshal(raw, &resources); err != nil {
+		return nil, false, err
+	}
+	return resources, false, nil
+}
+
+func fixOwner(user, group NodeUser) string {
+	u, g := "root", "root"
+	if user.Name != "" {
+		u = user.Name
+	} else if user.ID != nil {
+		u = strconv.Itoa(*user.ID)
+	}
+	if group.Name != "" {
+		g = group.Name
+	} else if group.ID != nil {
+		g = strconv.Itoa(*group.ID)
+	}
+	if u == "0" {
+		u = "root"
+	}
+	if g == "0" {
+		g = "root"
+	}
+	return u + ":" + g
+}
+
+// resourceCommand returns a command writing the resource to the path.
+// The inline data is transferred in base64, so that it is not interpreted by the remote shell.
+func resourceCommand(r Resource, redirects, path string) (commands.Cmd, error) {
+	source := ""
+	if r.Source != nil {
+		source = strings.TrimSpace(*r.Source)
+	}
+	gz := r.Compression != nil && *r.Compression == "gzip"
+
+	u, err := url.Parse(source)
+	if err != nil {
+		return commands.Cmd{}, err
+	}
+	switch u.Scheme {
+	case "http", "https":
+		cmd := fmt.Sprintf("curl -fsSL '%s'", source)
+		if gz {
+			cmd += " | gunzip"
+		}
+		return commands.Cmd{Cmd: "/bin/sh", Args: []string{"-c", fmt.Sprintf("%s %s %s", cmd, redirects, path)}}, nil
+	case "data", "":
+		data, err := decodeDataURL(source)
+		if err != nil {
+			return commands.Cmd{}, err
+		}
+		if gz {
+			if data, err = gUnzipData(data); err != nil {
+				return commands.Cmd{}, err
+			}
+		}
+		return commands.WriteFile(data, redirects, path), nil
+	default:
+		return commands.Cmd{}, errors.Errorf("unsupported source scheme %q", u.Scheme)
+	}
+}
+
+// decodeDataURL decodes the RFC 2397 data URL.
+func decodeDataURL(source string) ([]byte, error) {
+	if source == "" {
+		return []byte{}, nil
+	}
+	if !strings.HasPrefix(source, "data:") {
+		return nil, errors.Errorf("invalid data url %q", source)
+	}
+	i := strings.Index(source, ",")
+	if i < 0 {
+		return nil, errors.Errorf("invalid data url %q", source)
+	}
+	meta, data := source[len("data:"):i], source[i+1:]
+	if strings.HasSuffix(meta, ";base64") {
+		return base64.StdEncoding.DecodeString(data)
+	}
+	s, err := url.PathUnescape(data)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+func gUnzipData(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
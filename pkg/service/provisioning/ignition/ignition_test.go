@@ -0,0 +1,127 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ignition
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestRawBootstrapDataToProvisioningCommands(t *testing.T) {
+	var useCases = []struct {
+		name string
+	}{
+		{name: "v2"},
+		{name: "v3"},
+	}
+
+	for _, rt := range useCases {
+		t.Run(rt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config, err := os.ReadFile(filepath.Join("testdata", rt.name+".ign"))
+			g.Expect(err).NotTo(HaveOccurred())
+
+			cmds, err := NewService(nil).RawBootstrapDataToProvisioningCommands(config)
+			g.Expect(err).NotTo(HaveOccurred())
+			var actual bytes.Buffer
+			enc := json.NewEncoder(&actual)
+			enc.SetEscapeHTML(false)
+			enc.SetIndent("", "  ")
+			g.Expect(enc.Encode(cmds)).To(Succeed())
+
+			golden := filepath.Join("testdata", rt.name+".golden")
+			if *update {
+				g.Expect(os.WriteFile(golden, actual.Bytes(), 0600)).To(Succeed())
+			}
+			expected, err := os.ReadFile(golden)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(actual.String()).To(Equal(string(expected)))
+		})
+	}
+}
+
+func TestRawBootstrapDataToProvisioningCommandsError(t *testing.T) {
+	var useCases = []struct {
+		name   string
+		config string
+	}{
+		{
+			name:   "invalid json",
+			config: "#cloud-config\nruncmd: []",
+		},
+		{
+			name:   "missing version",
+			config: `{"storage": {"files": [{"path": "/foo", "contents": {"source": "data:,bar"}}]}}`,
+		},
+		{
+			name:   "unsupported source",
+			config: `{"ignition": {"version": "3.3.0"}, "storage": {"files": [{"path": "/foo", "contents": {"source": "s3://bucket/bar"}}]}}`,
+		},
+	}
+
+	for _, rt := range useCases {
+		t.Run(rt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := NewService(nil).RawBootstrapDataToProvisioningCommands([]byte(rt.config))
+			g.Expect(err).To(HaveOccurred())
+		})
+	}
+}
+
+func TestDecodeDataURL(t *testing.T) {
+	var useCases = []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "url encoded",
+			source:   "data:,foo%20bar%0A",
+			expected: "foo bar\n",
+		},
+		{
+			name:     "base64",
+			source:   "data:text/plain;charset=utf-8;base64,YWJjMTIzIT8kKiYoKSctPUB+",
+			expected: "abc123!?$*&()'-=@~",
+		},
+		{
+			name:     "empty",
+			source:   "data:,",
+			expected: "",
+		},
+	}
+
+	for _, rt := range useCases {
+		t.Run(rt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			data, err := decodeDataURL(rt.source)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(data)).To(Equal(rt.expected))
+		})
+	}
+}
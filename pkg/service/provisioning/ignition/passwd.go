@@ -0,0 +1,45 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ignition
+
+import (
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+)
+
+// usersCommands returns the commands replicating the Ignition passwd.users section.
+func usersCommands(users []User) []commands.Cmd {
+	cmds := make([]commands.Cmd, 0)
+	for _, u := range users {
+		user := commands.User{
+			Name:         u.Name,
+			Gecos:        u.Gecos,
+			HomeDir:      u.HomeDir,
+			PrimaryGroup: u.PrimaryGroup,
+			Shell:        u.Shell,
+			Groups:       u.Groups,
+		}
+		if u.PasswordHash != nil {
+			user.PasswordHash = *u.PasswordHash
+		}
+		cmds = append(cmds, commands.UserCommands(user)...)
+
+		if len(u.SSHAuthorizedKeys) > 0 {
+			cmds = append(cmds, commands.AuthorizedKeysCommands(u.Name, u.HomeDir, u.SSHAuthorizedKeys)...)
+		}
+	}
+	return cmds
+}
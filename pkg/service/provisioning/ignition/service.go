@@ -0,0 +1,63 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ignition
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/kubesphere/kubekey/v3/pkg/clients/ssh"
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+)
+
+// Service holds a collection of interfaces.
+// The interfaces are broken down like this to group functions together.
+type Service struct {
+	SSHClient ssh.Interface
+}
+
+// NewService returns a new service.
+func NewService(sshClient ssh.Interface) *Service {
+	return &Service{
+		SSHClient: sshClient,
+	}
+}
+
+// RawBootstrapDataToProvisioningCommands converts raw bootstrap data to provisioning commands.
+// The commands follow the order of the Ignition stages: users, files and then systemd units.
+func (s *Service) RawBootstrapDataToProvisioningCommands(config []byte) ([]commands.Cmd, error) {
+	c := &Config{}
+	if err := json.Unmarshal(config, c); err != nil {
+		return nil, errors.Wrap(err, "ignition config is not valid json")
+	}
+	if c.Ignition.Version == "" {
+		return nil, errors.New("ignition config version is missing")
+	}
+
+	cmds := make([]commands.Cmd, 0)
+	cmds = append(cmds, usersCommands(c.Passwd.Users)...)
+
+	filesCmds, err := filesCommands(c.Storage.Files)
+	if err != nil {
+		return cmds, err
+	}
+	cmds = append(cmds, filesCmds...)
+
+	cmds = append(cmds, unitsCommands(c.Systemd.Units)...)
+	return cmds, nil
+}
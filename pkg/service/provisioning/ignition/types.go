@@ -0,0 +1,97 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ignition
+
+import "encoding/json"
+
+// Config is the subset of the Ignition config, which is compatible with the spec v2.x and v3.x.
+type Config struct {
+	Ignition Ignition `json:"ignition"`
+	Passwd   Passwd   `json:"passwd,omitempty"`
+	Storage  Storage  `json:"storage,omitempty"`
+	Systemd  Systemd  `json:"systemd,omitempty"`
+}
+
+// Ignition is the metadata of the Ignition config.
+type Ignition struct {
+	Version string `json:"version"`
+}
+
+// Passwd is the users section of the Ignition config.
+type Passwd struct {
+	Users []User `json:"users,omitempty"`
+}
+
+// User is a user to create on the node.
+type User struct {
+	Name              string   `json:"name"`
+	PasswordHash      *string  `json:"passwordHash,omitempty"`
+	SSHAuthorizedKeys []string `json:"sshAuthorizedKeys,omitempty"`
+	Gecos             string   `json:"gecos,omitempty"`
+	Groups            []string `json:"groups,omitempty"`
+	HomeDir           string   `json:"homeDir,omitempty"`
+	PrimaryGroup      string   `json:"primaryGroup,omitempty"`
+	Shell             string   `json:"shell,omitempty"`
+}
+
+// Storage is the storage section of the Ignition config.
+type Storage struct {
+	Files []File `json:"files,omitempty"`
+}
+
+// File is a file to write on the node.
+type File struct {
+	Path     string   `json:"path"`
+	Contents Resource `json:"contents,omitempty"`
+	Mode     *int     `json:"mode,omitempty"`
+	User     NodeUser `json:"user,omitempty"`
+	Group    NodeUser `json:"group,omitempty"`
+	// Append is a bool in the spec v2.x and a list of resources in the spec v3.x.
+	Append json.RawMessage `json:"append,omitempty"`
+}
+
+// Resource is the source of a file content.
+type Resource struct {
+	Source      *string `json:"source,omitempty"`
+	Compression *string `json:"compression,omitempty"`
+}
+
+// NodeUser is the owner user or group of a file.
+type NodeUser struct {
+	ID   *int   `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// Systemd is the systemd section of the Ignition config.
+type Systemd struct {
+	Units []Unit `json:"units,omitempty"`
+}
+
+// Unit is a systemd unit on the node.
+type Unit struct {
+	Name     string   `json:"name"`
+	Enabled  *bool    `json:"enabled,omitempty"`
+	Mask     *bool    `json:"mask,omitempty"`
+	Contents *string  `json:"contents,omitempty"`
+	Dropins  []Dropin `json:"dropins,omitempty"`
+}
+
+// Dropin is a systemd unit drop-in.
+type Dropin struct {
+	Name     string  `json:"name"`
+	Contents *string `json:"contents,omitempty"`
+}
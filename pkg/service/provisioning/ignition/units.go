@@ -0,0 +1,71 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ignition
+
+import (
+	"path/filepath"
+
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+)
+
+const systemdDir = "/etc/systemd/system"
+
+// unitsCommands returns the commands replicating the Ignition systemd.units section.
+// The enabled units are (re)started after all units are written, as the node is already booted.
+func unitsCommands(units []Unit) []commands.Cmd {
+	cmds := make([]commands.Cmd, 0)
+	if len(units) == 0 {
+		return cmds
+	}
+
+	for _, u := range units {
+		if u.Contents != nil {
+			cmds = append(cmds,
+				commands.Cmd{Cmd: "mkdir", Args: []string{"-p", systemdDir}},
+				commands.WriteFile([]byte(*u.Contents), ">", filepath.Join(systemdDir, u.Name)),
+			)
+		}
+		for _, d := range u.Dropins {
+			if d.Contents == nil {
+				continue
+			}
+			dir := filepath.Join(systemdDir, u.Name+".d")
+			cmds = append(cmds,
+				commands.Cmd{Cmd: "mkdir", Args: []string{"-p", dir}},
+				commands.WriteFile([]byte(*d.Contents), ">", filepath.Join(dir, d.Name)),
+			)
+		}
+	}
+	cmds = append(cmds, commands.Cmd{Cmd: "systemctl", Args: []string{"daemon-reload"}})
+
+	var started []string
+	for _, u := range units {
+		switch {
+		case u.Mask != nil && *u.Mask:
+			cmds = append(cmds, commands.Cmd{Cmd: "systemctl", Args: []string{"mask", u.Name}})
+		case u.Enabled != nil && *u.Enabled:
+			cmds = append(cmds, commands.Cmd{Cmd: "systemctl", Args: []string{"enable", u.Name}})
+			started = append(started, u.Name)
+		case u.Enabled != nil && !*u.Enabled:
+			cmds = append(cmds, commands.Cmd{Cmd: "systemctl", Args: []string{"disable", u.Name}})
+		}
+	}
+	for _, name := range started {
+		cmds = append(cmds, commands.Cmd{Cmd: "systemctl", Args: []string{"restart", name}})
+	}
+	return cmds
+}
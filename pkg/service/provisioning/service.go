@@ -23,6 +23,7 @@ import (
 	"github.com/kubesphere/kubekey/v3/pkg/clients/ssh"
 	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/cloudinit"
 	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/commands"
+	"github.com/kubesphere/kubekey/v3/pkg/service/provisioning/ignition"
 )
 
 // Service holds a collection of interfaces.
@@ -32,11 +33,14 @@ type Service interface {
 }
 
 // NewService returns a new service given the cloud config format client.
-func NewService(sshClient ssh.Interface, format bootstrapv1.Format) Service {
+// strictCloudInit makes the cloud config conversion fail on unsupported modules.
+func NewService(sshClient ssh.Interface, format bootstrapv1.Format, strictCloudInit bool) Service {
 	switch format {
 	case bootstrapv1.CloudConfig:
-		return cloudinit.NewService(sshClient)
+		return cloudinit.NewService(sshClient, strictCloudInit)
+	case bootstrapv1.Ignition:
+		return ignition.NewService(sshClient)
 	default:
-		return cloudinit.NewService(sshClient)
+		return cloudinit.NewService(sshClient, strictCloudInit)
 	}
 }
@@ -0,0 +1,152 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package hostkey provides the SSH host key verification backed by a known_hosts file.
+package hostkey
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"k8s.io/client-go/util/homedir"
+)
+
+const (
+	// Strict only accepts the hosts whose key is recorded in the known_hosts file.
+	Strict = "strict"
+	// TOFU trusts the key of an unknown host on first use and records it into the known_hosts file.
+	TOFU = "tofu"
+	// Insecure accepts any host key.
+	Insecure = "insecure"
+
+	// DefaultKnownHostsFile is the known_hosts file used when none is specified.
+	DefaultKnownHostsFile = "~/.kube/kk_known_hosts"
+)
+
+// Modes is the list of the supported host key checking modes.
+var Modes = []string{Strict, TOFU, Insecure}
+
+// fileLocks serializes the writes into the same known_hosts file.
+var fileLocks sync.Map
+
+// Validate checks whether the host key checking mode is supported. An empty mode means Insecure.
+func Validate(mode string) error {
+	switch mode {
+	case "", Strict, TOFU, Insecure:
+		return nil
+	default:
+		return errors.Errorf("unsupported host key checking mode %q, must be one of %s", mode, strings.Join(Modes, ", "))
+	}
+}
+
+// Callback returns the ssh.HostKeyCallback of the mode. The knownHostsFile defaults to DefaultKnownHostsFile.
+func Callback(mode, knownHostsFile string) (ssh.HostKeyCallback, error) {
+	if err := Validate(mode); err != nil {
+		return nil, err
+	}
+	if mode == "" || mode == Insecure {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+
+	file := expand(knownHostsFile)
+	switch mode {
+	case Strict:
+		if _, err := os.Stat(file); err != nil {
+			return nil, errors.Wrapf(err, "known_hosts file %s is required by the %s host key checking", file, Strict)
+		}
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			return check(file, hostname, remote, key, false)
+		}, nil
+	default:
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			return check(file, hostname, remote, key, true)
+		}, nil
+	}
+}
+
+func check(file, hostname string, remote net.Addr, key ssh.PublicKey, trustOnFirstUse bool) error {
+	v, _ := fileLocks.LoadOrStore(file, &sync.Mutex{})
+	mu := v.(*sync.Mutex)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, err := os.Stat(file); os.IsNotExist(err) && trustOnFirstUse {
+		if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+			return errors.Wrapf(err, "create the directory of known_hosts file %s failed", file)
+		}
+		if err := os.WriteFile(file, nil, 0600); err != nil {
+			return errors.Wrapf(err, "create known_hosts file %s failed", file)
+		}
+	}
+
+	callback, err := knownhosts.New(file)
+	if err != nil {
+		return errors.Wrapf(err, "load known_hosts file %s failed", file)
+	}
+
+	err = callback(hostname, remote, key)
+	if err == nil {
+		return nil
+	}
+
+	var keyErr *knownhosts.KeyError
+	if !errors.As(err, &keyErr) {
+		return err
+	}
+	if len(keyErr.Want) > 0 {
+		want := keyErr.Want[0]
+		return errors.Errorf("host key mismatch for %s: the %s key %s offered by the host does not match the key recorded at %s:%d, "+
+			"the host may have been reinstalled or the connection is being intercepted. "+
+			"Remove the stale entry from the known_hosts file if the change is expected",
+			hostname, key.Type(), ssh.FingerprintSHA256(key), want.Filename, want.Line)
+	}
+	if !trustOnFirstUse {
+		return errors.Errorf("host key verification failed for %s: the %s key %s is not recorded in %s",
+			hostname, key.Type(), ssh.FingerprintSHA256(key), file)
+	}
+
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "open known_hosts file %s failed", file)
+	}
+	defer f.Close()
+
+	addresses := []string{knownhosts.Normalize(hostname)}
+	if remote != nil && knownhosts.Normalize(remote.String()) != addresses[0] {
+		addresses = append(addresses, knownhosts.Normalize(remote.String()))
+	}
+	if _, err := fmt.Fprintln(f, knownhosts.Line(addresses, key)); err != nil {
+		return errors.Wrapf(err, "record the host key of %s into %s failed", hostname, file)
+	}
+	return nil
+}
+
+func expand(file string) string {
+	if file == "" {
+		file = DefaultKnownHostsFile
+	}
+	if strings.HasPrefix(file, "~/") {
+		file = filepath.Join(homedir.HomeDir(), strings.TrimPrefix(file, "~/"))
+	}
+	return file
+}
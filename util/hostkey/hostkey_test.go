@@ -0,0 +1,107 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package hostkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func newPublicKey(t *testing.T) ssh.PublicKey {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key failed: %v", err)
+	}
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("convert key failed: %v", err)
+	}
+	return key
+}
+
+func TestCallback_TOFU(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".kube", "kk_known_hosts")
+	remote := &net.TCPAddr{IP: net.ParseIP("192.168.0.2"), Port: 22}
+	key := newPublicKey(t)
+
+	callback, err := Callback(TOFU, file)
+	if err != nil {
+		t.Fatalf("Callback() error = %v", err)
+	}
+	if err := callback("192.168.0.2:22", remote, key); err != nil {
+		t.Fatalf("first use error = %v", err)
+	}
+	if err := callback("192.168.0.2:22", remote, key); err != nil {
+		t.Fatalf("second use error = %v", err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("read known_hosts failed: %v", err)
+	}
+	if lines := strings.Count(string(content), "\n"); lines != 1 {
+		t.Errorf("known_hosts got %d lines, want 1", lines)
+	}
+
+	err = callback("192.168.0.2:22", remote, newPublicKey(t))
+	if err == nil || !strings.Contains(err.Error(), "host key mismatch") {
+		t.Errorf("mismatch error = %v, want host key mismatch", err)
+	}
+}
+
+func TestCallback_Strict(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "known_hosts")
+	if _, err := Callback(Strict, file); err == nil {
+		t.Fatalf("Callback() with a missing known_hosts file error = nil, want error")
+	}
+
+	remote := &net.TCPAddr{IP: net.ParseIP("192.168.0.2"), Port: 22}
+	key := newPublicKey(t)
+	tofu, _ := Callback(TOFU, file)
+	if err := tofu("192.168.0.2:22", remote, key); err != nil {
+		t.Fatalf("record key error = %v", err)
+	}
+
+	strict, err := Callback(Strict, file)
+	if err != nil {
+		t.Fatalf("Callback() error = %v", err)
+	}
+	if err := strict("192.168.0.2:22", remote, key); err != nil {
+		t.Errorf("known host error = %v", err)
+	}
+	other := &net.TCPAddr{IP: net.ParseIP("192.168.0.3"), Port: 22}
+	if err := strict("192.168.0.3:22", other, key); err == nil {
+		t.Errorf("unknown host error = nil, want error")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, mode := range []string{"", Strict, TOFU, Insecure} {
+		if err := Validate(mode); err != nil {
+			t.Errorf("Validate(%q) error = %v", mode, err)
+		}
+	}
+	if err := Validate("yes"); err == nil {
+		t.Errorf("Validate(\"yes\") error = nil, want error")
+	}
+}
@@ -0,0 +1,40 @@
+/*
+ Copyright 2022 The KubeSphere Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package version
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestParseFilesSha256(t *testing.T) {
+	m, err := ParseFilesSha256(Components)
+	if err != nil {
+		t.Fatalf("ParseFilesSha256() error = %v", err)
+	}
+
+	sha256 := regexp.MustCompile(`^[0-9a-f]{64}$`)
+	for id, arches := range m {
+		for arch, versions := range arches {
+			for v, sum := range versions {
+				if !sha256.MatchString(sum) {
+					t.Errorf("%s %s %s: invalid sha256 %q", id, arch, v, sum)
+				}
+			}
+		}
+	}
+}